@@ -4,9 +4,15 @@
 package hclogutils
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform-plugin-log/internal/fieldutils"
 )
 
+// MissingKey is the field key used by ArgsToFieldMap for a trailing value
+// without a key, matching go-hclog.
+const MissingKey = "EXTRA_VALUE_AT_END"
+
 // FieldMapsToArgs will shallow merge field maps into a slice of key/value pairs
 // arguments (i.e. `[k1, v1, k2, v2, ...]`) expected by hc-log.Logger methods.
 func FieldMapsToArgs(maps ...map[string]interface{}) []interface{} {
@@ -27,3 +33,33 @@ func FieldMapsToArgs(maps ...map[string]interface{}) []interface{} {
 		return FieldMapsToArgs(fieldutils.MergeFieldMaps(maps...))
 	}
 }
+
+// ArgsToFieldMap will convert a slice of key/value pairs arguments (i.e.
+// `[k1, v1, k2, v2, ...]`), as accepted by hc-log.Logger methods, into a field
+// map. Keys which are not strings are formatted with fmt.Sprint. A trailing
+// value without a key is stored under MissingKey.
+func ArgsToFieldMap(args ...interface{}) map[string]interface{} {
+	if len(args) == 0 {
+		return nil
+	}
+
+	result := make(map[string]interface{}, (len(args)+1)/2)
+
+	for i := 0; i < len(args); i += 2 {
+		if i+1 == len(args) {
+			result[MissingKey] = args[i]
+
+			break
+		}
+
+		key, ok := args[i].(string)
+
+		if !ok {
+			key = fmt.Sprint(args[i])
+		}
+
+		result[key] = args[i+1]
+	}
+
+	return result
+}
@@ -174,3 +174,67 @@ func TestFieldMapsToArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestArgsToFieldMap(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		args             []interface{}
+		expectedFieldMap map[string]interface{}
+	}{
+		"nil": {
+			args:             nil,
+			expectedFieldMap: nil,
+		},
+		"pairs": {
+			args: []interface{}{
+				"key1", "value1",
+				"key2", 2,
+			},
+			expectedFieldMap: map[string]interface{}{
+				"key1": "value1",
+				"key2": 2,
+			},
+		},
+		"non-string-key": {
+			args: []interface{}{
+				123, "value1",
+			},
+			expectedFieldMap: map[string]interface{}{
+				"123": "value1",
+			},
+		},
+		"extra-value-at-end": {
+			args: []interface{}{
+				"key1", "value1",
+				"value2",
+			},
+			expectedFieldMap: map[string]interface{}{
+				"key1":                "value1",
+				hclogutils.MissingKey: "value2",
+			},
+		},
+		"duplicate-key": {
+			args: []interface{}{
+				"key1", "value1",
+				"key1", "value2",
+			},
+			expectedFieldMap: map[string]interface{}{
+				"key1": "value2",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := hclogutils.ArgsToFieldMap(testCase.args...)
+
+			if diff := cmp.Diff(testCase.expectedFieldMap, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
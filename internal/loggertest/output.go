@@ -0,0 +1,37 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package loggertest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// CheckOutput calls logImpl with a context.Context containing the root logger
+// created by root, such as ProviderRoot or SDKRoot, and reports an error if
+// the log output differs from expectedOutput.
+func CheckOutput(t *testing.T, root func(context.Context, io.Writer) context.Context, logImpl func(context.Context), expectedOutput []map[string]interface{}) {
+	t.Helper()
+
+	var outputBuffer bytes.Buffer
+
+	ctx := context.Background()
+	ctx = root(ctx, &outputBuffer)
+
+	logImpl(ctx)
+
+	got, err := MultilineJSONDecode(&outputBuffer)
+
+	if err != nil {
+		t.Fatalf("unable to read multiple line JSON: %s", err)
+	}
+
+	if diff := cmp.Diff(expectedOutput, got); diff != "" {
+		t.Errorf("unexpected output difference: %s", diff)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package loggertest
+
+import (
+	"context"
+)
+
+// spanKey is the context.Context key holding a Span.
+type spanKey struct{}
+
+// Span is a fake active span, holding the IDs returned by
+// TraceContextExtractor.
+type Span struct {
+	TraceID string
+	SpanID  string
+}
+
+// ContextWithSpan returns a new context.Context with the given fake active
+// span, similar to tracing libraries starting a span.
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// TraceContextExtractor is a fake logging.TraceContextExtractor, returning the
+// IDs of the Span set with ContextWithSpan.
+type TraceContextExtractor struct{}
+
+func (TraceContextExtractor) ExtractTraceContext(ctx context.Context) (string, string, bool) {
+	span, ok := ctx.Value(spanKey{}).(Span)
+
+	return span.TraceID, span.SpanID, ok
+}
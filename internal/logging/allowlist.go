@@ -0,0 +1,116 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"slices"
+	"sort"
+)
+
+// RemovedFieldsKey is the field key listing the sorted keys of the fields
+// removed from a log entry by a field allowlist, either dropped or masked.
+// It is only included in log entries with removed fields.
+const RemovedFieldsKey = "@removed_fields"
+
+// FieldAllowlistMode determines whether a logger only writes the fields on
+// its field allowlist and how it removes the other fields.
+type FieldAllowlistMode int
+
+const (
+	// FieldAllowlistModeOff writes all fields. It is the default.
+	FieldAllowlistModeOff FieldAllowlistMode = iota
+
+	// FieldAllowlistModeDrop drops the fields not on the field allowlist.
+	FieldAllowlistModeDrop
+
+	// FieldAllowlistModeMask masks with asterisks (`*`) the values of the
+	// fields not on the field allowlist.
+	FieldAllowlistModeMask
+)
+
+// String returns the name of the FieldAllowlistMode, such as "drop".
+func (m FieldAllowlistMode) String() string {
+	switch m {
+	case FieldAllowlistModeDrop:
+		return "drop"
+	case FieldAllowlistModeMask:
+		return "mask"
+	default:
+		return "off"
+	}
+}
+
+// correlationFieldKeys are the keys of the fields correlating log entries
+// with requests and traces, which are always written by loggers with a field
+// allowlist, as they never hold sensitive values.
+var correlationFieldKeys = []string{
+	RequestIDFieldKey,
+	SpanIDFieldKey,
+	TraceIDFieldKey,
+}
+
+// withInheritedFieldAllowlist returns the subsystem LoggerOpts, restricted by
+// the field allowlist of the given root LoggerOpts. A subsystem logger
+// without its own field allowlist uses the one of its root logger. When both
+// have a field allowlist, only fields on both are written, and fields are
+// dropped if either of them drops fields.
+func (o LoggerOpts) withInheritedFieldAllowlist(root LoggerOpts) LoggerOpts {
+	switch {
+	case root.FieldAllowlistMode == FieldAllowlistModeOff:
+		return o
+	case o.FieldAllowlistMode == FieldAllowlistModeOff:
+		o.FieldAllowlist = slices.Clone(root.FieldAllowlist)
+		o.FieldAllowlistMode = root.FieldAllowlistMode
+
+		return o
+	}
+
+	fieldAllowlist := make([]string, 0, len(o.FieldAllowlist))
+
+	for _, key := range o.FieldAllowlist {
+		if slices.Contains(root.FieldAllowlist, key) {
+			fieldAllowlist = append(fieldAllowlist, key)
+		}
+	}
+
+	o.FieldAllowlist = fieldAllowlist
+
+	if root.FieldAllowlistMode == FieldAllowlistModeDrop {
+		o.FieldAllowlistMode = FieldAllowlistModeDrop
+	}
+
+	return o
+}
+
+// applyFieldAllowlist drops or masks, depending on the FieldAllowlistMode,
+// the fields whose key is not on the field allowlist, except for the
+// correlation fields, such as tf_req_id. It returns the sorted keys of the
+// removed fields, if any.
+//
+// Note that the given fields are changed-in-place by this method.
+func (lo LoggerOpts) applyFieldAllowlist(fields map[string]interface{}) []string {
+	if lo.FieldAllowlistMode == FieldAllowlistModeOff {
+		return nil
+	}
+
+	var removedKeys []string
+
+	for key := range fields {
+		if slices.Contains(lo.FieldAllowlist, key) || slices.Contains(correlationFieldKeys, key) {
+			continue
+		}
+
+		removedKeys = append(removedKeys, key)
+
+		if lo.FieldAllowlistMode == FieldAllowlistModeMask {
+			fields[key] = logMaskingReplacementString
+		} else {
+			delete(fields, key)
+		}
+	}
+
+	sort.Strings(removedKeys)
+
+	return removedKeys
+}
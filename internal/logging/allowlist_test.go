@@ -0,0 +1,121 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+func TestLoggerOptsWithInheritedRules_FieldAllowlist(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		root                       logging.LoggerOpts
+		subsystem                  logging.LoggerOpts
+		expectedFieldAllowlist     []string
+		expectedFieldAllowlistMode logging.FieldAllowlistMode
+	}{
+		"root-off": {
+			root: logging.LoggerOpts{
+				ApplyRulesToSubsystems: true,
+			},
+			subsystem: logging.LoggerOpts{
+				FieldAllowlist:     []string{"k1"},
+				FieldAllowlistMode: logging.FieldAllowlistModeMask,
+			},
+			expectedFieldAllowlist:     []string{"k1"},
+			expectedFieldAllowlistMode: logging.FieldAllowlistModeMask,
+		},
+		"root-without-apply-rules": {
+			root: logging.LoggerOpts{
+				FieldAllowlist:     []string{"k1"},
+				FieldAllowlistMode: logging.FieldAllowlistModeDrop,
+			},
+			subsystem:                  logging.LoggerOpts{},
+			expectedFieldAllowlist:     []string{"k1"},
+			expectedFieldAllowlistMode: logging.FieldAllowlistModeDrop,
+		},
+		"root-excluded": {
+			root: logging.LoggerOpts{
+				ApplyRulesToSubsystems: true,
+				FieldAllowlist:         []string{"k1"},
+				FieldAllowlistMode:     logging.FieldAllowlistModeDrop,
+			},
+			subsystem: logging.LoggerOpts{
+				ExcludeInheritedRules: true,
+			},
+			expectedFieldAllowlist:     []string{"k1"},
+			expectedFieldAllowlistMode: logging.FieldAllowlistModeDrop,
+		},
+		"subsystem-off": {
+			root: logging.LoggerOpts{
+				ApplyRulesToSubsystems: true,
+				FieldAllowlist:         []string{"k1", "k2"},
+				FieldAllowlistMode:     logging.FieldAllowlistModeMask,
+			},
+			subsystem:                  logging.LoggerOpts{},
+			expectedFieldAllowlist:     []string{"k1", "k2"},
+			expectedFieldAllowlistMode: logging.FieldAllowlistModeMask,
+		},
+		"both-intersection": {
+			root: logging.LoggerOpts{
+				ApplyRulesToSubsystems: true,
+				FieldAllowlist:         []string{"k1", "k2"},
+				FieldAllowlistMode:     logging.FieldAllowlistModeMask,
+			},
+			subsystem: logging.LoggerOpts{
+				FieldAllowlist:     []string{"k2", "k3"},
+				FieldAllowlistMode: logging.FieldAllowlistModeMask,
+			},
+			expectedFieldAllowlist:     []string{"k2"},
+			expectedFieldAllowlistMode: logging.FieldAllowlistModeMask,
+		},
+		"both-root-drop": {
+			root: logging.LoggerOpts{
+				ApplyRulesToSubsystems: true,
+				FieldAllowlist:         []string{"k1"},
+				FieldAllowlistMode:     logging.FieldAllowlistModeDrop,
+			},
+			subsystem: logging.LoggerOpts{
+				FieldAllowlist:     []string{"k1"},
+				FieldAllowlistMode: logging.FieldAllowlistModeMask,
+			},
+			expectedFieldAllowlist:     []string{"k1"},
+			expectedFieldAllowlistMode: logging.FieldAllowlistModeDrop,
+		},
+		"both-subsystem-drop": {
+			root: logging.LoggerOpts{
+				ApplyRulesToSubsystems: true,
+				FieldAllowlist:         []string{"k1"},
+				FieldAllowlistMode:     logging.FieldAllowlistModeMask,
+			},
+			subsystem: logging.LoggerOpts{
+				FieldAllowlist:     []string{"k1"},
+				FieldAllowlistMode: logging.FieldAllowlistModeDrop,
+			},
+			expectedFieldAllowlist:     []string{"k1"},
+			expectedFieldAllowlistMode: logging.FieldAllowlistModeDrop,
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.subsystem.WithInheritedRules(testCase.root)
+
+			if diff := cmp.Diff(testCase.expectedFieldAllowlist, got.FieldAllowlist); diff != "" {
+				t.Errorf("unexpected FieldAllowlist difference: %s", diff)
+			}
+
+			if got.FieldAllowlistMode != testCase.expectedFieldAllowlistMode {
+				t.Errorf("expected FieldAllowlistMode %s, got: %s", testCase.expectedFieldAllowlistMode, got.FieldAllowlistMode)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// Description is a read-only summary of the configuration of a logger, as
+// found in a context.Context. It is meant for debugging and for asserting
+// logger configuration in tests.
+//
+// Rules that hold literal strings to mask are only reported as counts, as
+// those strings are commonly the sensitive values themselves.
+type Description struct {
+	// Name is the name or "@module" of the logger.
+	Name string
+
+	// Level is the effective level of the logger.
+	Level hclog.Level
+
+	// Fields are the key/value pairs included in each log output of the
+	// logger. Values are reported after the masking rules of the logger
+	// have been applied to them.
+	Fields map[string]interface{}
+
+	// Subsystems are the sorted names of the subsystem loggers created for
+	// a root logger. It is always empty when describing a subsystem logger.
+	Subsystems []string
+
+	// AutoCreatedSubsystems are the sorted names of the subsystem loggers
+	// of a root logger which were automatically created, because they were
+	// used before being created. It is always empty when describing a
+	// subsystem logger.
+	AutoCreatedSubsystems []string
+
+	// FieldAllowlistMode is the name of the FieldAllowlistMode of the
+	// logger, either "drop" or "mask". It is empty when the logger writes all
+	// fields.
+	FieldAllowlistMode string
+
+	// FieldAllowlist are the only field keys written by the logger, when its
+	// FieldAllowlistMode is not empty.
+	FieldAllowlist []string
+
+	// OmitDryRun indicates whether the logger writes the logs its omit rules
+	// would omit, tagged with the rule which would omit them.
+	OmitDryRun bool
+
+	// OmitLogWithFieldKeys are the field keys that cause a log to be omitted.
+	OmitLogWithFieldKeys []string
+
+	// OmitLogWithFieldKeyMatchers are the descriptions of the field key
+	// matchers that cause a log to be omitted.
+	OmitLogWithFieldKeyMatchers []string
+
+	// OmitLogWithMessageRegexes are the patterns of the regular expressions
+	// that cause a log to be omitted when matching its message.
+	OmitLogWithMessageRegexes []string
+
+	// OmitLogWithMessageStrings are the strings that cause a log to be
+	// omitted when contained in its message.
+	OmitLogWithMessageStrings []string
+
+	// OmitLogWithRules are the descriptions of the rules that cause a log to
+	// be omitted.
+	OmitLogWithRules []string
+
+	// MaskFieldValuesWithFieldKeys are the field keys whose values are
+	// masked.
+	MaskFieldValuesWithFieldKeys []string
+
+	// MaskFieldValuesWithFieldKeyMatchers are the descriptions of the field
+	// key matchers whose values are masked.
+	MaskFieldValuesWithFieldKeyMatchers []string
+
+	// MaskFieldValuesWithFuncsCount is the number of functions masking field
+	// values.
+	MaskFieldValuesWithFuncsCount int
+
+	// MaskAllFieldValuesRegexes are the patterns of the regular expressions
+	// masking portions of all field values.
+	MaskAllFieldValuesRegexes []string
+
+	// MaskAllFieldValuesStringsCount is the number of strings masked within
+	// all field values.
+	MaskAllFieldValuesStringsCount int
+
+	// MaskMessageRegexes are the patterns of the regular expressions masking
+	// portions of the log message.
+	MaskMessageRegexes []string
+
+	// MaskMessageStringsCount is the number of strings masked within the log
+	// message.
+	MaskMessageStringsCount int
+
+	// MaskMessageWithFuncsCount is the number of functions masking the log
+	// message.
+	MaskMessageWithFuncsCount int
+}
+
+// NewDescription returns the Description of the given logger, configured with
+// the given LoggerOpts. The registry should only be given for root loggers.
+func NewDescription(logger hclog.Logger, lOpts LoggerOpts, registry *SubsystemRegistry) Description {
+	var fields map[string]interface{}
+
+	if len(lOpts.Fields) > 0 {
+		fields = make(map[string]interface{}, len(lOpts.Fields))
+
+		for k, v := range lOpts.Fields {
+			fields[k] = v
+		}
+
+		// The message is irrelevant here, only field values are described.
+		var msg string
+		lOpts.ApplyMask(&msg, fields)
+	}
+
+	return Description{
+		Name:                                logger.Name(),
+		Level:                               logger.GetLevel(),
+		Fields:                              fields,
+		Subsystems:                          registry.Names(),
+		AutoCreatedSubsystems:               registry.AutoCreatedNames(),
+		FieldAllowlistMode:                  fieldAllowlistModeToString(lOpts.FieldAllowlistMode),
+		FieldAllowlist:                      copyStrings(lOpts.FieldAllowlist),
+		OmitDryRun:                          lOpts.OmitDryRun,
+		OmitLogWithFieldKeys:                copyStrings(lOpts.OmitLogWithFieldKeys),
+		OmitLogWithFieldKeyMatchers:         fieldKeyMatchersToStrings(lOpts.OmitLogWithFieldKeyMatchers),
+		OmitLogWithMessageRegexes:           regexpsToPatterns(lOpts.OmitLogWithMessageRegexes),
+		OmitLogWithMessageStrings:           copyStrings(lOpts.OmitLogWithMessageStrings),
+		OmitLogWithRules:                    omitRulesToStrings(lOpts.OmitLogWithRules),
+		MaskFieldValuesWithFieldKeys:        copyStrings(lOpts.MaskFieldValuesWithFieldKeys),
+		MaskFieldValuesWithFieldKeyMatchers: fieldKeyMatchersToStrings(lOpts.MaskFieldValuesWithFieldKeyMatchers),
+		MaskFieldValuesWithFuncsCount:       len(lOpts.MaskFieldValuesWithFuncs),
+		MaskAllFieldValuesRegexes:           regexpsToPatterns(lOpts.MaskAllFieldValuesRegexes),
+		MaskAllFieldValuesStringsCount:      len(lOpts.MaskAllFieldValuesStrings),
+		MaskMessageRegexes:                  regexpsToPatterns(lOpts.MaskMessageRegexes),
+		MaskMessageStringsCount:             len(lOpts.MaskMessageStrings),
+		MaskMessageWithFuncsCount:           len(lOpts.MaskMessageWithFuncs),
+	}
+}
+
+func copyStrings(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+
+	result := make([]string, len(s))
+
+	copy(result, s)
+
+	return result
+}
+
+func regexpsToPatterns(expressions []*regexp.Regexp) []string {
+	if len(expressions) == 0 {
+		return nil
+	}
+
+	result := make([]string, 0, len(expressions))
+
+	for _, r := range expressions {
+		result = append(result, r.String())
+	}
+
+	return result
+}
+
+func fieldKeyMatchersToStrings(matchers []FieldKeyMatcher) []string {
+	if len(matchers) == 0 {
+		return nil
+	}
+
+	result := make([]string, 0, len(matchers))
+
+	for _, m := range matchers {
+		result = append(result, m.String())
+	}
+
+	return result
+}
+
+func omitRulesToStrings(rules []OmitRule) []string {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	result := make([]string, 0, len(rules))
+
+	for _, r := range rules {
+		result = append(result, r.String())
+	}
+
+	return result
+}
+
+func fieldAllowlistModeToString(mode FieldAllowlistMode) string {
+	if mode == FieldAllowlistModeOff {
+		return ""
+	}
+
+	return mode.String()
+}
@@ -0,0 +1,70 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// fieldValueText returns the text a field value is rendered as, which is
+// masked by the regexp and strings masking field values: strings as-is, the
+// contents of []byte, the message of an error, the result of String for a
+// fmt.Stringer, and the decimal form of integers and floating-point numbers.
+// It returns false for any other value, and for nil pointers, whose methods
+// could panic.
+func fieldValueText(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	case error:
+		if isNilPointer(v) {
+			return "", false
+		}
+
+		return v.Error(), true
+	case fmt.Stringer:
+		if isNilPointer(v) {
+			return "", false
+		}
+
+		return v.String(), true
+	case int:
+		return strconv.FormatInt(int64(v), 10), true
+	case int8:
+		return strconv.FormatInt(int64(v), 10), true
+	case int16:
+		return strconv.FormatInt(int64(v), 10), true
+	case int32:
+		return strconv.FormatInt(int64(v), 10), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), true
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10), true
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10), true
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), true
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), true
+	default:
+		return "", false
+	}
+}
+
+// isNilPointer returns true if the value is a nil pointer.
+func isNilPointer(value interface{}) bool {
+	v := reflect.ValueOf(value)
+
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
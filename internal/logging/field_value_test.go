@@ -0,0 +1,277 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+// testNilError is an error whose methods panic on a nil pointer.
+type testNilError struct {
+	msg string
+}
+
+func (e *testNilError) Error() string {
+	return e.msg
+}
+
+// testStringerError is a fmt.Stringer which is also an error, to verify errors
+// are rendered with their Error method.
+type testStringerError struct{}
+
+func (testStringerError) Error() string {
+	return "error secret"
+}
+
+func (testStringerError) String() string {
+	return "stringer secret"
+}
+
+func TestApplyMask_FieldValueKinds(t *testing.T) {
+	t.Parallel()
+
+	lOpts := logging.LoggerOpts{
+		MaskAllFieldValuesRegexes: []*regexp.Regexp{regexp.MustCompile(`token=\w+`)},
+		MaskAllFieldValuesStrings: []string{"secret", "4242"},
+	}
+
+	testCases := map[string]struct {
+		value          interface{}
+		expectedValue  interface{}
+		expectedMasked bool
+	}{
+		"string": {
+			value:          "a secret",
+			expectedValue:  "a ***",
+			expectedMasked: true,
+		},
+		"string-unmasked": {
+			value:          "a value",
+			expectedValue:  "a value",
+			expectedMasked: false,
+		},
+		"bytes": {
+			value:          []byte("body token=abc123"),
+			expectedValue:  "body ***",
+			expectedMasked: true,
+		},
+		"bytes-unmasked": {
+			value:          []byte("body"),
+			expectedValue:  []byte("body"),
+			expectedMasked: false,
+		},
+		"bytes-nil": {
+			value:          []byte(nil),
+			expectedValue:  []byte(nil),
+			expectedMasked: false,
+		},
+		"error": {
+			value:          errors.New("request failed: token=abc123"),
+			expectedValue:  "request failed: ***",
+			expectedMasked: true,
+		},
+		"error-wrapped": {
+			value:          fmt.Errorf("reading instance: %w", errors.New("secret leaked")),
+			expectedValue:  "reading instance: *** leaked",
+			expectedMasked: true,
+		},
+		"error-unmasked": {
+			value:          errors.New("request failed"),
+			expectedValue:  errors.New("request failed"),
+			expectedMasked: false,
+		},
+		"error-nil-pointer": {
+			value:          (*testNilError)(nil),
+			expectedValue:  (*testNilError)(nil),
+			expectedMasked: false,
+		},
+		"error-and-stringer": {
+			value:          testStringerError{},
+			expectedValue:  "error ***",
+			expectedMasked: true,
+		},
+		"stringer": {
+			value:          &url.URL{Scheme: "https", Host: "example.com", RawQuery: "token=abc123"},
+			expectedValue:  "https://example.com?***",
+			expectedMasked: true,
+		},
+		"stringer-unmasked": {
+			value:          &url.URL{Scheme: "https", Host: "example.com"},
+			expectedValue:  &url.URL{Scheme: "https", Host: "example.com"},
+			expectedMasked: false,
+		},
+		"stringer-nil-pointer": {
+			value:          (*url.URL)(nil),
+			expectedValue:  (*url.URL)(nil),
+			expectedMasked: false,
+		},
+		"stringer-value": {
+			value:          time.Duration(4242) * time.Hour,
+			expectedValue:  "***h0m0s",
+			expectedMasked: true,
+		},
+		"json-number": {
+			value:          json.Number("4242.5"),
+			expectedValue:  "***.5",
+			expectedMasked: true,
+		},
+		"int": {
+			value:          int(424242),
+			expectedValue:  "***42",
+			expectedMasked: true,
+		},
+		"int-unmasked": {
+			value:          int(42),
+			expectedValue:  int(42),
+			expectedMasked: false,
+		},
+		"int-negative": {
+			value:          int(-4242),
+			expectedValue:  "-***",
+			expectedMasked: true,
+		},
+		"int8": {
+			value:          int8(42),
+			expectedValue:  int8(42),
+			expectedMasked: false,
+		},
+		"int16": {
+			value:          int16(4242),
+			expectedValue:  "***",
+			expectedMasked: true,
+		},
+		"int32": {
+			value:          int32(14242),
+			expectedValue:  "1***",
+			expectedMasked: true,
+		},
+		"int64": {
+			value:          int64(4242000000),
+			expectedValue:  "***000000",
+			expectedMasked: true,
+		},
+		"uint": {
+			value:          uint(4242),
+			expectedValue:  "***",
+			expectedMasked: true,
+		},
+		"uint8": {
+			value:          uint8(42),
+			expectedValue:  uint8(42),
+			expectedMasked: false,
+		},
+		"uint16": {
+			value:          uint16(4242),
+			expectedValue:  "***",
+			expectedMasked: true,
+		},
+		"uint32": {
+			value:          uint32(4242),
+			expectedValue:  "***",
+			expectedMasked: true,
+		},
+		"uint64": {
+			value:          uint64(4242),
+			expectedValue:  "***",
+			expectedMasked: true,
+		},
+		"float32": {
+			value:          float32(42.4242),
+			expectedValue:  "42.***",
+			expectedMasked: true,
+		},
+		"float64": {
+			value:          float64(4242.5),
+			expectedValue:  "***.5",
+			expectedMasked: true,
+		},
+		"float64-unmasked": {
+			value:          float64(42.5),
+			expectedValue:  float64(42.5),
+			expectedMasked: false,
+		},
+		"bool": {
+			value:          true,
+			expectedValue:  true,
+			expectedMasked: false,
+		},
+		"nil": {
+			value:          nil,
+			expectedValue:  nil,
+			expectedMasked: false,
+		},
+		"struct": {
+			value:          struct{ Secret string }{Secret: "secret"},
+			expectedValue:  struct{ Secret string }{Secret: "secret"},
+			expectedMasked: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			msg := testLogMsg
+			fields := map[string]interface{}{
+				"k1": testCase.value,
+			}
+
+			gotMasked := lOpts.ApplyMask(&msg, fields)
+
+			if gotMasked != testCase.expectedMasked {
+				t.Errorf("expected masked %t, got: %t", testCase.expectedMasked, gotMasked)
+			}
+
+			if diff := cmp.Diff(testCase.expectedValue, fields["k1"], cmp.Comparer(errorsEqual), cmp.Comparer(urlsEqual)); diff != "" {
+				t.Errorf("unexpected field value difference: %s", diff)
+			}
+
+			gotNested, gotNestedMasked := lOpts.ApplyMaskNested([]interface{}{testCase.value})
+
+			if gotNestedMasked != testCase.expectedMasked {
+				t.Errorf("expected nested masked %t, got: %t", testCase.expectedMasked, gotNestedMasked)
+			}
+
+			if diff := cmp.Diff([]interface{}{testCase.expectedValue}, gotNested, cmp.Comparer(errorsEqual), cmp.Comparer(urlsEqual)); diff != "" {
+				t.Errorf("unexpected nested value difference: %s", diff)
+			}
+		})
+	}
+}
+
+// errorsEqual compares errors by their message.
+func errorsEqual(i, j error) bool {
+	if i == j {
+		return true
+	}
+
+	if i == nil || j == nil {
+		return i == nil && j == nil
+	}
+
+	return i.Error() == j.Error()
+}
+
+// urlsEqual compares *url.URL by their string form.
+func urlsEqual(i, j *url.URL) bool {
+	if i == j {
+		return true
+	}
+
+	if i == nil || j == nil {
+		return i == nil && j == nil
+	}
+
+	return i.String() == j.String()
+}
@@ -4,8 +4,10 @@
 package logging
 
 import (
+	"context"
 	"strings"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-log/internal/fieldutils"
 	"github.com/hashicorp/terraform-plugin-log/internal/hclogutils"
 )
@@ -16,19 +18,43 @@ const logMaskingReplacementString = "***"
 // and determines, based on the LoggerOpts configuration, if the
 // log should be omitted (i.e. prevent it to be printed on the final writer).
 func (lo LoggerOpts) ShouldOmit(msg *string, fieldMaps ...map[string]interface{}) bool {
+	_, omit := lo.shouldOmit(*msg, fieldMaps...)
+
+	return omit
+}
+
+// shouldOmit is ShouldOmit, also returning the identifier of the rule which
+// omits the log, such as "omit_log_with_field_keys:foo".
+func (lo LoggerOpts) shouldOmit(msg string, fieldMaps ...map[string]interface{}) (string, bool) {
 	// Omit log if any of the configured keys is found in the given fields
 	if len(lo.OmitLogWithFieldKeys) > 0 {
-		fieldsKeys := fieldutils.FieldMapsToKeys(fieldMaps...)
-		if argKeysContain(fieldsKeys, lo.OmitLogWithFieldKeys) {
-			return true
+		for _, k := range lo.OmitLogWithFieldKeys {
+			for _, f := range fieldMaps {
+				if _, ok := f[k]; ok {
+					return keyRuleID("omit_log_with_field_keys", k), true
+				}
+			}
+		}
+	}
+
+	// Omit log if any of the configured key matchers matches a key of the given fields
+	if len(lo.OmitLogWithFieldKeyMatchers) > 0 {
+		for _, f := range fieldMaps {
+			for fk := range f {
+				for _, m := range lo.OmitLogWithFieldKeyMatchers {
+					if m.Match(fk) {
+						return keyRuleID("omit_log_with_field_key_matchers", m.String()), true
+					}
+				}
+			}
 		}
 	}
 
 	// Omit log if any of the configured regexp matches the log message
 	if len(lo.OmitLogWithMessageRegexes) > 0 {
 		for _, r := range lo.OmitLogWithMessageRegexes {
-			if r.MatchString(*msg) {
-				return true
+			if r.MatchString(msg) {
+				return keyRuleID("omit_log_with_message_regexes", r.String()), true
 			}
 		}
 	}
@@ -36,101 +62,383 @@ func (lo LoggerOpts) ShouldOmit(msg *string, fieldMaps ...map[string]interface{}
 	// Omit log if any of the configured strings is contained in the log message
 	if len(lo.OmitLogWithMessageStrings) > 0 {
 		for _, s := range lo.OmitLogWithMessageStrings {
-			if strings.Contains(*msg, s) {
-				return true
+			if strings.Contains(msg, s) {
+				return keyRuleID("omit_log_with_message_strings", s), true
 			}
 		}
 	}
 
-	return false
+	return "", false
 }
 
 // ApplyMask takes a log's *string message and slices of fields,
 // and applies masking to fields keys' values and/or to log message,
-// based on the LoggerOpts configuration.
+// based on the LoggerOpts configuration. It returns true if any of the
+// message or field values was masked.
 //
 // Note that the given input is changed-in-place by this method.
-func (lo LoggerOpts) ApplyMask(msg *string, fieldMaps ...map[string]interface{}) {
+func (lo LoggerOpts) ApplyMask(msg *string, fieldMaps ...map[string]interface{}) bool {
+	return lo.applyMask(nil, msg, fieldMaps...)
+}
+
+// applyMask is ApplyMask, recording the masked fields, the message
+// replacements and the rules masking them in the given maskAudit, if any.
+//
+// The message is masked in order: MaskMessageWithFuncs, MaskMessageRegexes
+// and then MaskMessageStrings.
+func (lo LoggerOpts) applyMask(audit *maskAudit, msg *string, fieldMaps ...map[string]interface{}) bool {
+	masked := lo.applyFieldMask(audit, fieldMaps...)
+
+	// Replace the log message with the one returned by any of the configured functions
+	if len(lo.MaskMessageWithFuncs) > 0 && lo.applyMessageMaskers(audit, msg) {
+		masked = true
+	}
+
+	// Replace any part of the log message matching any of the configured regexp
+	if len(lo.MaskMessageRegexes) > 0 {
+		for _, r := range lo.MaskMessageRegexes {
+			if r.MatchString(*msg) {
+				if audit != nil {
+					audit.message(len(r.FindAllStringIndex(*msg, -1)), regexpRuleID("mask_message_regexes", r))
+				}
+
+				*msg = r.ReplaceAllString(*msg, logMaskingReplacementString)
+				masked = true
+			}
+		}
+	}
+
+	// Replace any part of the log message equal to any of the configured strings
+	if m := lo.messageStringsMatcher(); m != nil {
+		var matched func(int)
+
+		if audit != nil {
+			matched = func(i int) {
+				audit.message(1, indexRuleID("mask_message_strings", i))
+			}
+		}
+
+		if maskedMsg, ok := m.replaceAll(*msg, logMaskingReplacementString, matched); ok {
+			*msg = maskedMsg
+			masked = true
+		}
+	}
+
+	return masked
+}
+
+// ApplyMaskNested applies the field masking of the LoggerOpts configuration
+// to a nested value, such as decoded JSON, and returns the masked value. Maps
+// with string keys are masked like log fields, at any depth, so the masking
+// of field values by field key also applies to their keys. Values in slices
+// are masked like field values, including values rendered as text, such as
+// errors. It returns true if any value was masked.
+//
+// Note that maps and slices of the given value are changed-in-place by this
+// method.
+func (lo LoggerOpts) ApplyMaskNested(value interface{}) (interface{}, bool) {
+	return lo.applyMaskNested(lo.fieldValuesStringsMatcher(), value)
+}
+
+// MasksFieldValuesWithFieldKeys returns true if the LoggerOpts configuration
+// masks field values by field key, which requires values structured as
+// fields, such as decoded JSON, rather than text.
+func (lo LoggerOpts) MasksFieldValuesWithFieldKeys() bool {
+	return len(lo.MaskFieldValuesWithFieldKeys) > 0 ||
+		len(lo.MaskFieldValuesWithFieldKeyMatchers) > 0 ||
+		len(lo.MaskFieldValuesWithFuncs) > 0
+}
+
+// applyMaskNested is ApplyMaskNested, with the given stringMatcher of the
+// MaskAllFieldValuesStrings.
+func (lo LoggerOpts) applyMaskNested(stringsMatcher *stringMatcher, value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		masked := lo.applyFieldMask(nil, v)
+
+		for k, fv := range v {
+			if maskedValue, ok := lo.applyMaskNested(stringsMatcher, fv); ok {
+				v[k] = maskedValue
+				masked = true
+			}
+		}
+
+		return v, masked
+	case []interface{}:
+		masked := false
+
+		for i, ev := range v {
+			if maskedValue, ok := lo.applyMaskNested(stringsMatcher, ev); ok {
+				v[i] = maskedValue
+				masked = true
+			}
+		}
+
+		return v, masked
+	case string:
+		return lo.applyFieldValueMask(nil, stringsMatcher, "", v)
+	default:
+		if maskedValue, ok := maskSensitiveValue(value); ok {
+			return maskedValue, true
+		}
+
+		text, ok := fieldValueText(value)
+
+		if !ok {
+			return value, false
+		}
+
+		if maskedText, ok := lo.applyFieldValueMask(nil, stringsMatcher, "", text); ok {
+			return maskedText, true
+		}
+
+		return value, false
+	}
+}
+
+// applyFieldMask applies the masking of field values, by field key and by
+// value, to the given fields, recording it in the given maskAudit, if any.
+// It returns true if any field value was masked.
+//
+// Masking is applied in order: SensitiveValue, MaskFieldValuesWithFieldKeys,
+// MaskFieldValuesWithFieldKeyMatchers, MaskFieldValuesWithFuncs, and then
+// MaskAllFieldValuesRegexes and MaskAllFieldValuesStrings, so the values
+// returned by functions are still masked by regexp and strings.
+func (lo LoggerOpts) applyFieldMask(audit *maskAudit, fieldMaps ...map[string]interface{}) bool {
+	masked := false
+
+	// Replace any SensitiveValue, regardless of the field key or its depth
+	for _, f := range fieldMaps {
+		for fk, fv := range f {
+			if maskedValue, ok := maskSensitiveValue(fv); ok {
+				if audit != nil {
+					audit.field(fk, "sensitive_value")
+				}
+
+				f[fk] = maskedValue
+				masked = true
+			}
+		}
+	}
+
 	// Replace any log field value with the corresponding field key equal to the configured strings
 	if len(lo.MaskFieldValuesWithFieldKeys) > 0 {
 		for _, k := range lo.MaskFieldValuesWithFieldKeys {
 			for _, f := range fieldMaps {
 				for fk := range f {
 					if k == fk {
+						if audit != nil {
+							audit.field(fk, keyRuleID("mask_field_values_with_field_keys", k))
+						}
+
 						f[k] = logMaskingReplacementString
+						masked = true
 					}
 				}
 			}
 		}
 	}
 
-	// Replace any part of any log field matching any of the configured regexp
-	if len(lo.MaskAllFieldValuesRegexes) > 0 {
-		for _, r := range lo.MaskAllFieldValuesRegexes {
-			for _, f := range fieldMaps {
-				for fk, fv := range f {
-					// Can apply the regexp replacement, only if the field value is indeed a string
-					fvStr, ok := fv.(string)
-					if ok {
-						f[fk] = r.ReplaceAllString(fvStr, logMaskingReplacementString)
+	// Replace any log field value with the corresponding field key matching any of the configured key matchers
+	if len(lo.MaskFieldValuesWithFieldKeyMatchers) > 0 {
+		for _, f := range fieldMaps {
+			for fk := range f {
+				for _, m := range lo.MaskFieldValuesWithFieldKeyMatchers {
+					if m.Match(fk) {
+						if audit != nil {
+							audit.field(fk, keyRuleID("mask_field_values_with_field_key_matchers", m.String()))
+						}
+
+						f[fk] = logMaskingReplacementString
+						masked = true
+
+						break
 					}
 				}
 			}
 		}
 	}
 
-	// Replace any part of any log field matching any of the configured strings
-	if len(lo.MaskAllFieldValuesStrings) > 0 {
-		for _, s := range lo.MaskAllFieldValuesStrings {
-			for _, f := range fieldMaps {
-				for fk, fv := range f {
-					// Can apply the regexp replacement, only if the field value is indeed a string
-					fvStr, ok := fv.(string)
-					if ok {
-						f[fk] = strings.ReplaceAll(fvStr, s, logMaskingReplacementString)
-					}
-				}
+	// Replace any log field value with the value returned by any of the configured functions
+	if len(lo.MaskFieldValuesWithFuncs) > 0 && lo.applyFieldValueMaskers(audit, fieldMaps...) {
+		masked = true
+	}
+
+	if len(lo.MaskAllFieldValuesRegexes) == 0 && len(lo.MaskAllFieldValuesStrings) == 0 {
+		return masked
+	}
+
+	stringsMatcher := lo.fieldValuesStringsMatcher()
+
+	for _, f := range fieldMaps {
+		for fk, fv := range f {
+			// Can apply the replacement, only if the field value is rendered as text
+			fvStr, ok := fieldValueText(fv)
+			if !ok {
+				continue
+			}
+
+			if maskedStr, ok := lo.applyFieldValueMask(audit, stringsMatcher, fk, fvStr); ok {
+				f[fk] = maskedStr
+				masked = true
 			}
 		}
 	}
 
-	// Replace any part of the log message matching any of the configured regexp
-	if len(lo.MaskMessageRegexes) > 0 {
-		for _, r := range lo.MaskMessageRegexes {
-			*msg = r.ReplaceAllString(*msg, logMaskingReplacementString)
+	return masked
+}
+
+// applyFieldValueMask replaces any part of the value of the field `key`
+// matching any of the configured regexp or strings, with the given
+// stringMatcher of the MaskAllFieldValuesStrings, recording it in the given
+// maskAudit, if any. It returns true if the value was masked.
+func (lo LoggerOpts) applyFieldValueMask(audit *maskAudit, stringsMatcher *stringMatcher, key string, value string) (string, bool) {
+	masked := false
+
+	// Replace any part of the field value matching any of the configured regexp
+	for _, r := range lo.MaskAllFieldValuesRegexes {
+		if r.MatchString(value) {
+			if audit != nil {
+				audit.field(key, regexpRuleID("mask_all_field_values_regexes", r))
+			}
+
+			value = r.ReplaceAllString(value, logMaskingReplacementString)
+			masked = true
 		}
 	}
 
-	// Replace any part of the log message equal to any of the configured strings
-	if len(lo.MaskMessageStrings) > 0 {
-		for _, s := range lo.MaskMessageStrings {
-			*msg = strings.ReplaceAll(*msg, s, logMaskingReplacementString)
+	// Replace any part of the field value matching any of the configured strings
+	if stringsMatcher != nil {
+		var matched func(int)
+
+		if audit != nil {
+			matched = func(i int) {
+				audit.field(key, indexRuleID("mask_all_field_values_strings", i))
+			}
+		}
+
+		if maskedValue, ok := stringsMatcher.replaceAll(value, logMaskingReplacementString, matched); ok {
+			value = maskedValue
+			masked = true
 		}
 	}
+
+	return value, masked
 }
 
-func OmitOrMask(tfLoggerOpts LoggerOpts, msg *string, additionalFields []map[string]interface{}) ([]interface{}, bool) {
+// OmitOrMask applies the omit and mask rules of the LoggerOpts to a log
+// entry, written by the given logger at the given level. It returns the
+// masked fields as hclog arguments, and whether the entry should be omitted.
+// Entries the logger would not write at its level are always omitted. In
+// OmitDryRun mode, entries the omit rules would omit are tagged with the
+// WouldOmitFieldKey field instead.
+//
+// Entries that are not omitted are then processed by the EntryHooks of the
+// LoggerOpts, which can also drop them, masked again if changed by any hook,
+// and recorded with the SpanEventBridge of the LoggerOpts, if any. The number of emitted, omitted
+// and masked entries is counted in the statistics returned by StatsSnapshot.
+func OmitOrMask(ctx context.Context, logger hclog.Logger, level hclog.Level, tfLoggerOpts LoggerOpts, msg *string, additionalFields []map[string]interface{}) ([]interface{}, bool) {
+	// Skip logs the logger would not write anyway
+	if level < logger.GetLevel() {
+		return nil, true
+	}
+
+	counters := tfLoggerOpts.statsCounters(logger.Name(), level)
+
 	additionalFieldsMap := fieldutils.MergeFieldMaps(additionalFields...)
 
+	// Include the trace and span IDs of the active span in `ctx`, if any
+	tfLoggerOpts.traceContextFields(ctx, additionalFieldsMap)
+
+	// Merge the fields of the logger into a new map, so masking never
+	// changes the fields stored in the context.Context, which are shared.
+	fields := fieldutils.MergeFieldMaps(tfLoggerOpts.Fields, additionalFieldsMap)
+
 	// Apply the provider root LoggerOpts to determine if this log should be omitted
-	if tfLoggerOpts.ShouldOmit(msg, tfLoggerOpts.Fields, additionalFieldsMap) {
+	ruleID, omit := tfLoggerOpts.shouldOmit(*msg, fields)
+
+	if !omit {
+		ruleID, omit = tfLoggerOpts.shouldOmitWithRules(level, *msg, fields)
+	}
+
+	if omit && !tfLoggerOpts.OmitDryRun {
+		counters.omit(ruleID)
+
 		return nil, true
 	}
 
-	// Apply the provider root LoggerOpts to apply masking to this log
-	tfLoggerOpts.ApplyMask(msg, tfLoggerOpts.Fields, additionalFieldsMap)
+	var audit *maskAudit
 
-	return hclogutils.FieldMapsToArgs(tfLoggerOpts.Fields, additionalFieldsMap), false
-}
+	if tfLoggerOpts.MaskAudit {
+		audit = &maskAudit{}
+	}
 
-func argKeysContain(haystack []string, needles []string) bool {
-	for _, h := range haystack {
-		for _, n := range needles {
-			if n == h {
-				return true
-			}
+	// Drop or mask the fields which are not on the field allowlist, if any
+	removedKeys := tfLoggerOpts.applyFieldAllowlist(fields)
+
+	if audit != nil && tfLoggerOpts.FieldAllowlistMode == FieldAllowlistModeMask {
+		for _, key := range removedKeys {
+			audit.field(key, "field_allowlist")
 		}
 	}
 
-	return false
+	// Apply the provider root LoggerOpts to apply masking to this log
+	if tfLoggerOpts.applyMask(audit, msg, fields) || len(removedKeys) > 0 {
+		counters.mask()
+	}
+
+	// Record the removed fields and the masking after masking, as their
+	// keys are not masked
+	if len(removedKeys) > 0 {
+		fields[RemovedFieldsKey] = removedKeys
+	}
+
+	if maskedValue := audit.value(tfLoggerOpts.MaskAuditRuleIDs); maskedValue != nil {
+		fields[MaskedFieldKey] = maskedValue
+	}
+
+	// Tag the log the omit rules would omit in dry-run mode
+	if omit {
+		fields[WouldOmitFieldKey] = ruleID
+	}
+
+	// Skip building the entry when there is nothing to process it
+	if len(tfLoggerOpts.EntryHooks) == 0 && tfLoggerOpts.SpanEventBridge == nil {
+		counters.emit()
+
+		return hclogutils.FieldMapsToArgs(fields), false
+	}
+
+	entry := Entry{
+		Level:      level,
+		LoggerName: logger.Name(),
+		Message:    *msg,
+		Fields:     fields,
+	}
+
+	// Apply the entry hooks, which may transform or drop this log
+	entry, keep := tfLoggerOpts.applyEntryHooks(ctx, entry)
+	if !keep {
+		counters.omit("entry_hook")
+
+		return nil, true
+	}
+
+	entry.Level = level
+	entry.LoggerName = logger.Name()
+
+	// Mask the entry again, as the hooks may have added or changed its
+	// message or fields after masking
+	if len(tfLoggerOpts.EntryHooks) > 0 {
+		tfLoggerOpts.maskEntry(&entry)
+	}
+
+	*msg = entry.Message
+
+	// Record the log entry on the active span in `ctx`, if any
+	tfLoggerOpts.recordSpanEvent(ctx, entry)
+
+	counters.emit()
+
+	return hclogutils.FieldMapsToArgs(entry.Fields), false
 }
@@ -47,6 +47,32 @@ func TestShouldOmit(t *testing.T) {
 			},
 			expectedToOmit: true,
 		},
+		"omit-log-by-key-matcher": {
+			lOpts: logging.LoggerOpts{
+				OmitLogWithFieldKeyMatchers: []logging.FieldKeyMatcher{logging.NewFieldKeyPrefix("debug_")},
+			},
+			msg: testLogMsg,
+			fieldMaps: []map[string]interface{}{
+				{
+					"k1":         "v1",
+					"debug_body": "v2",
+				},
+			},
+			expectedToOmit: true,
+		},
+		"no-omit-log-by-key-matcher": {
+			lOpts: logging.LoggerOpts{
+				OmitLogWithFieldKeyMatchers: []logging.FieldKeyMatcher{logging.NewFieldKeyPrefix("debug_")},
+			},
+			msg: testLogMsg,
+			fieldMaps: []map[string]interface{}{
+				{
+					"k1":       "v1",
+					"no_debug": "v2",
+				},
+			},
+			expectedToOmit: false,
+		},
 		"no-omit-log-by-key-if-case-mismatches": {
 			lOpts: logging.LoggerOpts{
 				OmitLogWithFieldKeys: []string{"K2"},
@@ -124,6 +150,7 @@ func TestApplyMask(t *testing.T) {
 		fieldMaps         []map[string]interface{}
 		expectedMsg       string
 		expectedFieldMaps []map[string]interface{}
+		expectedMasked    bool
 	}{
 		"empty-opts": {
 			lOpts: logging.LoggerOpts{},
@@ -160,6 +187,31 @@ func TestApplyMask(t *testing.T) {
 					"k2": "***",
 				},
 			},
+			expectedMasked: true,
+		},
+		"mask-log-by-key-matcher": {
+			lOpts: logging.LoggerOpts{
+				MaskFieldValuesWithFieldKeyMatchers: []logging.FieldKeyMatcher{logging.NewFieldKeyGlob("*password*").CaseInsensitive()},
+			},
+			msg: testLogMsg,
+			fieldMaps: []map[string]interface{}{
+				{
+					"k1":            "v1",
+					"Password":      "v2",
+					"db_password":   "v3",
+					"password_hash": "v4",
+				},
+			},
+			expectedMsg: "System FOO has caused error BAR because of incorrectly configured BAZ",
+			expectedFieldMaps: []map[string]interface{}{
+				{
+					"k1":            "v1",
+					"Password":      "***",
+					"db_password":   "***",
+					"password_hash": "***",
+				},
+			},
+			expectedMasked: true,
 		},
 		"no-mask-log-by-key-if-case-mismatches": {
 			lOpts: logging.LoggerOpts{
@@ -198,6 +250,7 @@ func TestApplyMask(t *testing.T) {
 					"k2": "v2",
 				},
 			},
+			expectedMasked: true,
 		},
 		"mask-log-matching-regexp-case-sensitive": {
 			lOpts: logging.LoggerOpts{
@@ -217,6 +270,7 @@ func TestApplyMask(t *testing.T) {
 					"k2": "v2",
 				},
 			},
+			expectedMasked: true,
 		},
 		"mask-log-and-fields-matching-regexp": {
 			lOpts: logging.LoggerOpts{
@@ -241,6 +295,7 @@ func TestApplyMask(t *testing.T) {
 					"k2": "*** with more extra text",
 				},
 			},
+			expectedMasked: true,
 		},
 		"mask-log-and-fields-matching-strings": {
 			lOpts: logging.LoggerOpts{
@@ -261,6 +316,7 @@ func TestApplyMask(t *testing.T) {
 					"k2": "*** with more extra text",
 				},
 			},
+			expectedMasked: true,
 		},
 		"mask-log-by-key-and-matching-regexp": {
 			lOpts: logging.LoggerOpts{
@@ -281,6 +337,7 @@ func TestApplyMask(t *testing.T) {
 					"k2": "***",
 				},
 			},
+			expectedMasked: true,
 		},
 	}
 
@@ -289,7 +346,11 @@ func TestApplyMask(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			testCase.lOpts.ApplyMask(&testCase.msg, testCase.fieldMaps...)
+			gotMasked := testCase.lOpts.ApplyMask(&testCase.msg, testCase.fieldMaps...)
+
+			if gotMasked != testCase.expectedMasked {
+				t.Errorf("expected masked %t, got %t", testCase.expectedMasked, gotMasked)
+			}
 
 			if diff := cmp.Diff(testCase.msg, testCase.expectedMsg); diff != "" {
 				t.Errorf("unexpected difference detected in log message: %s", diff)
@@ -301,3 +362,103 @@ func TestApplyMask(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyMaskNested(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		lOpts          logging.LoggerOpts
+		value          interface{}
+		expectedValue  interface{}
+		expectedMasked bool
+	}{
+		"no-masking": {
+			lOpts: logging.LoggerOpts{},
+			value: map[string]interface{}{
+				"k1": "v1",
+			},
+			expectedValue: map[string]interface{}{
+				"k1": "v1",
+			},
+			expectedMasked: false,
+		},
+		"mask-nested-keys": {
+			lOpts: logging.LoggerOpts{
+				MaskFieldValuesWithFieldKeys: []string{"password"},
+			},
+			value: map[string]interface{}{
+				"password": "secret",
+				"user": map[string]interface{}{
+					"name":     "example",
+					"password": map[string]interface{}{"value": "secret"},
+				},
+				"users": []interface{}{
+					map[string]interface{}{"password": "secret"},
+				},
+			},
+			expectedValue: map[string]interface{}{
+				"password": "***",
+				"user": map[string]interface{}{
+					"name":     "example",
+					"password": "***",
+				},
+				"users": []interface{}{
+					map[string]interface{}{"password": "***"},
+				},
+			},
+			expectedMasked: true,
+		},
+		"mask-nested-values": {
+			lOpts: logging.LoggerOpts{
+				MaskAllFieldValuesRegexes: []*regexp.Regexp{regexp.MustCompile(`tok-\d+`)},
+				MaskAllFieldValuesStrings: []string{"hunter2"},
+			},
+			value: map[string]interface{}{
+				"token": "tok-123",
+				"nested": map[string]interface{}{
+					"passwords": []interface{}{"hunter2", "other", 123},
+				},
+			},
+			expectedValue: map[string]interface{}{
+				"token": "***",
+				"nested": map[string]interface{}{
+					"passwords": []interface{}{"***", "other", 123},
+				},
+			},
+			expectedMasked: true,
+		},
+		"string": {
+			lOpts: logging.LoggerOpts{
+				MaskAllFieldValuesStrings: []string{"hunter2"},
+			},
+			value:          "password is hunter2",
+			expectedValue:  "password is ***",
+			expectedMasked: true,
+		},
+		"message-masking-ignored": {
+			lOpts: logging.LoggerOpts{
+				MaskMessageStrings: []string{"hunter2"},
+			},
+			value:          "hunter2",
+			expectedValue:  "hunter2",
+			expectedMasked: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			gotValue, gotMasked := testCase.lOpts.ApplyMaskNested(testCase.value)
+
+			if gotMasked != testCase.expectedMasked {
+				t.Errorf("expected masked %t, got %t", testCase.expectedMasked, gotMasked)
+			}
+
+			if diff := cmp.Diff(testCase.expectedValue, gotValue); diff != "" {
+				t.Errorf("unexpected difference detected in value: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// Entry is a log entry, after the omit and mask rules of the LoggerOpts of
+// the logger writing it have been applied.
+type Entry struct {
+	// Level is the level of the log entry.
+	Level hclog.Level
+
+	// LoggerName is the name or "@module" of the logger writing the entry.
+	LoggerName string
+
+	// Message is the masked message of the log entry.
+	Message string
+
+	// Fields are the masked fields of the log entry, including the fields
+	// of the logger.
+	Fields map[string]interface{}
+}
+
+// EntryHook processes log entries after omission and masking, before they
+// are written. Hooks can enrich or transform entries, drop them, or send
+// them to a side channel.
+//
+// Hooks are only called for entries that are not omitted and would be written
+// by the logger at its level. The message and fields of the entries returned
+// by hooks are masked again, so hooks cannot add values to log output which
+// the mask rules of the logger would mask.
+type EntryHook interface {
+	// ProcessEntry returns the entry to write, and whether it should be
+	// written at all. Changes to the Level and LoggerName of the entry are
+	// ignored. The Fields map of the entry can be modified in place.
+	ProcessEntry(ctx context.Context, entry Entry) (Entry, bool)
+}
+
+// EntryHookFunc is a function implementing EntryHook.
+type EntryHookFunc func(ctx context.Context, entry Entry) (Entry, bool)
+
+// ProcessEntry calls the EntryHookFunc.
+func (f EntryHookFunc) ProcessEntry(ctx context.Context, entry Entry) (Entry, bool) {
+	return f(ctx, entry)
+}
+
+// applyEntryHooks runs the entry through the EntryHooks of the LoggerOpts,
+// in order, stopping as soon as a hook drops it. It returns the resulting
+// entry, and whether it should be written.
+func (lo LoggerOpts) applyEntryHooks(ctx context.Context, entry Entry) (Entry, bool) {
+	for _, hook := range lo.EntryHooks {
+		var keep bool
+
+		entry, keep = hook.ProcessEntry(ctx, entry)
+
+		if !keep {
+			return entry, false
+		}
+	}
+
+	return entry, true
+}
+
+// entryMetadataFieldKeys are the keys of the fields included in log entries
+// after masking, which are never masked.
+var entryMetadataFieldKeys = []string{
+	MaskedFieldKey,
+	RemovedFieldsKey,
+	WouldOmitFieldKey,
+}
+
+// maskEntry applies the masking of the LoggerOpts to the message and fields of
+// an entry returned by the EntryHooks, except for the metadata fields
+// included after masking. Values which are already masked with the
+// replacement string stay unchanged.
+func (lo LoggerOpts) maskEntry(entry *Entry) {
+	metadata := make(map[string]interface{}, len(entryMetadataFieldKeys))
+
+	for _, key := range entryMetadataFieldKeys {
+		if value, ok := entry.Fields[key]; ok {
+			metadata[key] = value
+			delete(entry.Fields, key)
+		}
+	}
+
+	lo.applyMask(nil, &entry.Message, entry.Fields)
+
+	for key, value := range metadata {
+		entry.Fields[key] = value
+	}
+}
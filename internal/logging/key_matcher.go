@@ -0,0 +1,254 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// fieldKeyMatcherKind is the kind of comparison of a FieldKeyMatcher.
+type fieldKeyMatcherKind int
+
+const (
+	// fieldKeyMatcherNone is the kind of the zero value, matching nothing.
+	fieldKeyMatcherNone fieldKeyMatcherKind = iota
+	fieldKeyMatcherExact
+	fieldKeyMatcherPrefix
+	fieldKeyMatcherSuffix
+	fieldKeyMatcherGlob
+)
+
+// FieldKeyMatcher matches field keys by a pattern, rather than comparing them
+// with an exact key. Matching a field key does not allocate.
+//
+// The zero value does not match any field key.
+type FieldKeyMatcher struct {
+	kind            fieldKeyMatcherKind
+	pattern         string
+	caseInsensitive bool
+}
+
+// NewFieldKeyEqualFold returns a FieldKeyMatcher matching field keys equal to
+// `key` under Unicode case-folding, e.g. "Password" for "password".
+func NewFieldKeyEqualFold(key string) FieldKeyMatcher {
+	return FieldKeyMatcher{
+		kind:            fieldKeyMatcherExact,
+		pattern:         key,
+		caseInsensitive: true,
+	}
+}
+
+// NewFieldKeyPrefix returns a FieldKeyMatcher matching field keys starting
+// with `prefix`.
+func NewFieldKeyPrefix(prefix string) FieldKeyMatcher {
+	return FieldKeyMatcher{
+		kind:    fieldKeyMatcherPrefix,
+		pattern: prefix,
+	}
+}
+
+// NewFieldKeySuffix returns a FieldKeyMatcher matching field keys ending with
+// `suffix`.
+func NewFieldKeySuffix(suffix string) FieldKeyMatcher {
+	return FieldKeyMatcher{
+		kind:    fieldKeyMatcherSuffix,
+		pattern: suffix,
+	}
+}
+
+// NewFieldKeyGlob returns a FieldKeyMatcher matching complete field keys
+// against the glob `pattern`, where `*` matches any sequence of characters
+// and `?` matches any single character. All other characters match
+// themselves.
+func NewFieldKeyGlob(pattern string) FieldKeyMatcher {
+	return FieldKeyMatcher{
+		kind:    fieldKeyMatcherGlob,
+		pattern: pattern,
+	}
+}
+
+// CaseInsensitive returns a copy of the FieldKeyMatcher which matches field
+// keys under Unicode case-folding.
+func (m FieldKeyMatcher) CaseInsensitive() FieldKeyMatcher {
+	m.caseInsensitive = true
+
+	return m
+}
+
+// Match returns true if the FieldKeyMatcher matches the field `key`.
+func (m FieldKeyMatcher) Match(key string) bool {
+	switch m.kind {
+	case fieldKeyMatcherExact:
+		if m.caseInsensitive {
+			return strings.EqualFold(key, m.pattern)
+		}
+
+		return key == m.pattern
+	case fieldKeyMatcherPrefix:
+		if m.caseInsensitive {
+			return hasPrefixFold(key, m.pattern)
+		}
+
+		return strings.HasPrefix(key, m.pattern)
+	case fieldKeyMatcherSuffix:
+		if m.caseInsensitive {
+			return hasSuffixFold(key, m.pattern)
+		}
+
+		return strings.HasSuffix(key, m.pattern)
+	case fieldKeyMatcherGlob:
+		return globMatch(m.pattern, key, m.caseInsensitive)
+	default:
+		return false
+	}
+}
+
+// String returns a description of the FieldKeyMatcher, such as
+// "glob:*password*" or "prefix:db_ (case-insensitive)".
+func (m FieldKeyMatcher) String() string {
+	var kind string
+
+	switch m.kind {
+	case fieldKeyMatcherPrefix:
+		kind = "prefix"
+	case fieldKeyMatcherSuffix:
+		kind = "suffix"
+	case fieldKeyMatcherGlob:
+		kind = "glob"
+	case fieldKeyMatcherExact:
+		kind = "exact"
+	default:
+		return "none"
+	}
+
+	if m.caseInsensitive {
+		return kind + ":" + m.pattern + " (case-insensitive)"
+	}
+
+	return kind + ":" + m.pattern
+}
+
+// globMatch returns true if the complete `name` matches the glob `pattern`,
+// where `*` matches any sequence of characters and `?` matches any single
+// character. On a mismatch, it backtracks to the most recent `*` only, which
+// is sufficient for glob patterns and avoids compiling them.
+func globMatch(pattern, name string, caseInsensitive bool) bool {
+	px, nx := 0, 0
+
+	// The position to restart at on a mismatch, after the most recent `*`
+	// consumed one more character of `name`.
+	nextPx, nextNx := 0, 0
+
+	for px < len(pattern) || nx < len(name) {
+		if px < len(pattern) {
+			pr, pw := utf8.DecodeRuneInString(pattern[px:])
+
+			switch pr {
+			case '*':
+				nextPx = px
+
+				if nx < len(name) {
+					_, nw := utf8.DecodeRuneInString(name[nx:])
+					nextNx = nx + nw
+				} else {
+					nextNx = len(name) + 1
+				}
+
+				px += pw
+
+				continue
+			case '?':
+				if nx < len(name) {
+					_, nw := utf8.DecodeRuneInString(name[nx:])
+					px += pw
+					nx += nw
+
+					continue
+				}
+			default:
+				if nx < len(name) {
+					nr, nw := utf8.DecodeRuneInString(name[nx:])
+
+					if nr == pr || (caseInsensitive && runeEqualFold(nr, pr)) {
+						px += pw
+						nx += nw
+
+						continue
+					}
+				}
+			}
+		}
+
+		if 0 < nextNx && nextNx <= len(name) {
+			px = nextPx
+			nx = nextNx
+
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// hasPrefixFold returns true if `s` begins with `prefix` under Unicode
+// case-folding. Runes are compared one by one, as case variants may have
+// different UTF-8 lengths, such as the Kelvin sign and "k".
+func hasPrefixFold(s, prefix string) bool {
+	for prefix != "" {
+		if s == "" {
+			return false
+		}
+
+		sr, sw := utf8.DecodeRuneInString(s)
+		pr, pw := utf8.DecodeRuneInString(prefix)
+
+		if sr != pr && !runeEqualFold(sr, pr) {
+			return false
+		}
+
+		s = s[sw:]
+		prefix = prefix[pw:]
+	}
+
+	return true
+}
+
+// hasSuffixFold returns true if `s` ends with `suffix` under Unicode
+// case-folding, comparing runes one by one from the end, as with
+// hasPrefixFold.
+func hasSuffixFold(s, suffix string) bool {
+	for suffix != "" {
+		if s == "" {
+			return false
+		}
+
+		sr, sw := utf8.DecodeLastRuneInString(s)
+		pr, pw := utf8.DecodeLastRuneInString(suffix)
+
+		if sr != pr && !runeEqualFold(sr, pr) {
+			return false
+		}
+
+		s = s[:len(s)-sw]
+		suffix = suffix[:len(suffix)-pw]
+	}
+
+	return true
+}
+
+// runeEqualFold returns true if the runes are equal under Unicode
+// case-folding, as with strings.EqualFold.
+func runeEqualFold(a, b rune) bool {
+	for r := unicode.SimpleFold(a); r != a; r = unicode.SimpleFold(r) {
+		if r == b {
+			return true
+		}
+	}
+
+	return false
+}
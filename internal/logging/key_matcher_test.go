@@ -0,0 +1,165 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+func TestFieldKeyMatcher(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		matcher        logging.FieldKeyMatcher
+		expectedString string
+		matching       []string
+		notMatching    []string
+	}{
+		"zero-value": {
+			matcher:        logging.FieldKeyMatcher{},
+			expectedString: "none",
+			notMatching:    []string{"", "password"},
+		},
+		"equal-fold": {
+			matcher:        logging.NewFieldKeyEqualFold("password"),
+			expectedString: "exact:password (case-insensitive)",
+			matching:       []string{"password", "Password", "PASSWORD"},
+			notMatching:    []string{"db_password", "password_hash", "passwor"},
+		},
+		"prefix": {
+			matcher:        logging.NewFieldKeyPrefix("db_"),
+			expectedString: "prefix:db_",
+			matching:       []string{"db_", "db_password", "db_user"},
+			notMatching:    []string{"DB_PASSWORD", "d", "my_db_password"},
+		},
+		"prefix-case-insensitive": {
+			matcher:        logging.NewFieldKeyPrefix("db_").CaseInsensitive(),
+			expectedString: "prefix:db_ (case-insensitive)",
+			matching:       []string{"db_password", "DB_PASSWORD", "Db_User"},
+			notMatching:    []string{"d", "my_db_password"},
+		},
+		"suffix": {
+			matcher:        logging.NewFieldKeySuffix("_token"),
+			expectedString: "suffix:_token",
+			matching:       []string{"_token", "access_token", "refresh_token"},
+			notMatching:    []string{"ACCESS_TOKEN", "token", "token_type"},
+		},
+		"suffix-case-insensitive": {
+			matcher:        logging.NewFieldKeySuffix("_token").CaseInsensitive(),
+			expectedString: "suffix:_token (case-insensitive)",
+			matching:       []string{"access_token", "ACCESS_TOKEN"},
+			notMatching:    []string{"token", "token_type"},
+		},
+		"glob": {
+			matcher:        logging.NewFieldKeyGlob("*password*"),
+			expectedString: "glob:*password*",
+			matching:       []string{"password", "db_password", "password_hash"},
+			notMatching:    []string{"Password", "passwd"},
+		},
+		"glob-case-insensitive": {
+			matcher:        logging.NewFieldKeyGlob("*password*").CaseInsensitive(),
+			expectedString: "glob:*password* (case-insensitive)",
+			matching:       []string{"password", "Password", "DB_PASSWORD", "password_hash"},
+			notMatching:    []string{"passwd"},
+		},
+		"glob-single-character": {
+			matcher:        logging.NewFieldKeyGlob("key?"),
+			expectedString: "glob:key?",
+			matching:       []string{"key1", "keyA"},
+			notMatching:    []string{"key", "key12"},
+		},
+		"glob-special-characters": {
+			matcher:        logging.NewFieldKeyGlob("a.b[c]+*"),
+			expectedString: "glob:a.b[c]+*",
+			matching:       []string{"a.b[c]+", "a.b[c]+d"},
+			notMatching:    []string{"aXb[c]+", "a.bc+"},
+		},
+		"glob-empty": {
+			matcher:        logging.NewFieldKeyGlob(""),
+			expectedString: "glob:",
+			matching:       []string{""},
+			notMatching:    []string{"a"},
+		},
+		"glob-backtracking": {
+			matcher:        logging.NewFieldKeyGlob("*a*b?"),
+			expectedString: "glob:*a*b?",
+			matching:       []string{"abc", "xxaxxbc", "aabab1"},
+			notMatching:    []string{"ab", "ba1", "xxaxxb"},
+		},
+		"prefix-unicode-case-insensitive": {
+			matcher:        logging.NewFieldKeyPrefix("k_").CaseInsensitive(),
+			expectedString: "prefix:k_ (case-insensitive)",
+			matching:       []string{"k_1", "K_1", "\u212a_1"},
+			notMatching:    []string{"\u212a", "x_1", "_k_1"},
+		},
+		"prefix-unicode-case-insensitive-longer-pattern": {
+			matcher:        logging.NewFieldKeyPrefix("\u212a_").CaseInsensitive(),
+			expectedString: "prefix:\u212a_ (case-insensitive)",
+			matching:       []string{"k_", "K_1"},
+			notMatching:    []string{"k", "\xe2\x84_"},
+		},
+		"suffix-unicode-case-insensitive": {
+			matcher:        logging.NewFieldKeySuffix("_k").CaseInsensitive(),
+			expectedString: "suffix:_k (case-insensitive)",
+			matching:       []string{"1_k", "1_K", "1_\u212a"},
+			notMatching:    []string{"\u212a", "1_x", "1_k_"},
+		},
+		"suffix-unicode-case-insensitive-longer-pattern": {
+			matcher:        logging.NewFieldKeySuffix("_\u212a").CaseInsensitive(),
+			expectedString: "suffix:_\u212a (case-insensitive)",
+			matching:       []string{"_k", "1_K"},
+			notMatching:    []string{"k", "_\x84\xaa"},
+		},
+		"glob-unicode-case-insensitive": {
+			matcher:        logging.NewFieldKeyGlob("straße_?").CaseInsensitive(),
+			expectedString: "glob:straße_? (case-insensitive)",
+			matching:       []string{"straße_1", "STRAẞE_Ü"},
+			notMatching:    []string{"strasse_1", "straße_"},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := testCase.matcher.String(); got != testCase.expectedString {
+				t.Errorf("expected String %q, got: %q", testCase.expectedString, got)
+			}
+
+			for _, key := range testCase.matching {
+				if !testCase.matcher.Match(key) {
+					t.Errorf("expected %s to match %q", testCase.matcher, key)
+				}
+			}
+
+			for _, key := range testCase.notMatching {
+				if testCase.matcher.Match(key) {
+					t.Errorf("expected %s to not match %q", testCase.matcher, key)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFieldKeyMatcher(b *testing.B) {
+	benchmarks := map[string]logging.FieldKeyMatcher{
+		"equal-fold":              logging.NewFieldKeyEqualFold("password"),
+		"prefix-case-insensitive": logging.NewFieldKeyPrefix("db_").CaseInsensitive(),
+		"suffix-case-insensitive": logging.NewFieldKeySuffix("_token").CaseInsensitive(),
+		"glob-case-insensitive":   logging.NewFieldKeyGlob("*password*").CaseInsensitive(),
+	}
+
+	for name, matcher := range benchmarks {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for n := 0; n < b.N; n++ {
+				matcher.Match("DB_PASSWORD_HASH")
+			}
+		})
+	}
+}
@@ -41,6 +41,16 @@ const (
 	// a logger does not provide set methods for these options.
 	SDKRootLoggerOptionsKey loggerKey = "sdk-options"
 
+	// ProviderSubsystemRegistryKey is the loggerKey that will hold the
+	// SubsystemRegistry tracking the subsystem loggers created for the root
+	// provider logger.
+	ProviderSubsystemRegistryKey loggerKey = "provider-subsystems"
+
+	// SDKSubsystemRegistryKey is the loggerKey that will hold the
+	// SubsystemRegistry tracking the subsystem loggers created for the root
+	// SDK logger.
+	SDKSubsystemRegistryKey loggerKey = "sdk-subsystems"
+
 	// SinkKey is the loggerKey that will hold the logging sink used for
 	// test frameworks.
 	SinkKey loggerKey = ""
@@ -51,6 +61,11 @@ const (
 	// a logger does not provide set methods for these options.
 	SinkOptionsKey loggerKey = "sink-options"
 
+	// SinkStandardLoggerKey is the loggerKey that will hold whether the
+	// logging sink writes to the standard library log package, such as the
+	// sink of ContextWithStandardLogging.
+	SinkStandardLoggerKey loggerKey = "sink-standard-logger"
+
 	// TFLoggerOpts is the loggerKey that will hold the LoggerOpts associated
 	// with the provider root logger (at `provider.tf-logger-opts`), and the
 	// provider sub-system logger (at `provider.SUBSYSTEM.tf-logger-opts`),
@@ -58,6 +73,16 @@ const (
 	// Note that only some LoggerOpts require to be stored this way,
 	// while others use the underlying *hclog.LoggerOptions of hclog.Logger.
 	TFLoggerOpts loggerKey = "tf-logger-opts"
+
+	// RequestIDKey is the loggerKey that will hold the correlation ID of the
+	// request, which is also included as a root field of the SDK and provider
+	// loggers.
+	RequestIDKey loggerKey = "request-id"
+
+	// OperationIDKey is the loggerKey that will hold the ID of the innermost
+	// operation started with tflog.StartOperation, which is the parent ID of
+	// nested operations.
+	OperationIDKey loggerKey = "operation-id"
 )
 
 // providerSubsystemLoggerKey is the loggerKey that will hold the subsystem logger
@@ -66,6 +91,13 @@ func providerSubsystemLoggerKey(subsystem string) loggerKey {
 	return ProviderRootLoggerKey + loggerKey("."+subsystem)
 }
 
+// providerSubsystemLoggerOptionsKey is the loggerKey that will hold the
+// subsystem logger options when a provider subsystem logger is created. This
+// is to assist creating nested subsystem loggers.
+func providerSubsystemLoggerOptionsKey(subsystem string) loggerKey {
+	return ProviderRootLoggerOptionsKey + loggerKey("."+subsystem)
+}
+
 // providerRootTFLoggerOptsKey is the loggerKey that will hold
 // the LoggerOpts of the provider.
 func providerRootTFLoggerOptsKey() loggerKey {
@@ -84,6 +116,13 @@ func sdkSubsystemLoggerKey(subsystem string) loggerKey {
 	return SDKRootLoggerKey + loggerKey("."+subsystem)
 }
 
+// sdkSubsystemLoggerOptionsKey is the loggerKey that will hold the subsystem
+// logger options when an SDK subsystem logger is created. This is to assist
+// creating nested subsystem loggers.
+func sdkSubsystemLoggerOptionsKey(subsystem string) loggerKey {
+	return SDKRootLoggerOptionsKey + loggerKey("."+subsystem)
+}
+
 // sdkRootTFLoggerOptsKey is the loggerKey that will hold
 // the LoggerOpts of the SDK.
 func sdkRootTFLoggerOptsKey() loggerKey {
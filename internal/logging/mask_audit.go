@@ -0,0 +1,108 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// MaskedFieldKey is the field key of the masking audit metadata of a log
+// entry, included by loggers with MaskAudit enabled in entries with masked
+// message or field values. Its value is an object with:
+//
+//   - "fields": the sorted keys of the fields with masked values, if any.
+//   - "message_replacements": the number of replacements in the message, if
+//     any.
+//   - "rules": the sorted identifiers of the rules which masked any value,
+//     only when MaskAuditRuleIDs is enabled.
+//
+// It never includes the original values.
+const MaskedFieldKey = "@masked"
+
+// maskAudit records the masking applied to a log entry.
+type maskAudit struct {
+	fields              map[string]struct{}
+	messageReplacements int
+	rules               map[string]struct{}
+}
+
+// field records the masking of the value of the field `key` by the rule
+// `ruleID`.
+func (a *maskAudit) field(key string, ruleID string) {
+	if a.fields == nil {
+		a.fields = make(map[string]struct{})
+	}
+
+	a.fields[key] = struct{}{}
+	a.rule(ruleID)
+}
+
+// message records `replacements` replacements in the message by the rule
+// `ruleID`.
+func (a *maskAudit) message(replacements int, ruleID string) {
+	a.messageReplacements += replacements
+	a.rule(ruleID)
+}
+
+func (a *maskAudit) rule(ruleID string) {
+	if a.rules == nil {
+		a.rules = make(map[string]struct{})
+	}
+
+	a.rules[ruleID] = struct{}{}
+}
+
+// value returns the value of the MaskedFieldKey field, or nil if nothing was
+// masked or the maskAudit is nil.
+func (a *maskAudit) value(includeRuleIDs bool) map[string]interface{} {
+	if a == nil || len(a.rules) == 0 {
+		return nil
+	}
+
+	result := make(map[string]interface{}, 3)
+
+	if len(a.fields) > 0 {
+		result["fields"] = slices.Sorted(maps.Keys(a.fields))
+	}
+
+	if a.messageReplacements > 0 {
+		result["message_replacements"] = a.messageReplacements
+	}
+
+	if includeRuleIDs {
+		result["rules"] = slices.Sorted(maps.Keys(a.rules))
+	}
+
+	return result
+}
+
+// keyRuleID returns the identifier of the rule of the given kind, such as
+// "mask_field_values_with_field_keys", configured with a field key or other
+// value which is not sensitive itself.
+func keyRuleID(kind string, key string) string {
+	return kind + ":" + key
+}
+
+// indexRuleID returns the identifier of the rule of the given kind, such as
+// "mask_message_strings", by its index within the rules of the logger,
+// including inherited rules. It is used for rules configured with strings
+// which are commonly sensitive values themselves.
+func indexRuleID(kind string, index int) string {
+	return kind + "[" + strconv.Itoa(index) + "]"
+}
+
+// regexpRuleID returns the identifier of the rule of the given kind, such as
+// "mask_message_regexes", configured with the given *regexp.Regexp. The
+// identifier of rules of the common secrets rule set is their name instead,
+// such as "common_secrets:jwt".
+func regexpRuleID(kind string, r *regexp.Regexp) string {
+	if name, ok := commonSecretRuleName(r); ok {
+		return keyRuleID("common_secrets", name)
+	}
+
+	return keyRuleID(kind, r.String())
+}
@@ -0,0 +1,115 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"reflect"
+	"slices"
+)
+
+// FieldValueMasker masks field values with a function, such as to keep the
+// host of a URL while masking its credentials and query parameters.
+type FieldValueMasker struct {
+	// Keys are the field keys whose values are masked. When empty, all
+	// field values are masked.
+	Keys []string
+
+	// Func returns the masked value of a field value, of any type. It must
+	// not modify the given value, such as a map, but return a new value
+	// instead. Returning the given value leaves the field value as-is.
+	Func func(value interface{}) interface{}
+}
+
+// appliesTo returns true if the FieldValueMasker masks the value of the
+// field `key`.
+func (m FieldValueMasker) appliesTo(key string) bool {
+	return len(m.Keys) == 0 || slices.Contains(m.Keys, key)
+}
+
+// applyFieldValueMaskers masks the values of the given fields with the
+// MaskFieldValuesWithFuncs, in order, recording it in the given maskAudit, if
+// any. Values which are already fully masked are not given to the functions.
+// It returns true if any field value was masked.
+func (lo LoggerOpts) applyFieldValueMaskers(audit *maskAudit, fieldMaps ...map[string]interface{}) bool {
+	masked := false
+
+	for i, m := range lo.MaskFieldValuesWithFuncs {
+		for _, f := range fieldMaps {
+			for fk, fv := range f {
+				// Skip values which are already fully masked, such as by
+				// field key
+				if !m.appliesTo(fk) || fv == logMaskingReplacementString {
+					continue
+				}
+
+				maskedValue := m.Func(fv)
+
+				if sameValue(fv, maskedValue) {
+					continue
+				}
+
+				if audit != nil {
+					audit.field(fk, indexRuleID("mask_field_values_with_funcs", i))
+				}
+
+				f[fk] = maskedValue
+				masked = true
+			}
+		}
+	}
+
+	return masked
+}
+
+// applyMessageMaskers masks the message with the MaskMessageWithFuncs, in
+// order, recording it in the given maskAudit, if any. It returns true if the
+// message was masked.
+func (lo LoggerOpts) applyMessageMaskers(audit *maskAudit, msg *string) bool {
+	masked := false
+
+	for i, fn := range lo.MaskMessageWithFuncs {
+		maskedMsg := fn(*msg)
+
+		if maskedMsg == *msg {
+			continue
+		}
+
+		if audit != nil {
+			audit.message(1, indexRuleID("mask_message_with_funcs", i))
+		}
+
+		*msg = maskedMsg
+		masked = true
+	}
+
+	return masked
+}
+
+// sameValue returns true if `a` and `b` are the same value. Values which
+// are not comparable, such as maps and slices, are the same when they
+// reference the same underlying data, which avoids comparing them deeply.
+func sameValue(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	valueA, valueB := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	if valueA.Type() != valueB.Type() {
+		return false
+	}
+
+	if valueA.Comparable() && valueB.Comparable() {
+		return a == b
+	}
+
+	switch valueA.Kind() {
+	case reflect.Map, reflect.Func:
+		return valueA.Pointer() == valueB.Pointer()
+	case reflect.Slice:
+		return valueA.Pointer() == valueB.Pointer() && valueA.Len() == valueB.Len()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+func TestApplyMask_Funcs(t *testing.T) {
+	t.Parallel()
+
+	upper := func(value interface{}) interface{} {
+		s, ok := value.(string)
+
+		if !ok {
+			return value
+		}
+
+		return strings.ToUpper(s)
+	}
+
+	testCases := map[string]struct {
+		lOpts          logging.LoggerOpts
+		msg            string
+		fields         map[string]interface{}
+		expectedMsg    string
+		expectedFields map[string]interface{}
+		expectedMasked bool
+	}{
+		"no-funcs": {
+			lOpts:          logging.LoggerOpts{},
+			msg:            testLogMsg,
+			fields:         map[string]interface{}{"k1": "v1"},
+			expectedMsg:    testLogMsg,
+			expectedFields: map[string]interface{}{"k1": "v1"},
+			expectedMasked: false,
+		},
+		"field-keys": {
+			lOpts: logging.LoggerOpts{
+				MaskFieldValuesWithFuncs: []logging.FieldValueMasker{
+					{Keys: []string{"k1"}, Func: upper},
+				},
+			},
+			msg:            testLogMsg,
+			fields:         map[string]interface{}{"k1": "v1", "k2": "v2"},
+			expectedMsg:    testLogMsg,
+			expectedFields: map[string]interface{}{"k1": "V1", "k2": "v2"},
+			expectedMasked: true,
+		},
+		"field-all-keys": {
+			lOpts: logging.LoggerOpts{
+				MaskFieldValuesWithFuncs: []logging.FieldValueMasker{
+					{Func: upper},
+				},
+			},
+			msg:            testLogMsg,
+			fields:         map[string]interface{}{"k1": "v1", "k2": "v2", "k3": 3},
+			expectedMsg:    testLogMsg,
+			expectedFields: map[string]interface{}{"k1": "V1", "k2": "V2", "k3": 3},
+			expectedMasked: true,
+		},
+		"field-unchanged": {
+			lOpts: logging.LoggerOpts{
+				MaskFieldValuesWithFuncs: []logging.FieldValueMasker{
+					{Func: upper},
+				},
+			},
+			msg: testLogMsg,
+			fields: map[string]interface{}{
+				"k1": "V1",
+				"k2": map[string]interface{}{"nested": "v2"},
+				"k3": []interface{}{"v3"},
+			},
+			expectedMsg: testLogMsg,
+			expectedFields: map[string]interface{}{
+				"k1": "V1",
+				"k2": map[string]interface{}{"nested": "v2"},
+				"k3": []interface{}{"v3"},
+			},
+			expectedMasked: false,
+		},
+		"field-non-string": {
+			lOpts: logging.LoggerOpts{
+				MaskFieldValuesWithFuncs: []logging.FieldValueMasker{
+					{
+						Keys: []string{"headers"},
+						Func: func(value interface{}) interface{} {
+							headers, ok := value.(map[string]interface{})
+
+							if !ok {
+								return value
+							}
+
+							result := make(map[string]interface{}, len(headers))
+
+							for k, v := range headers {
+								if k == "Authorization" {
+									v = "***"
+								}
+
+								result[k] = v
+							}
+
+							return result
+						},
+					},
+					{
+						Keys: []string{"port"},
+						Func: func(value interface{}) interface{} {
+							return 0
+						},
+					},
+				},
+			},
+			msg: testLogMsg,
+			fields: map[string]interface{}{
+				"headers": map[string]interface{}{
+					"Accept":        "application/json",
+					"Authorization": "Bearer token",
+				},
+				"port": 8080,
+			},
+			expectedMsg: testLogMsg,
+			expectedFields: map[string]interface{}{
+				"headers": map[string]interface{}{
+					"Accept":        "application/json",
+					"Authorization": "***",
+				},
+				"port": 0,
+			},
+			expectedMasked: true,
+		},
+		"field-order": {
+			lOpts: logging.LoggerOpts{
+				MaskFieldValuesWithFieldKeys: []string{"k1"},
+				MaskFieldValuesWithFuncs: []logging.FieldValueMasker{
+					{
+						Func: func(value interface{}) interface{} {
+							return fmt.Sprint(value) + "-secret"
+						},
+					},
+					{Func: upper},
+				},
+				MaskAllFieldValuesStrings: []string{"-SECRET"},
+			},
+			msg:         testLogMsg,
+			fields:      map[string]interface{}{"k1": "v1", "k2": "v2"},
+			expectedMsg: testLogMsg,
+			expectedFields: map[string]interface{}{
+				"k1": "***",
+				"k2": "V2***",
+			},
+			expectedMasked: true,
+		},
+		"message": {
+			lOpts: logging.LoggerOpts{
+				MaskMessageWithFuncs: []func(string) string{
+					func(msg string) string {
+						return strings.ReplaceAll(msg, "incorrectly configured", "misconfigured")
+					},
+				},
+			},
+			msg:            testLogMsg,
+			expectedMsg:    "System FOO has caused error BAR because of misconfigured BAZ",
+			expectedMasked: true,
+		},
+		"message-unchanged": {
+			lOpts: logging.LoggerOpts{
+				MaskMessageWithFuncs: []func(string) string{
+					strings.TrimSpace,
+				},
+			},
+			msg:            testLogMsg,
+			expectedMsg:    testLogMsg,
+			expectedMasked: false,
+		},
+		"message-order": {
+			lOpts: logging.LoggerOpts{
+				MaskMessageWithFuncs: []func(string) string{
+					strings.ToLower,
+				},
+				MaskMessageRegexes: []*regexp.Regexp{regexp.MustCompile("ba[rz]")},
+				MaskMessageStrings: []string{"foo"},
+			},
+			msg:            testLogMsg,
+			expectedMsg:    "system *** has caused error *** because of incorrectly configured ***",
+			expectedMasked: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			msg := testCase.msg
+
+			gotMasked := testCase.lOpts.ApplyMask(&msg, testCase.fields)
+
+			if gotMasked != testCase.expectedMasked {
+				t.Errorf("expected masked %t, got: %t", testCase.expectedMasked, gotMasked)
+			}
+
+			if msg != testCase.expectedMsg {
+				t.Errorf("expected message %q, got: %q", testCase.expectedMsg, msg)
+			}
+
+			if diff := cmp.Diff(testCase.expectedFields, testCase.fields); diff != "" {
+				t.Errorf("unexpected fields difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// WouldOmitFieldKey is the field key identifying the rule which would omit a
+// log entry, such as "omit_log_with_message_regexes:^Polling", included by
+// loggers with OmitDryRun enabled instead of omitting the entry.
+const WouldOmitFieldKey = "@would_omit"
+
+// OmitLogFunc returns true if a log entry at `level`, with the message `msg`
+// and the fields `fields`, should be omitted. The message and fields are
+// given before masking. The fields must not be modified.
+type OmitLogFunc func(level hclog.Level, msg string, fields map[string]interface{}) bool
+
+// OmitRule is a predicate omitting log entries, such as entries with a field
+// equal to a value, which can be limited to entries below a level.
+//
+// The zero value does not omit any log entry.
+type OmitRule struct {
+	description string
+	belowLevel  hclog.Level
+	match       OmitLogFunc
+}
+
+// NewOmitRuleFieldValues returns an OmitRule omitting log entries with the
+// field `key` equal to any of `values`. Values are compared by their default
+// formatting, as with fmt.Sprint, so the value 200 matches field values of any
+// integer type, as well as the string "200".
+func NewOmitRuleFieldValues(key string, values ...interface{}) OmitRule {
+	formattedValues := make([]string, 0, len(values))
+
+	for _, value := range values {
+		formattedValues = append(formattedValues, fmt.Sprint(value))
+	}
+
+	return OmitRule{
+		description: "field_values:" + key,
+		match: func(_ hclog.Level, _ string, fields map[string]interface{}) bool {
+			value, ok := fields[key]
+
+			if !ok {
+				return false
+			}
+
+			formattedValue := fmt.Sprint(value)
+
+			for _, v := range formattedValues {
+				if formattedValue == v {
+					return true
+				}
+			}
+
+			return false
+		},
+	}
+}
+
+// NewOmitRuleFieldValueRegexes returns an OmitRule omitting log entries with
+// the field `key` matching any of the given *regexp.Regexp. Field values which
+// are not strings are matched by their default formatting, as with
+// fmt.Sprint.
+func NewOmitRuleFieldValueRegexes(key string, expressions ...*regexp.Regexp) OmitRule {
+	return OmitRule{
+		description: "field_value_regexes:" + key + "=" + strings.Join(regexpsToPatterns(expressions), ","),
+		match: func(_ hclog.Level, _ string, fields map[string]interface{}) bool {
+			value, ok := fields[key]
+
+			if !ok {
+				return false
+			}
+
+			valueStr, ok := value.(string)
+
+			if !ok {
+				valueStr = fmt.Sprint(value)
+			}
+
+			for _, r := range expressions {
+				if r.MatchString(valueStr) {
+					return true
+				}
+			}
+
+			return false
+		},
+	}
+}
+
+// NewOmitRuleMessageRegexes returns an OmitRule omitting log entries with a
+// message matching any of the given *regexp.Regexp.
+func NewOmitRuleMessageRegexes(expressions ...*regexp.Regexp) OmitRule {
+	return OmitRule{
+		description: "message_regexes:" + strings.Join(regexpsToPatterns(expressions), ","),
+		match: func(_ hclog.Level, msg string, _ map[string]interface{}) bool {
+			for _, r := range expressions {
+				if r.MatchString(msg) {
+					return true
+				}
+			}
+
+			return false
+		},
+	}
+}
+
+// NewOmitRuleFunc returns an OmitRule omitting log entries for which `fn`
+// returns true.
+func NewOmitRuleFunc(fn OmitLogFunc) OmitRule {
+	return OmitRule{
+		description: "func",
+		match:       fn,
+	}
+}
+
+// BelowLevel returns a copy of the OmitRule which only omits log entries
+// below `level`, i.e. less severe. For example, a rule below hclog.Info only
+// omits trace and debug entries.
+func (r OmitRule) BelowLevel(level hclog.Level) OmitRule {
+	r.belowLevel = level
+
+	return r
+}
+
+// Match returns true if the OmitRule omits the log entry at `level`, with the
+// message `msg` and the fields `fields`.
+func (r OmitRule) Match(level hclog.Level, msg string, fields map[string]interface{}) bool {
+	if r.match == nil {
+		return false
+	}
+
+	if r.belowLevel != hclog.NoLevel && level >= r.belowLevel {
+		return false
+	}
+
+	return r.match(level, msg, fields)
+}
+
+// String returns a description of the OmitRule, such as
+// "field_values:http_status (below INFO)". Field values are not included, as
+// those could be sensitive.
+func (r OmitRule) String() string {
+	if r.match == nil {
+		return "none"
+	}
+
+	if r.belowLevel != hclog.NoLevel {
+		return r.description + " (below " + strings.ToUpper(r.belowLevel.String()) + ")"
+	}
+
+	return r.description
+}
+
+// ShouldOmitWithRules returns true if any of the OmitLogWithRules of the
+// LoggerOpts omits the log entry at `level`, with the message `msg` and the
+// fields `fields`.
+func (lo LoggerOpts) ShouldOmitWithRules(level hclog.Level, msg string, fields map[string]interface{}) bool {
+	_, omit := lo.shouldOmitWithRules(level, msg, fields)
+
+	return omit
+}
+
+// shouldOmitWithRules is ShouldOmitWithRules, also returning the identifier
+// of the OmitRule which omits the log entry, such as
+// "omit_log_with_rules:field_values:http_status".
+func (lo LoggerOpts) shouldOmitWithRules(level hclog.Level, msg string, fields map[string]interface{}) (string, bool) {
+	for _, r := range lo.OmitLogWithRules {
+		if r.Match(level, msg, fields) {
+			return keyRuleID("omit_log_with_rules", r.String()), true
+		}
+	}
+
+	return "", false
+}
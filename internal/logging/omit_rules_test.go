@@ -0,0 +1,147 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+func TestOmitRule(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		rule           logging.OmitRule
+		level          hclog.Level
+		msg            string
+		fields         map[string]interface{}
+		expectedMatch  bool
+		expectedString string
+	}{
+		"zero-value": {
+			rule:           logging.OmitRule{},
+			level:          hclog.Debug,
+			msg:            testLogMsg,
+			expectedMatch:  false,
+			expectedString: "none",
+		},
+		"field-values-match": {
+			rule:           logging.NewOmitRuleFieldValues("http_status", 200, 204),
+			level:          hclog.Debug,
+			msg:            testLogMsg,
+			fields:         map[string]interface{}{"http_status": int64(204)},
+			expectedMatch:  true,
+			expectedString: "field_values:http_status",
+		},
+		"field-values-match-string": {
+			rule:           logging.NewOmitRuleFieldValues("http_status", 200),
+			level:          hclog.Debug,
+			msg:            testLogMsg,
+			fields:         map[string]interface{}{"http_status": "200"},
+			expectedMatch:  true,
+			expectedString: "field_values:http_status",
+		},
+		"field-values-mismatch": {
+			rule:           logging.NewOmitRuleFieldValues("http_status", 200),
+			level:          hclog.Debug,
+			msg:            testLogMsg,
+			fields:         map[string]interface{}{"http_status": 404},
+			expectedMatch:  false,
+			expectedString: "field_values:http_status",
+		},
+		"field-values-missing-key": {
+			rule:           logging.NewOmitRuleFieldValues("http_status", 200),
+			level:          hclog.Debug,
+			msg:            testLogMsg,
+			fields:         map[string]interface{}{"status": 200},
+			expectedMatch:  false,
+			expectedString: "field_values:http_status",
+		},
+		"field-value-regexes-match": {
+			rule:           logging.NewOmitRuleFieldValueRegexes("http_status", regexp.MustCompile(`^2\d\d$`)),
+			level:          hclog.Debug,
+			msg:            testLogMsg,
+			fields:         map[string]interface{}{"http_status": 201},
+			expectedMatch:  true,
+			expectedString: `field_value_regexes:http_status=^2\d\d$`,
+		},
+		"field-value-regexes-mismatch": {
+			rule:           logging.NewOmitRuleFieldValueRegexes("path", regexp.MustCompile(`^/health`)),
+			level:          hclog.Debug,
+			msg:            testLogMsg,
+			fields:         map[string]interface{}{"path": "/v1/instances"},
+			expectedMatch:  false,
+			expectedString: `field_value_regexes:path=^/health`,
+		},
+		"message-regexes-match": {
+			rule:           logging.NewOmitRuleMessageRegexes(regexp.MustCompile("BAR"), regexp.MustCompile("QUX")),
+			level:          hclog.Debug,
+			msg:            testLogMsg,
+			expectedMatch:  true,
+			expectedString: "message_regexes:BAR,QUX",
+		},
+		"func-match": {
+			rule: logging.NewOmitRuleFunc(func(level hclog.Level, msg string, fields map[string]interface{}) bool {
+				return level == hclog.Warn && strings.HasPrefix(msg, "System") && fields["k1"] == "v1"
+			}),
+			level:          hclog.Warn,
+			msg:            testLogMsg,
+			fields:         map[string]interface{}{"k1": "v1"},
+			expectedMatch:  true,
+			expectedString: "func",
+		},
+		"func-mismatch": {
+			rule: logging.NewOmitRuleFunc(func(level hclog.Level, _ string, _ map[string]interface{}) bool {
+				return level == hclog.Warn
+			}),
+			level:          hclog.Error,
+			msg:            testLogMsg,
+			expectedMatch:  false,
+			expectedString: "func",
+		},
+		"below-level-match": {
+			rule:           logging.NewOmitRuleFieldValues("http_status", 200).BelowLevel(hclog.Info),
+			level:          hclog.Debug,
+			msg:            testLogMsg,
+			fields:         map[string]interface{}{"http_status": 200},
+			expectedMatch:  true,
+			expectedString: "field_values:http_status (below INFO)",
+		},
+		"below-level-at-level": {
+			rule:           logging.NewOmitRuleFieldValues("http_status", 200).BelowLevel(hclog.Info),
+			level:          hclog.Info,
+			msg:            testLogMsg,
+			fields:         map[string]interface{}{"http_status": 200},
+			expectedMatch:  false,
+			expectedString: "field_values:http_status (below INFO)",
+		},
+		"below-level-above-level": {
+			rule:           logging.NewOmitRuleFieldValues("http_status", 200).BelowLevel(hclog.Info),
+			level:          hclog.Error,
+			msg:            testLogMsg,
+			fields:         map[string]interface{}{"http_status": 200},
+			expectedMatch:  false,
+			expectedString: "field_values:http_status (below INFO)",
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := testCase.rule.Match(testCase.level, testCase.msg, testCase.fields); got != testCase.expectedMatch {
+				t.Errorf("expected Match to return %t, got %t", testCase.expectedMatch, got)
+			}
+
+			if got := testCase.rule.String(); got != testCase.expectedString {
+				t.Errorf("expected String %q, got: %q", testCase.expectedString, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// GetOperationID returns the ID of the innermost operation in `ctx`. If no
+// operation has been started, it will return an empty string.
+func GetOperationID(ctx context.Context) string {
+	operationID, ok := ctx.Value(OperationIDKey).(string)
+	if !ok {
+		return ""
+	}
+
+	return operationID
+}
+
+// SetOperationID sets `operationID` as the ID of the innermost operation.
+func SetOperationID(ctx context.Context, operationID string) context.Context {
+	return context.WithValue(ctx, OperationIDKey, operationID)
+}
+
+// NewOperationID returns a new random operation ID.
+func NewOperationID() string {
+	id := make([]byte, 8)
+
+	// crypto/rand.Read never returns an error
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"os"
 	"regexp"
+	"slices"
+	"sync/atomic"
 
 	"github.com/hashicorp/go-hclog"
 )
@@ -19,6 +21,10 @@ type LoggerOpts struct {
 	// Name is the name or "@module" of a logger.
 	Name string
 
+	// Subsystem is the name of the subsystem of a subsystem logger. It is
+	// empty for root loggers.
+	Subsystem string
+
 	// Level is the most verbose level that a logger will write logs for.
 	Level hclog.Level
 
@@ -52,6 +58,84 @@ type LoggerOpts struct {
 	// at the time of new subsystem creation.
 	IncludeRootFields bool
 
+	// ApplyRulesToSubsystems indicates whether the omit and mask rules of a
+	// root logger should also be applied by all of its subsystem loggers,
+	// including the ones created afterwards. This is performed each time a
+	// subsystem logger writes a log, so rules added to the root logger
+	// afterwards are also applied.
+	ApplyRulesToSubsystems bool
+
+	// TraceContextExtractor extracts the IDs of the active trace and span
+	// from the context.Context of each log, which are then included as the
+	// trace_id and span_id fields. Subsystem loggers without their own
+	// TraceContextExtractor use the one of their root logger.
+	TraceContextExtractor TraceContextExtractor
+
+	// EntryHooks process each log entry, after omission and masking, in
+	// order. Subsystem loggers run their own EntryHooks first, followed by
+	// the ones of their root logger.
+	EntryHooks []EntryHook
+
+	// SpanEventBridge records each log entry, after omission and masking,
+	// as an event on the active trace span in the context.Context of the log.
+	// Subsystem loggers without their own SpanEventBridge use the one of
+	// their root logger.
+	SpanEventBridge SpanEventBridge
+
+	// Stats counts the log entries emitted, omitted and masked by the
+	// logger. Subsystem loggers without their own StatsCollector use the one
+	// of their root logger.
+	Stats *StatsCollector
+
+	// statsLogger caches the counters of the log entries of the logger, as
+	// set by WithStatsLogger.
+	statsLogger *loggerStats
+
+	// MaskAudit indicates whether the logger should include the
+	// MaskedFieldKey field, listing the masked field keys and the number of
+	// replacements in the message, in each log with masked values. The
+	// original values are never included. Subsystem loggers also include it
+	// when their root logger does.
+	MaskAudit bool
+
+	// MaskAuditRuleIDs indicates whether the MaskedFieldKey field should
+	// also list the identifiers of the rules which masked any value, such as
+	// "mask_field_values_with_field_keys:password". It only has an effect
+	// together with MaskAudit.
+	MaskAuditRuleIDs bool
+
+	// ExcludeInheritedRules indicates whether a subsystem logger should
+	// ignore the omit and mask rules of its root logger, even when the root
+	// logger applies them to subsystems.
+	ExcludeInheritedRules bool
+
+	// FieldAllowlist are the only field keys the logger writes, when the
+	// FieldAllowlistMode is not FieldAllowlistModeOff. All other fields,
+	// including the fields of the logger itself, are either dropped or
+	// masked, depending on the FieldAllowlistMode, and their keys are listed
+	// in the RemovedFieldsKey field. This happens after omission, so the omit
+	// rules still apply to all fields, and before masking.
+	//
+	// Example:
+	//
+	//   FieldAllowlist = `['foo']`, FieldAllowlistMode = FieldAllowlistModeDrop
+	//
+	//   log1 = `{ msg = "...", fields = { 'foo': '...', 'bar': '...' }`  -> `{ 'foo': '...', '@removed_fields': ['bar'] }`
+	//   log2 = `{ msg = "...", fields = { 'foo': '...' }`                -> as-is
+	//
+	FieldAllowlist []string
+
+	// FieldAllowlistMode determines whether the logger only writes the fields
+	// on the FieldAllowlist, and whether it drops or masks the other fields.
+	FieldAllowlistMode FieldAllowlistMode
+
+	// OmitDryRun indicates whether the logger should write the logs its omit
+	// rules would omit, with the WouldOmitFieldKey field identifying the rule
+	// which would omit them, rather than omitting them. A subsystem logger
+	// applying the rules of its root logger is in dry-run mode when either of
+	// them is.
+	OmitDryRun bool
+
 	// OmitLogWithFieldKeys indicates that the logger should omit to write
 	// any log when any of the given keys is found within the fields.
 	//
@@ -65,6 +149,19 @@ type LoggerOpts struct {
 	//
 	OmitLogWithFieldKeys []string
 
+	// OmitLogWithFieldKeyMatchers indicates that the logger should omit to
+	// write any log when any key within the fields matches any of the given
+	// FieldKeyMatcher.
+	//
+	// Example:
+	//
+	//   OmitLogWithFieldKeyMatchers = `[NewFieldKeyPrefix("debug_")]`
+	//
+	//   log1 = `{ msg = "...", fields = { 'debug_body': '...' }`  -> omitted
+	//   log2 = `{ msg = "...", fields = { 'bar': '...' }`         -> printed
+	//
+	OmitLogWithFieldKeyMatchers []FieldKeyMatcher
+
 	// OmitLogWithMessageRegexes indicates that the logger should omit to write
 	// any log that matches any of the given *regexp.Regexp.
 	//
@@ -91,6 +188,20 @@ type LoggerOpts struct {
 	//
 	OmitLogWithMessageStrings []string
 
+	// OmitLogWithRules indicates that the logger should omit to write any
+	// log that any of the given OmitRule omits, such as a log with a field
+	// equal to a value, optionally only below a level.
+	//
+	// Example:
+	//
+	//   OmitLogWithRules = `[NewOmitRuleFieldValues("http_status", 200).BelowLevel(hclog.Info)]`
+	//
+	//   log1 = `{ level = "debug", msg = "...", fields = { 'http_status': 200 }`  -> omitted
+	//   log2 = `{ level = "debug", msg = "...", fields = { 'http_status': 404 }`  -> printed
+	//   log3 = `{ level = "info", msg = "...", fields = { 'http_status': 200 }`   -> printed
+	//
+	OmitLogWithRules []OmitRule
+
 	// MaskFieldValuesWithFieldKeys indicates that the logger should mask with asterisks (`*`)
 	// any field value where the key matches one of the given keys.
 	//
@@ -104,10 +215,41 @@ type LoggerOpts struct {
 	//
 	MaskFieldValuesWithFieldKeys []string
 
+	// MaskFieldValuesWithFieldKeyMatchers indicates that the logger should
+	// mask with asterisks (`*`) any field value where the key matches any of
+	// the given FieldKeyMatcher.
+	//
+	// Example:
+	//
+	//   MaskFieldValuesWithFieldKeyMatchers = `[NewFieldKeyGlob("*password*").CaseInsensitive()]`
+	//
+	//   log1 = `{ msg = "...", fields = { 'Password': '***', 'bar': '...' }`  -> masked value
+	//   log2 = `{ msg = "...", fields = { 'bar': '...' }`                     -> as-is value
+	//   log3 = `{ msg = "...", fields = { 'db_password': '***' }`             -> masked value
+	//
+	MaskFieldValuesWithFieldKeyMatchers []FieldKeyMatcher
+
+	// MaskFieldValuesWithFuncs indicates that the logger should replace the
+	// values of fields with the given keys, or of all fields when no keys are
+	// given, with the value returned by the function of the given
+	// FieldValueMasker, in order. Field values of any type are masked.
+	//
+	// Example:
+	//
+	//   MaskFieldValuesWithFuncs = `[{ Keys: ['url'], Func: redactURL }]`
+	//
+	//   log1 = `{ msg = "...", fields = { 'url': 'https://host/***' }`  -> masked value
+	//   log2 = `{ msg = "...", fields = { 'bar': '...' }`               -> as-is value
+	//
+	MaskFieldValuesWithFuncs []FieldValueMasker
+
 	// MaskAllFieldValuesRegexes indicates that the logger should replace, within
 	// all the log field values, the portion matching one of the given *regexp.Regexp.
 	//
-	// Note that the replacement will happen, only for field values that are of type string.
+	// Note that the replacement will happen, only for field values that are strings,
+	// or that are rendered as text: []byte, error, fmt.Stringer and numbers. Those
+	// are replaced with their masked text, while values without any replacement
+	// keep their type.
 	//
 	// Example:
 	//
@@ -122,7 +264,10 @@ type LoggerOpts struct {
 	// MaskAllFieldValuesStrings indicates that the logger should replace, within
 	// all the log field values, the portion equal to one of the given strings.
 	//
-	// Note that the replacement will happen, only for field values that are of type string.
+	// Note that the replacement will happen, only for field values that are strings,
+	// or that are rendered as text: []byte, error, fmt.Stringer and numbers. Those
+	// are replaced with their masked text, while values without any replacement
+	// keep their type.
 	//
 	// Example:
 	//
@@ -131,8 +276,23 @@ type LoggerOpts struct {
 	//   log1 = `{ msg = "...", fields = { 'k1': '***', 'k2': 'bar', 'k3': '***' }`  -> masked value
 	//   log2 = `{ msg = "...", fields = { 'k1': 'boo', 'k2': 'far', 'k3': '***' }`  -> as-is value
 	//   log2 = `{ msg = "...", fields = { 'k1': '*** bar ***' }`                    -> masked value
+	//
+	// Where strings overlap within a field value, the longest match is masked
+	// first, so a string is masked as a whole even if it contains others.
 	MaskAllFieldValuesStrings []string
 
+	// MaskMessageWithFuncs indicates that the logger should replace the log
+	// message with the one returned by the given functions, in order.
+	//
+	// Example:
+	//
+	//   MaskMessageWithFuncs = `[redactURLs]`
+	//
+	//   log1 = `{ msg = "request to https://host/***", fields = {...}`  -> masked message
+	//   log2 = `{ msg = "pineapple mango", fields = {...}`             -> as-is
+	//
+	MaskMessageWithFuncs []func(msg string) string
+
 	// MaskMessageRegexes indicates that the logger should replace, within
 	// a log message, the portion matching one of the given *regexp.Regexp.
 	//
@@ -157,7 +317,49 @@ type LoggerOpts struct {
 	//   log2 = `{ msg = "pineapple mango", fields = {...}`      -> as-is
 	//   log3 = `{ msg = "pineapple mango ***", fields = {...}`  -> masked portion
 	//
+	// Where strings overlap within a message, the longest match is masked
+	// first, so a string is masked as a whole even if it contains others.
 	MaskMessageStrings []string
+
+	// maskAllFieldValuesStringsMatcher is the stringMatcher compiled for the
+	// MaskAllFieldValuesStrings by WithMaskAllFieldValuesStrings, so log
+	// entries are masked in a single pass, regardless of the number of
+	// strings.
+	maskAllFieldValuesStringsMatcher *stringMatcher
+
+	// maskMessageStringsMatcher is the stringMatcher compiled for the
+	// MaskMessageStrings by WithMaskMessageStrings.
+	maskMessageStringsMatcher *stringMatcher
+
+	// revision identifies root LoggerOpts saved into a context.Context, and
+	// changes each time they are saved, so the effective LoggerOpts of
+	// subsystem loggers are only computed again when the root LoggerOpts
+	// change.
+	revision *optsRevision
+
+	// effective caches the effective LoggerOpts of subsystem LoggerOpts
+	// saved into a context.Context, and is replaced each time they are saved.
+	effective *effectiveOptsCache
+}
+
+// optsRevision is a revision of root LoggerOpts. It is not empty, so each
+// revision has a distinct address.
+type optsRevision struct {
+	_ byte
+}
+
+// effectiveOptsCache holds the last effective LoggerOpts computed for
+// subsystem LoggerOpts, with the revision of the root LoggerOpts they
+// inherit from.
+type effectiveOptsCache struct {
+	last atomic.Pointer[effectiveOpts]
+}
+
+// effectiveOpts are effective subsystem LoggerOpts, computed with the root
+// LoggerOpts of the revision `root`.
+type effectiveOpts struct {
+	root *optsRevision
+	opts LoggerOpts
 }
 
 // Copy creates a duplicate LoggerOpts. This should be used to ensure
@@ -165,22 +367,42 @@ type LoggerOpts struct {
 // new context.Context.
 func (o LoggerOpts) Copy() LoggerOpts {
 	result := LoggerOpts{
-		AdditionalLocationOffset:     o.AdditionalLocationOffset,
-		Fields:                       make(map[string]any, len(o.Fields)),
-		IncludeLocation:              o.IncludeLocation,
-		IncludeRootFields:            o.IncludeRootFields,
-		IncludeTime:                  o.IncludeTime,
-		Level:                        o.Level,
-		MaskAllFieldValuesRegexes:    make([]*regexp.Regexp, len(o.MaskAllFieldValuesRegexes)),
-		MaskAllFieldValuesStrings:    make([]string, len(o.MaskAllFieldValuesStrings)),
-		MaskFieldValuesWithFieldKeys: make([]string, len(o.MaskFieldValuesWithFieldKeys)),
-		MaskMessageRegexes:           make([]*regexp.Regexp, len(o.MaskMessageRegexes)),
-		MaskMessageStrings:           make([]string, len(o.MaskMessageStrings)),
-		Name:                         o.Name,
-		OmitLogWithFieldKeys:         make([]string, len(o.OmitLogWithFieldKeys)),
-		OmitLogWithMessageRegexes:    make([]*regexp.Regexp, len(o.OmitLogWithMessageRegexes)),
-		OmitLogWithMessageStrings:    make([]string, len(o.OmitLogWithMessageStrings)),
-		Output:                       o.Output,
+		AdditionalLocationOffset:            o.AdditionalLocationOffset,
+		ApplyRulesToSubsystems:              o.ApplyRulesToSubsystems,
+		EntryHooks:                          make([]EntryHook, len(o.EntryHooks)),
+		ExcludeInheritedRules:               o.ExcludeInheritedRules,
+		FieldAllowlist:                      make([]string, len(o.FieldAllowlist)),
+		FieldAllowlistMode:                  o.FieldAllowlistMode,
+		Fields:                              make(map[string]any, len(o.Fields)),
+		IncludeLocation:                     o.IncludeLocation,
+		IncludeRootFields:                   o.IncludeRootFields,
+		IncludeTime:                         o.IncludeTime,
+		Level:                               o.Level,
+		MaskAudit:                           o.MaskAudit,
+		MaskAuditRuleIDs:                    o.MaskAuditRuleIDs,
+		MaskAllFieldValuesRegexes:           make([]*regexp.Regexp, len(o.MaskAllFieldValuesRegexes)),
+		MaskAllFieldValuesStrings:           make([]string, len(o.MaskAllFieldValuesStrings)),
+		MaskFieldValuesWithFieldKeys:        make([]string, len(o.MaskFieldValuesWithFieldKeys)),
+		MaskFieldValuesWithFieldKeyMatchers: make([]FieldKeyMatcher, len(o.MaskFieldValuesWithFieldKeyMatchers)),
+		MaskFieldValuesWithFuncs:            make([]FieldValueMasker, len(o.MaskFieldValuesWithFuncs)),
+		MaskMessageRegexes:                  make([]*regexp.Regexp, len(o.MaskMessageRegexes)),
+		MaskMessageStrings:                  make([]string, len(o.MaskMessageStrings)),
+		MaskMessageWithFuncs:                make([]func(string) string, len(o.MaskMessageWithFuncs)),
+		maskAllFieldValuesStringsMatcher:    o.maskAllFieldValuesStringsMatcher,
+		maskMessageStringsMatcher:           o.maskMessageStringsMatcher,
+		statsLogger:                         o.statsLogger,
+		Name:                                o.Name,
+		OmitDryRun:                          o.OmitDryRun,
+		OmitLogWithFieldKeys:                make([]string, len(o.OmitLogWithFieldKeys)),
+		OmitLogWithFieldKeyMatchers:         make([]FieldKeyMatcher, len(o.OmitLogWithFieldKeyMatchers)),
+		OmitLogWithMessageRegexes:           make([]*regexp.Regexp, len(o.OmitLogWithMessageRegexes)),
+		OmitLogWithMessageStrings:           make([]string, len(o.OmitLogWithMessageStrings)),
+		OmitLogWithRules:                    make([]OmitRule, len(o.OmitLogWithRules)),
+		Output:                              o.Output,
+		SpanEventBridge:                     o.SpanEventBridge,
+		Stats:                               o.Stats,
+		Subsystem:                           o.Subsystem,
+		TraceContextExtractor:               o.TraceContextExtractor,
 	}
 
 	// Copy all slice/map contents to prevent leaking memory references
@@ -189,14 +411,197 @@ func (o LoggerOpts) Copy() LoggerOpts {
 		result.Fields[key] = value
 	}
 
+	copy(result.EntryHooks, o.EntryHooks)
+	copy(result.FieldAllowlist, o.FieldAllowlist)
 	copy(result.MaskAllFieldValuesRegexes, o.MaskAllFieldValuesRegexes)
 	copy(result.MaskAllFieldValuesStrings, o.MaskAllFieldValuesStrings)
 	copy(result.MaskFieldValuesWithFieldKeys, o.MaskFieldValuesWithFieldKeys)
+	copy(result.MaskFieldValuesWithFieldKeyMatchers, o.MaskFieldValuesWithFieldKeyMatchers)
+	copy(result.MaskFieldValuesWithFuncs, o.MaskFieldValuesWithFuncs)
 	copy(result.MaskMessageRegexes, o.MaskMessageRegexes)
 	copy(result.MaskMessageStrings, o.MaskMessageStrings)
+	copy(result.MaskMessageWithFuncs, o.MaskMessageWithFuncs)
 	copy(result.OmitLogWithFieldKeys, o.OmitLogWithFieldKeys)
+	copy(result.OmitLogWithFieldKeyMatchers, o.OmitLogWithFieldKeyMatchers)
 	copy(result.OmitLogWithMessageRegexes, o.OmitLogWithMessageRegexes)
 	copy(result.OmitLogWithMessageStrings, o.OmitLogWithMessageStrings)
+	copy(result.OmitLogWithRules, o.OmitLogWithRules)
+
+	return result
+}
+
+// WithInheritedRules returns the subsystem LoggerOpts, with its field
+// allowlist restricted by the one of the given root LoggerOpts and, when the
+// root logger applies its rules to subsystems and the subsystem logger does
+// not exclude them, the omit and mask rules of the root LoggerOpts prepended
+// to its own rules. Slices holding rules are never shared with the inputs.
+//
+// The field allowlist of the root LoggerOpts always applies, regardless of
+// ApplyRulesToSubsystems and ExcludeInheritedRules, as a subsystem logger
+// must never write fields its root logger does not allow.
+//
+// The result is meant for writing logs, not to be saved into a new
+// context.Context.
+func (o LoggerOpts) WithInheritedRules(root LoggerOpts) LoggerOpts {
+	o = o.withInheritedFieldAllowlist(root)
+
+	if !root.ApplyRulesToSubsystems || o.ExcludeInheritedRules {
+		return o
+	}
+
+	o.OmitLogWithFieldKeys = slices.Concat(root.OmitLogWithFieldKeys, o.OmitLogWithFieldKeys)
+	o.OmitLogWithFieldKeyMatchers = slices.Concat(root.OmitLogWithFieldKeyMatchers, o.OmitLogWithFieldKeyMatchers)
+	o.OmitLogWithMessageRegexes = slices.Concat(root.OmitLogWithMessageRegexes, o.OmitLogWithMessageRegexes)
+	o.OmitLogWithMessageStrings = slices.Concat(root.OmitLogWithMessageStrings, o.OmitLogWithMessageStrings)
+	o.OmitLogWithRules = slices.Concat(root.OmitLogWithRules, o.OmitLogWithRules)
+	o.MaskFieldValuesWithFieldKeys = slices.Concat(root.MaskFieldValuesWithFieldKeys, o.MaskFieldValuesWithFieldKeys)
+	o.MaskFieldValuesWithFieldKeyMatchers = slices.Concat(root.MaskFieldValuesWithFieldKeyMatchers, o.MaskFieldValuesWithFieldKeyMatchers)
+	o.MaskFieldValuesWithFuncs = slices.Concat(root.MaskFieldValuesWithFuncs, o.MaskFieldValuesWithFuncs)
+	o.MaskAllFieldValuesRegexes = slices.Concat(root.MaskAllFieldValuesRegexes, o.MaskAllFieldValuesRegexes)
+	o.MaskAllFieldValuesStrings = slices.Concat(root.MaskAllFieldValuesStrings, o.MaskAllFieldValuesStrings)
+	o.MaskMessageRegexes = slices.Concat(root.MaskMessageRegexes, o.MaskMessageRegexes)
+	o.MaskMessageStrings = slices.Concat(root.MaskMessageStrings, o.MaskMessageStrings)
+	o.maskAllFieldValuesStringsMatcher = o.maskAllFieldValuesStringsMatcher.withInherited(root.maskAllFieldValuesStringsMatcher)
+	o.maskMessageStringsMatcher = o.maskMessageStringsMatcher.withInherited(root.maskMessageStringsMatcher)
+	o.MaskMessageWithFuncs = slices.Concat(root.MaskMessageWithFuncs, o.MaskMessageWithFuncs)
+	o.OmitDryRun = o.OmitDryRun || root.OmitDryRun
+
+	return o
+}
+
+// WithInheritedExtensions returns the subsystem LoggerOpts, with the
+// extensions of the given root LoggerOpts, such as the TraceContextExtractor,
+// SpanEventBridge and Stats, used where the subsystem LoggerOpts does not
+// configure its own. The EntryHooks of the root LoggerOpts are appended to the ones of
+// the subsystem LoggerOpts, and the MaskAudit of the root LoggerOpts is
+// enabled for the subsystem LoggerOpts.
+//
+// The result is meant for writing logs, not to be saved into a new
+// context.Context.
+func (o LoggerOpts) WithInheritedExtensions(root LoggerOpts) LoggerOpts {
+	if len(root.EntryHooks) > 0 {
+		o.EntryHooks = slices.Concat(o.EntryHooks, root.EntryHooks)
+	}
+
+	if o.SpanEventBridge == nil {
+		o.SpanEventBridge = root.SpanEventBridge
+	}
+
+	if o.TraceContextExtractor == nil {
+		o.TraceContextExtractor = root.TraceContextExtractor
+	}
+
+	if o.Stats == nil {
+		o.Stats = root.Stats
+	}
+
+	if root.MaskAudit {
+		o.MaskAudit = true
+		o.MaskAuditRuleIDs = o.MaskAuditRuleIDs || root.MaskAuditRuleIDs
+	}
+
+	return o
+}
+
+// withEffective returns the subsystem LoggerOpts with the rules and the
+// extensions of the given root LoggerOpts, as with WithInheritedRules and
+// WithInheritedExtensions. The result is cached while neither the subsystem
+// nor the root LoggerOpts saved into the context.Context change, so it is
+// computed once per change rather than once per log entry.
+func (o LoggerOpts) withEffective(root LoggerOpts) LoggerOpts {
+	cache := o.effective
+
+	if cache == nil || root.revision == nil {
+		return o.WithInheritedRules(root).WithInheritedExtensions(root)
+	}
+
+	if last := cache.last.Load(); last != nil && last.root == root.revision {
+		return last.opts
+	}
+
+	o.effective = nil
+	opts := o.WithInheritedRules(root).WithInheritedExtensions(root)
+
+	cache.last.Store(&effectiveOpts{
+		root: root.revision,
+		opts: opts,
+	})
+
+	return opts
+}
+
+// withRevision returns the root LoggerOpts with a new revision, for saving
+// them into a context.Context.
+func (o LoggerOpts) withRevision() LoggerOpts {
+	o.revision = &optsRevision{}
+
+	return o
+}
+
+// withEffectiveCache returns the subsystem LoggerOpts with a new cache of
+// their effective LoggerOpts, for saving them into a context.Context.
+func (o LoggerOpts) withEffectiveCache() LoggerOpts {
+	o.effective = &effectiveOptsCache{}
+
+	return o
+}
+
+// WithRootExtensions returns a copy of the given existing root LoggerOpts,
+// with the options of the LoggerOpts of a new root logger applied, which
+// preserves the fields and rules already set in the context.Context the new
+// root logger is created with:
+//
+//   - Fields are merged, with the ones of the new root logger taking
+//     precedence on key collisions.
+//   - Omit and mask rules are appended to the existing rules.
+//   - ApplyRulesToSubsystems, MaskAudit, OmitDryRun, the field allowlist and
+//     the extensions, such as the EntryHooks, TraceContextExtractor,
+//     SpanEventBridge and Stats, replace the existing ones.
+//
+// ExcludeInheritedRules and IncludeRootFields only apply to subsystem loggers
+// and are ignored. The other options, such as the Level and Output, configure
+// the hclog.Logger of the new root logger instead.
+func (o LoggerOpts) WithRootExtensions(existing LoggerOpts) LoggerOpts {
+	// Copy to prevent slice/map aliasing issues.
+	// Reference: https://github.com/hashicorp/terraform-plugin-log/issues/131
+	result := existing.Copy()
+
+	for key, value := range o.Fields {
+		result.Fields[key] = value
+	}
+
+	result.ApplyRulesToSubsystems = o.ApplyRulesToSubsystems
+	result.OmitLogWithFieldKeys = slices.Concat(existing.OmitLogWithFieldKeys, o.OmitLogWithFieldKeys)
+	result.OmitLogWithFieldKeyMatchers = slices.Concat(existing.OmitLogWithFieldKeyMatchers, o.OmitLogWithFieldKeyMatchers)
+	result.OmitLogWithMessageRegexes = slices.Concat(existing.OmitLogWithMessageRegexes, o.OmitLogWithMessageRegexes)
+	result.OmitLogWithMessageStrings = slices.Concat(existing.OmitLogWithMessageStrings, o.OmitLogWithMessageStrings)
+	result.OmitLogWithRules = slices.Concat(existing.OmitLogWithRules, o.OmitLogWithRules)
+	result.MaskFieldValuesWithFieldKeys = slices.Concat(existing.MaskFieldValuesWithFieldKeys, o.MaskFieldValuesWithFieldKeys)
+	result.MaskFieldValuesWithFieldKeyMatchers = slices.Concat(existing.MaskFieldValuesWithFieldKeyMatchers, o.MaskFieldValuesWithFieldKeyMatchers)
+	result.MaskFieldValuesWithFuncs = slices.Concat(existing.MaskFieldValuesWithFuncs, o.MaskFieldValuesWithFuncs)
+	result.MaskAllFieldValuesRegexes = slices.Concat(existing.MaskAllFieldValuesRegexes, o.MaskAllFieldValuesRegexes)
+	result.MaskAllFieldValuesStrings = slices.Concat(existing.MaskAllFieldValuesStrings, o.MaskAllFieldValuesStrings)
+	result.MaskMessageRegexes = slices.Concat(existing.MaskMessageRegexes, o.MaskMessageRegexes)
+	result.MaskMessageStrings = slices.Concat(existing.MaskMessageStrings, o.MaskMessageStrings)
+	result.MaskMessageWithFuncs = slices.Concat(existing.MaskMessageWithFuncs, o.MaskMessageWithFuncs)
+
+	if len(o.MaskAllFieldValuesStrings) > 0 {
+		result.maskAllFieldValuesStringsMatcher = newStringMatcher(result.MaskAllFieldValuesStrings)
+	}
+
+	if len(o.MaskMessageStrings) > 0 {
+		result.maskMessageStringsMatcher = newStringMatcher(result.MaskMessageStrings)
+	}
+
+	result.EntryHooks = slices.Clone(o.EntryHooks)
+	result.SpanEventBridge = o.SpanEventBridge
+	result.Stats = o.Stats
+	result.TraceContextExtractor = o.TraceContextExtractor
+	result.FieldAllowlist = slices.Clone(o.FieldAllowlist)
+	result.FieldAllowlistMode = o.FieldAllowlistMode
+	result.MaskAudit = o.MaskAudit
+	result.MaskAuditRuleIDs = o.MaskAuditRuleIDs
+	result.OmitDryRun = o.OmitDryRun
 
 	return result
 }
@@ -218,6 +623,40 @@ func ApplyLoggerOpts(opts ...Option) LoggerOpts {
 	return l
 }
 
+// ApplySubsystemLoggerOpts generates a LoggerOpts for a new subsystem logger
+// out of a list of Option implementations. Defaults are inherited from the
+// parent logger, which is either the root logger or the parent subsystem
+// logger of a nested subsystem: IncludeLocation, IncludeTime and Output from
+// its logger options, if available, and fields and omit and mask rules from
+// its LoggerOpts. AdditionalLocationOffset is 1 by default.
+func ApplySubsystemLoggerOpts(parentLoggerOptions *hclog.LoggerOptions, parentLoggerOpts LoggerOpts, opts ...Option) LoggerOpts {
+	// Copy to prevent slice/map aliasing issues.
+	// Reference: https://github.com/hashicorp/terraform-plugin-log/issues/131
+	l := parentLoggerOpts.Copy()
+
+	// set some defaults, which are not inherited
+	l.AdditionalLocationOffset = 1
+	l.ApplyRulesToSubsystems = false
+	l.IncludeLocation = true
+	l.IncludeRootFields = false
+	l.IncludeTime = true
+	l.Level = hclog.NoLevel
+	l.Name = ""
+	l.Output = os.Stderr
+
+	if parentLoggerOptions != nil {
+		l.IncludeLocation = parentLoggerOptions.IncludeLocation
+		l.IncludeTime = !parentLoggerOptions.DisableTime
+		l.Output = parentLoggerOptions.Output
+	}
+
+	for _, opt := range opts {
+		l = opt(l)
+	}
+
+	return l
+}
+
 // WithAdditionalLocationOffset sets the WithAdditionalLocationOffset
 // configuration option, allowing implementations to fix location information
 // when implementing helper functions. The default offset of 1 is automatically
@@ -287,6 +726,69 @@ func WithRootFields() Option {
 	}
 }
 
+// WithApplyRulesToSubsystems enables the application of the omit and mask
+// rules of a root logger by all of its subsystem loggers.
+func WithApplyRulesToSubsystems() Option {
+	return func(l LoggerOpts) LoggerOpts {
+		l.ApplyRulesToSubsystems = true
+		return l
+	}
+}
+
+// WithoutInheritedRules disables the application of the omit and mask rules
+// of the root logger by a subsystem logger.
+func WithoutInheritedRules() Option {
+	return func(l LoggerOpts) LoggerOpts {
+		l.ExcludeInheritedRules = true
+		return l
+	}
+}
+
+// WithEntryHooks appends EntryHook to the LoggerOpts.EntryHooks field.
+func WithEntryHooks(hooks ...EntryHook) Option {
+	return func(l LoggerOpts) LoggerOpts {
+		l.EntryHooks = append(l.EntryHooks, hooks...)
+		return l
+	}
+}
+
+// WithMaskAudit enables the LoggerOpts.MaskAudit field, and the
+// LoggerOpts.MaskAuditRuleIDs field if `includeRuleIDs` is true.
+func WithMaskAudit(includeRuleIDs bool) Option {
+	return func(l LoggerOpts) LoggerOpts {
+		l.MaskAudit = true
+		l.MaskAuditRuleIDs = includeRuleIDs
+		return l
+	}
+}
+
+// WithSpanEventBridge sets the SpanEventBridge, which records each log entry
+// as an event on the active trace span in the context.Context of the log.
+func WithSpanEventBridge(bridge SpanEventBridge) Option {
+	return func(l LoggerOpts) LoggerOpts {
+		l.SpanEventBridge = bridge
+		return l
+	}
+}
+
+// WithStats sets the StatsCollector, which counts the log entries emitted,
+// omitted and masked by the logger.
+func WithStats(collector *StatsCollector) Option {
+	return func(l LoggerOpts) LoggerOpts {
+		l.Stats = collector
+		return l
+	}
+}
+
+// WithTraceContextExtractor sets the TraceContextExtractor, which extracts
+// the IDs of the active trace and span from the context.Context of each log.
+func WithTraceContextExtractor(extractor TraceContextExtractor) Option {
+	return func(l LoggerOpts) LoggerOpts {
+		l.TraceContextExtractor = extractor
+		return l
+	}
+}
+
 // WithoutLocation disables the location included with logging statements. It
 // should only ever be used to make log output deterministic when testing
 // terraform-plugin-log.
@@ -307,6 +809,24 @@ func WithoutTimestamp() Option {
 	}
 }
 
+// WithFieldAllowlist sets the LoggerOpts.FieldAllowlistMode field to `mode`
+// and appends keys to the LoggerOpts.FieldAllowlist field.
+func WithFieldAllowlist(mode FieldAllowlistMode, keys ...string) Option {
+	return func(l LoggerOpts) LoggerOpts {
+		l.FieldAllowlistMode = mode
+		l.FieldAllowlist = append(l.FieldAllowlist, keys...)
+		return l
+	}
+}
+
+// WithOmitDryRun enables the LoggerOpts.OmitDryRun field.
+func WithOmitDryRun() Option {
+	return func(l LoggerOpts) LoggerOpts {
+		l.OmitDryRun = true
+		return l
+	}
+}
+
 // WithOmitLogWithFieldKeys appends keys to the LoggerOpts.OmitLogWithFieldKeys field.
 func WithOmitLogWithFieldKeys(keys ...string) Option {
 	return func(l LoggerOpts) LoggerOpts {
@@ -315,6 +835,14 @@ func WithOmitLogWithFieldKeys(keys ...string) Option {
 	}
 }
 
+// WithOmitLogWithFieldKeyMatchers appends FieldKeyMatcher to the LoggerOpts.OmitLogWithFieldKeyMatchers field.
+func WithOmitLogWithFieldKeyMatchers(matchers ...FieldKeyMatcher) Option {
+	return func(l LoggerOpts) LoggerOpts {
+		l.OmitLogWithFieldKeyMatchers = append(l.OmitLogWithFieldKeyMatchers, matchers...)
+		return l
+	}
+}
+
 // WithOmitLogWithMessageRegexes appends *regexp.Regexp to the LoggerOpts.OmitLogWithMessageRegexes field.
 func WithOmitLogWithMessageRegexes(expressions ...*regexp.Regexp) Option {
 	return func(l LoggerOpts) LoggerOpts {
@@ -331,6 +859,14 @@ func WithOmitLogWithMessageStrings(matchingStrings ...string) Option {
 	}
 }
 
+// WithOmitLogWithRules appends OmitRule to the LoggerOpts.OmitLogWithRules field.
+func WithOmitLogWithRules(rules ...OmitRule) Option {
+	return func(l LoggerOpts) LoggerOpts {
+		l.OmitLogWithRules = append(l.OmitLogWithRules, rules...)
+		return l
+	}
+}
+
 // WithMaskFieldValuesWithFieldKeys appends keys to the LoggerOpts.MaskFieldValuesWithFieldKeys field.
 func WithMaskFieldValuesWithFieldKeys(keys ...string) Option {
 	return func(l LoggerOpts) LoggerOpts {
@@ -339,6 +875,31 @@ func WithMaskFieldValuesWithFieldKeys(keys ...string) Option {
 	}
 }
 
+// WithMaskFieldValuesWithFieldKeyMatchers appends FieldKeyMatcher to the LoggerOpts.MaskFieldValuesWithFieldKeyMatchers field.
+func WithMaskFieldValuesWithFieldKeyMatchers(matchers ...FieldKeyMatcher) Option {
+	return func(l LoggerOpts) LoggerOpts {
+		l.MaskFieldValuesWithFieldKeyMatchers = append(l.MaskFieldValuesWithFieldKeyMatchers, matchers...)
+		return l
+	}
+}
+
+// WithMaskFieldValuesWithFunc appends a FieldValueMasker with the given keys
+// and function to the LoggerOpts.MaskFieldValuesWithFuncs field. A nil
+// function is ignored.
+func WithMaskFieldValuesWithFunc(keys []string, fn func(interface{}) interface{}) Option {
+	return func(l LoggerOpts) LoggerOpts {
+		if fn == nil {
+			return l
+		}
+
+		l.MaskFieldValuesWithFuncs = append(l.MaskFieldValuesWithFuncs, FieldValueMasker{
+			Keys: slices.Clone(keys),
+			Func: fn,
+		})
+		return l
+	}
+}
+
 // WithMaskAllFieldValuesRegexes appends keys to the LoggerOpts.MaskAllFieldValuesRegexes field.
 func WithMaskAllFieldValuesRegexes(expressions ...*regexp.Regexp) Option {
 	return func(l LoggerOpts) LoggerOpts {
@@ -347,10 +908,12 @@ func WithMaskAllFieldValuesRegexes(expressions ...*regexp.Regexp) Option {
 	}
 }
 
-// WithMaskAllFieldValuesStrings appends keys to the LoggerOpts.MaskAllFieldValuesStrings field.
+// WithMaskAllFieldValuesStrings appends keys to the LoggerOpts.MaskAllFieldValuesStrings field,
+// and compiles them for masking.
 func WithMaskAllFieldValuesStrings(matchingStrings ...string) Option {
 	return func(l LoggerOpts) LoggerOpts {
 		l.MaskAllFieldValuesStrings = append(l.MaskAllFieldValuesStrings, matchingStrings...)
+		l.maskAllFieldValuesStringsMatcher = newStringMatcher(l.MaskAllFieldValuesStrings)
 		return l
 	}
 }
@@ -363,10 +926,25 @@ func WithMaskMessageRegexes(expressions ...*regexp.Regexp) Option {
 	}
 }
 
-// WithMaskMessageStrings appends string to the LoggerOpts.MaskMessageStrings field.
+// WithMaskMessageStrings appends string to the LoggerOpts.MaskMessageStrings field,
+// and compiles them for masking.
 func WithMaskMessageStrings(matchingStrings ...string) Option {
 	return func(l LoggerOpts) LoggerOpts {
 		l.MaskMessageStrings = append(l.MaskMessageStrings, matchingStrings...)
+		l.maskMessageStringsMatcher = newStringMatcher(l.MaskMessageStrings)
+		return l
+	}
+}
+
+// WithMaskMessageWithFunc appends a function to the
+// LoggerOpts.MaskMessageWithFuncs field. A nil function is ignored.
+func WithMaskMessageWithFunc(fn func(string) string) Option {
+	return func(l LoggerOpts) LoggerOpts {
+		if fn == nil {
+			return l
+		}
+
+		l.MaskMessageWithFuncs = append(l.MaskMessageWithFuncs, fn)
 		return l
 	}
 }
@@ -4,59 +4,122 @@
 package logging_test
 
 import (
+	"context"
 	"os"
+	"reflect"
 	"regexp"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
 	"github.com/hashicorp/terraform-plugin-log/internal/logging"
 )
 
+// testEntryHook is a logging.EntryHook which does nothing.
+type testEntryHook struct{}
+
+func (testEntryHook) ProcessEntry(_ context.Context, entry logging.Entry) (logging.Entry, bool) {
+	return entry, true
+}
+
+// testSpanEventBridge is a logging.SpanEventBridge which does nothing.
+type testSpanEventBridge struct{}
+
+func (testSpanEventBridge) AddSpanEvent(_ context.Context, _ logging.SpanEvent) {}
+
+func (testSpanEventBridge) SetSpanError(_ context.Context, _ string) {}
+
+// testFieldValueMaskFunc is a FieldValueMasker function which masks nothing.
+func testFieldValueMaskFunc(value interface{}) interface{} {
+	return value
+}
+
+// testMessageMaskFunc is a message masking function which masks nothing.
+func testMessageMaskFunc(msg string) string {
+	return msg
+}
+
 func TestLoggerOptsCopy(t *testing.T) {
 	t.Parallel()
 
 	regex1 := regexp.MustCompile("regex1")
 	regex2 := regexp.MustCompile("regex2")
+	stats := logging.NewStatsCollector()
 
 	// Populate all fields.
 	originalLoggerOpts := logging.LoggerOpts{
-		AdditionalLocationOffset:     1,
-		Fields:                       map[string]any{"key1": "value1"},
-		IncludeLocation:              true,
-		IncludeRootFields:            true,
-		IncludeTime:                  true,
-		Level:                        hclog.Error,
-		MaskAllFieldValuesRegexes:    []*regexp.Regexp{regex1},
-		MaskAllFieldValuesStrings:    []string{"string1"},
-		MaskFieldValuesWithFieldKeys: []string{"string1"},
-		MaskMessageRegexes:           []*regexp.Regexp{regex1},
-		MaskMessageStrings:           []string{"string1"},
-		Name:                         "name1",
-		OmitLogWithFieldKeys:         []string{"string1"},
-		OmitLogWithMessageRegexes:    []*regexp.Regexp{regex1},
-		OmitLogWithMessageStrings:    []string{"string1"},
-		Output:                       os.Stdout,
+		AdditionalLocationOffset:            1,
+		ApplyRulesToSubsystems:              true,
+		EntryHooks:                          []logging.EntryHook{testEntryHook{}},
+		ExcludeInheritedRules:               true,
+		FieldAllowlist:                      []string{"key1"},
+		FieldAllowlistMode:                  logging.FieldAllowlistModeDrop,
+		Fields:                              map[string]any{"key1": "value1"},
+		IncludeLocation:                     true,
+		IncludeRootFields:                   true,
+		IncludeTime:                         true,
+		Level:                               hclog.Error,
+		MaskAudit:                           true,
+		MaskAuditRuleIDs:                    true,
+		MaskAllFieldValuesRegexes:           []*regexp.Regexp{regex1},
+		MaskAllFieldValuesStrings:           []string{"string1"},
+		MaskFieldValuesWithFieldKeys:        []string{"string1"},
+		MaskFieldValuesWithFieldKeyMatchers: []logging.FieldKeyMatcher{logging.NewFieldKeyPrefix("prefix1")},
+		MaskFieldValuesWithFuncs:            []logging.FieldValueMasker{{Keys: []string{"key1"}, Func: testFieldValueMaskFunc}},
+		MaskMessageRegexes:                  []*regexp.Regexp{regex1},
+		MaskMessageStrings:                  []string{"string1"},
+		MaskMessageWithFuncs:                []func(string) string{testMessageMaskFunc},
+		Name:                                "name1",
+		OmitDryRun:                          true,
+		OmitLogWithFieldKeys:                []string{"string1"},
+		OmitLogWithFieldKeyMatchers:         []logging.FieldKeyMatcher{logging.NewFieldKeyPrefix("prefix1")},
+		OmitLogWithMessageRegexes:           []*regexp.Regexp{regex1},
+		OmitLogWithMessageStrings:           []string{"string1"},
+		OmitLogWithRules:                    []logging.OmitRule{logging.NewOmitRuleFieldValues("key1", "value1")},
+		Output:                              os.Stdout,
+		SpanEventBridge:                     testSpanEventBridge{},
+		Stats:                               stats,
+		Subsystem:                           "subsystem1",
+		TraceContextExtractor:               loggertest.TraceContextExtractor{},
 	}
 
 	// Expected LoggerOpts should exactly match original.
 	expectedLoggerOpts := logging.LoggerOpts{
-		AdditionalLocationOffset:     1,
-		Fields:                       map[string]any{"key1": "value1"},
-		IncludeLocation:              true,
-		IncludeRootFields:            true,
-		IncludeTime:                  true,
-		Level:                        hclog.Error,
-		MaskAllFieldValuesRegexes:    []*regexp.Regexp{regex1},
-		MaskAllFieldValuesStrings:    []string{"string1"},
-		MaskFieldValuesWithFieldKeys: []string{"string1"},
-		MaskMessageRegexes:           []*regexp.Regexp{regex1},
-		MaskMessageStrings:           []string{"string1"},
-		Name:                         "name1",
-		OmitLogWithFieldKeys:         []string{"string1"},
-		OmitLogWithMessageRegexes:    []*regexp.Regexp{regex1},
-		OmitLogWithMessageStrings:    []string{"string1"},
-		Output:                       os.Stdout,
+		AdditionalLocationOffset:            1,
+		ApplyRulesToSubsystems:              true,
+		EntryHooks:                          []logging.EntryHook{testEntryHook{}},
+		ExcludeInheritedRules:               true,
+		FieldAllowlist:                      []string{"key1"},
+		FieldAllowlistMode:                  logging.FieldAllowlistModeDrop,
+		Fields:                              map[string]any{"key1": "value1"},
+		IncludeLocation:                     true,
+		IncludeRootFields:                   true,
+		IncludeTime:                         true,
+		Level:                               hclog.Error,
+		MaskAudit:                           true,
+		MaskAuditRuleIDs:                    true,
+		MaskAllFieldValuesRegexes:           []*regexp.Regexp{regex1},
+		MaskAllFieldValuesStrings:           []string{"string1"},
+		MaskFieldValuesWithFieldKeys:        []string{"string1"},
+		MaskFieldValuesWithFieldKeyMatchers: []logging.FieldKeyMatcher{logging.NewFieldKeyPrefix("prefix1")},
+		MaskFieldValuesWithFuncs:            []logging.FieldValueMasker{{Keys: []string{"key1"}, Func: testFieldValueMaskFunc}},
+		MaskMessageRegexes:                  []*regexp.Regexp{regex1},
+		MaskMessageStrings:                  []string{"string1"},
+		MaskMessageWithFuncs:                []func(string) string{testMessageMaskFunc},
+		Name:                                "name1",
+		OmitDryRun:                          true,
+		OmitLogWithFieldKeys:                []string{"string1"},
+		OmitLogWithFieldKeyMatchers:         []logging.FieldKeyMatcher{logging.NewFieldKeyPrefix("prefix1")},
+		OmitLogWithMessageRegexes:           []*regexp.Regexp{regex1},
+		OmitLogWithMessageStrings:           []string{"string1"},
+		OmitLogWithRules:                    []logging.OmitRule{logging.NewOmitRuleFieldValues("key1", "value1")},
+		Output:                              os.Stdout,
+		SpanEventBridge:                     testSpanEventBridge{},
+		Stats:                               stats,
+		Subsystem:                           "subsystem1",
+		TraceContextExtractor:               loggertest.TraceContextExtractor{},
 	}
 
 	// Create a copy before modifying the original LoggerOpts. This will be
@@ -65,21 +128,40 @@ func TestLoggerOptsCopy(t *testing.T) {
 
 	// Ensure modifications of original does not effect copy.
 	originalLoggerOpts.AdditionalLocationOffset = 2
+	originalLoggerOpts.ApplyRulesToSubsystems = false
+	originalLoggerOpts.EntryHooks = append(originalLoggerOpts.EntryHooks, testEntryHook{})
+	originalLoggerOpts.ExcludeInheritedRules = false
+	originalLoggerOpts.FieldAllowlist = append(originalLoggerOpts.FieldAllowlist, "key2")
+	originalLoggerOpts.FieldAllowlistMode = logging.FieldAllowlistModeMask
 	originalLoggerOpts.Fields["key2"] = "value2"
 	originalLoggerOpts.IncludeLocation = false
 	originalLoggerOpts.IncludeRootFields = false
 	originalLoggerOpts.IncludeTime = false
 	originalLoggerOpts.Level = hclog.Debug
+	originalLoggerOpts.MaskAudit = false
+	originalLoggerOpts.MaskAuditRuleIDs = false
 	originalLoggerOpts.MaskAllFieldValuesRegexes = append(originalLoggerOpts.MaskAllFieldValuesRegexes, regex2)
 	originalLoggerOpts.MaskAllFieldValuesStrings = append(originalLoggerOpts.MaskAllFieldValuesStrings, "string2")
 	originalLoggerOpts.MaskFieldValuesWithFieldKeys = append(originalLoggerOpts.MaskFieldValuesWithFieldKeys, "string2")
+	originalLoggerOpts.MaskFieldValuesWithFieldKeyMatchers = append(originalLoggerOpts.MaskFieldValuesWithFieldKeyMatchers, logging.NewFieldKeyPrefix("prefix2"))
+	originalLoggerOpts.MaskFieldValuesWithFuncs[0].Func = nil
+	originalLoggerOpts.MaskFieldValuesWithFuncs = append(originalLoggerOpts.MaskFieldValuesWithFuncs, logging.FieldValueMasker{Func: testFieldValueMaskFunc})
 	originalLoggerOpts.MaskMessageRegexes = append(originalLoggerOpts.MaskMessageRegexes, regex2)
 	originalLoggerOpts.MaskMessageStrings = append(originalLoggerOpts.MaskMessageStrings, "string2")
+	originalLoggerOpts.MaskMessageWithFuncs[0] = nil
+	originalLoggerOpts.MaskMessageWithFuncs = append(originalLoggerOpts.MaskMessageWithFuncs, testMessageMaskFunc)
 	originalLoggerOpts.Name = "name2"
+	originalLoggerOpts.OmitDryRun = false
 	originalLoggerOpts.OmitLogWithFieldKeys = append(originalLoggerOpts.OmitLogWithFieldKeys, "string2")
+	originalLoggerOpts.OmitLogWithFieldKeyMatchers = append(originalLoggerOpts.OmitLogWithFieldKeyMatchers, logging.NewFieldKeyPrefix("prefix2"))
 	originalLoggerOpts.OmitLogWithMessageRegexes = append(originalLoggerOpts.OmitLogWithMessageRegexes, regex2)
 	originalLoggerOpts.OmitLogWithMessageStrings = append(originalLoggerOpts.OmitLogWithMessageStrings, "string2")
+	originalLoggerOpts.OmitLogWithRules = append(originalLoggerOpts.OmitLogWithRules, logging.NewOmitRuleFieldValues("key2", "value2"))
 	originalLoggerOpts.Output = os.Stderr
+	originalLoggerOpts.SpanEventBridge = nil
+	originalLoggerOpts.Stats = logging.NewStatsCollector()
+	originalLoggerOpts.Subsystem = "subsystem2"
+	originalLoggerOpts.TraceContextExtractor = nil
 
 	// Prevent go-cmp errors.
 	cmpOpts := []cmp.Option{
@@ -107,9 +189,71 @@ func TestLoggerOptsCopy(t *testing.T) {
 			// Simple comparison test is good enough for our purposes.
 			return i.String() == j.String()
 		}),
+		cmp.Comparer(func(i, j logging.FieldKeyMatcher) bool {
+			return i.String() == j.String()
+		}),
+		cmp.Comparer(func(i, j logging.OmitRule) bool {
+			return i.String() == j.String()
+		}),
+		cmp.Comparer(func(i, j func(interface{}) interface{}) bool {
+			return reflect.ValueOf(i).Pointer() == reflect.ValueOf(j).Pointer()
+		}),
+		cmp.Comparer(func(i, j func(string) string) bool {
+			return reflect.ValueOf(i).Pointer() == reflect.ValueOf(j).Pointer()
+		}),
+		cmp.Comparer(func(i, j *logging.StatsCollector) bool {
+			return i == j
+		}),
+		// The compiled strings matchers are immutable, so they are shared.
+		cmpopts.IgnoreUnexported(logging.LoggerOpts{}),
 	}
 
 	if diff := cmp.Diff(copiedLoggerOpts, expectedLoggerOpts, cmpOpts...); diff != "" {
 		t.Errorf("unexpected difference: %s", diff)
 	}
 }
+
+func TestGetSDKSubsystemEffectiveTFLoggerOpts(t *testing.T) {
+	t.Parallel()
+
+	const subsystem = "test_subsystem"
+
+	ctx := context.Background()
+	ctx = logging.SetSDKRootTFLoggerOpts(ctx, logging.ApplyLoggerOpts(
+		logging.WithApplyRulesToSubsystems(),
+		logging.WithMaskMessageStrings("root1"),
+	))
+	ctx = logging.SetSDKSubsystemTFLoggerOpts(ctx, subsystem, logging.ApplyLoggerOpts(
+		logging.WithMaskMessageStrings("subsystem1"),
+	))
+
+	// Unchanged LoggerOpts mask identically, whether the effective LoggerOpts
+	// are computed or cached.
+	for i := 0; i < 2; i++ {
+		msg := "root1 root2 subsystem1 subsystem2"
+
+		logging.GetSDKSubsystemEffectiveTFLoggerOpts(ctx, subsystem).ApplyMask(&msg)
+
+		if diff := cmp.Diff("*** root2 *** subsystem2", msg); diff != "" {
+			t.Errorf("unexpected difference: %s", diff)
+		}
+	}
+
+	ctx = logging.SetSDKRootTFLoggerOpts(ctx, logging.WithMaskMessageStrings("root2")(logging.GetSDKRootTFLoggerOpts(ctx).Copy()))
+	msg := "root1 root2 subsystem1 subsystem2"
+
+	logging.GetSDKSubsystemEffectiveTFLoggerOpts(ctx, subsystem).ApplyMask(&msg)
+
+	if diff := cmp.Diff("*** *** *** subsystem2", msg); diff != "" {
+		t.Errorf("unexpected difference after root change: %s", diff)
+	}
+
+	ctx = logging.SetSDKSubsystemTFLoggerOpts(ctx, subsystem, logging.WithMaskMessageStrings("subsystem2")(logging.GetSDKSubsystemTFLoggerOpts(ctx, subsystem).Copy()))
+	msg = "root1 root2 subsystem1 subsystem2"
+
+	logging.GetSDKSubsystemEffectiveTFLoggerOpts(ctx, subsystem).ApplyMask(&msg)
+
+	if diff := cmp.Diff("*** *** *** ***", msg); diff != "" {
+		t.Errorf("unexpected difference after subsystem change: %s", diff)
+	}
+}
@@ -59,6 +59,24 @@ func SetProviderRootLoggerOptions(ctx context.Context, loggerOptions *hclog.Logg
 	return context.WithValue(ctx, ProviderRootLoggerOptionsKey, loggerOptions)
 }
 
+// GetProviderSubsystemRegistry returns the SubsystemRegistry tracking the
+// subsystem loggers created for the root provider logger. If the root logger
+// has not been created, it will return nil.
+func GetProviderSubsystemRegistry(ctx context.Context) *SubsystemRegistry {
+	registry, ok := ctx.Value(ProviderSubsystemRegistryKey).(*SubsystemRegistry)
+	if !ok {
+		return nil
+	}
+
+	return registry
+}
+
+// SetProviderSubsystemRegistry sets `registry` as the SubsystemRegistry
+// tracking the subsystem loggers created for the root provider logger.
+func SetProviderSubsystemRegistry(ctx context.Context, registry *SubsystemRegistry) context.Context {
+	return context.WithValue(ctx, ProviderSubsystemRegistryKey, registry)
+}
+
 // NewProviderSubsystemLoggerWarning is the text included in log output when a
 // subsystem is auto-generated by terraform-plugin-log because it was used
 // before the provider instantiated it.
@@ -100,7 +118,31 @@ func GetProviderRootTFLoggerOpts(ctx context.Context) LoggerOpts {
 
 // SetProviderRootTFLoggerOpts sets the LoggerOpts of the provider root logger, in the context.
 func SetProviderRootTFLoggerOpts(ctx context.Context, lOpts LoggerOpts) context.Context {
-	return context.WithValue(ctx, providerRootTFLoggerOptsKey(), lOpts)
+	return context.WithValue(ctx, providerRootTFLoggerOptsKey(), lOpts.withRevision())
+}
+
+// GetProviderSubsystemLoggerOptions returns the subsystem logger options used
+// for creating the logger for the named subsystem in provider space. If the subsystem
+// logger has not been created or the options are not present, it will return
+// nil.
+func GetProviderSubsystemLoggerOptions(ctx context.Context, subsystem string) *hclog.LoggerOptions {
+	if GetProviderSubsystemLogger(ctx, subsystem) == nil {
+		return nil
+	}
+
+	loggerOptions, ok := ctx.Value(providerSubsystemLoggerOptionsKey(subsystem)).(*hclog.LoggerOptions)
+
+	if !ok {
+		return nil
+	}
+
+	return loggerOptions
+}
+
+// SetProviderSubsystemLoggerOptions sets `loggerOptions` as the subsystem logger
+// options used for creating the logger for the named subsystem in provider space.
+func SetProviderSubsystemLoggerOptions(ctx context.Context, subsystem string, loggerOptions *hclog.LoggerOptions) context.Context {
+	return context.WithValue(ctx, providerSubsystemLoggerOptionsKey(subsystem), loggerOptions)
 }
 
 // GetProviderSubsystemTFLoggerOpts retrieves the LoggerOpts of the logger for the named provider subsystem.
@@ -116,5 +158,14 @@ func GetProviderSubsystemTFLoggerOpts(ctx context.Context, subsystem string) Log
 
 // SetProviderSubsystemTFLoggerOpts sets the LoggerOpts of the logger for the named provider subsystem, in the context.
 func SetProviderSubsystemTFLoggerOpts(ctx context.Context, subsystem string, lOpts LoggerOpts) context.Context {
-	return context.WithValue(ctx, providerSubsystemTFLoggerOptsKey(subsystem), lOpts)
+	return context.WithValue(ctx, providerSubsystemTFLoggerOptsKey(subsystem), lOpts.withEffectiveCache())
+}
+
+// GetProviderSubsystemEffectiveTFLoggerOpts retrieves the LoggerOpts of the logger for the named provider subsystem,
+// including the omit and mask rules and the extensions inherited from the provider root logger.
+// The result is meant for writing logs, not to be saved into a new context.Context.
+func GetProviderSubsystemEffectiveTFLoggerOpts(ctx context.Context, subsystem string) LoggerOpts {
+	root := GetProviderRootTFLoggerOpts(ctx)
+
+	return GetProviderSubsystemTFLoggerOpts(ctx, subsystem).withEffective(root)
 }
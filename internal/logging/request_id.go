@@ -0,0 +1,45 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+)
+
+// RequestIDFieldKey is the field key holding the correlation ID of the
+// request.
+const RequestIDFieldKey = "tf_req_id"
+
+// GetRequestID returns the correlation ID of the request in `ctx`. If no
+// correlation ID has been set, it will return an empty string.
+func GetRequestID(ctx context.Context) string {
+	requestID, ok := ctx.Value(RequestIDKey).(string)
+	if !ok {
+		return ""
+	}
+
+	return requestID
+}
+
+// SetRequestID sets `requestID` as the correlation ID of the request.
+func SetRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, requestID)
+}
+
+// NewRequestID returns a new random correlation ID, formatted as a version 4
+// UUID.
+func NewRequestID() string {
+	id := make([]byte, 16)
+
+	// crypto/rand.Read never returns an error
+	_, _ = rand.Read(id)
+
+	// Set the version (4) and variant (RFC 4122) bits
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:16])
+}
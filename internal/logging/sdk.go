@@ -73,7 +73,25 @@ func GetSDKRootTFLoggerOpts(ctx context.Context) LoggerOpts {
 
 // SetSDKRootTFLoggerOpts sets the LoggerOpts of the SDK root logger, in the context.
 func SetSDKRootTFLoggerOpts(ctx context.Context, lOpts LoggerOpts) context.Context {
-	return context.WithValue(ctx, sdkRootTFLoggerOptsKey(), lOpts)
+	return context.WithValue(ctx, sdkRootTFLoggerOptsKey(), lOpts.withRevision())
+}
+
+// GetSDKSubsystemRegistry returns the SubsystemRegistry tracking the
+// subsystem loggers created for the root SDK logger. If the root logger has
+// not been created, it will return nil.
+func GetSDKSubsystemRegistry(ctx context.Context) *SubsystemRegistry {
+	registry, ok := ctx.Value(SDKSubsystemRegistryKey).(*SubsystemRegistry)
+	if !ok {
+		return nil
+	}
+
+	return registry
+}
+
+// SetSDKSubsystemRegistry sets `registry` as the SubsystemRegistry tracking
+// the subsystem loggers created for the root SDK logger.
+func SetSDKSubsystemRegistry(ctx context.Context, registry *SubsystemRegistry) context.Context {
+	return context.WithValue(ctx, SDKSubsystemRegistryKey, registry)
 }
 
 // NewSDKSubsystemLoggerWarning is the text included in log output when a
@@ -104,6 +122,30 @@ func SetSDKSubsystemLogger(ctx context.Context, subsystem string, logger hclog.L
 	return context.WithValue(ctx, sdkSubsystemLoggerKey(subsystem), logger)
 }
 
+// GetSDKSubsystemLoggerOptions returns the subsystem logger options used
+// for creating the logger for the named subsystem in SDK space. If the subsystem
+// logger has not been created or the options are not present, it will return
+// nil.
+func GetSDKSubsystemLoggerOptions(ctx context.Context, subsystem string) *hclog.LoggerOptions {
+	if GetSDKSubsystemLogger(ctx, subsystem) == nil {
+		return nil
+	}
+
+	loggerOptions, ok := ctx.Value(sdkSubsystemLoggerOptionsKey(subsystem)).(*hclog.LoggerOptions)
+
+	if !ok {
+		return nil
+	}
+
+	return loggerOptions
+}
+
+// SetSDKSubsystemLoggerOptions sets `loggerOptions` as the subsystem logger
+// options used for creating the logger for the named subsystem in SDK space.
+func SetSDKSubsystemLoggerOptions(ctx context.Context, subsystem string, loggerOptions *hclog.LoggerOptions) context.Context {
+	return context.WithValue(ctx, sdkSubsystemLoggerOptionsKey(subsystem), loggerOptions)
+}
+
 // GetSDKSubsystemTFLoggerOpts retrieves the LoggerOpts of the logger for the named SDK subsystem.
 // The value is stored in the context.Context: if none is found, a new one will be created.
 func GetSDKSubsystemTFLoggerOpts(ctx context.Context, subsystem string) LoggerOpts {
@@ -117,5 +159,14 @@ func GetSDKSubsystemTFLoggerOpts(ctx context.Context, subsystem string) LoggerOp
 
 // SetSDKSubsystemTFLoggerOpts sets the LoggerOpts of the logger for the named SDK subsystem, in the context.
 func SetSDKSubsystemTFLoggerOpts(ctx context.Context, subsystem string, lOpts LoggerOpts) context.Context {
-	return context.WithValue(ctx, sdkSubsystemTFLoggerOptsKey(subsystem), lOpts)
+	return context.WithValue(ctx, sdkSubsystemTFLoggerOptsKey(subsystem), lOpts.withEffectiveCache())
+}
+
+// GetSDKSubsystemEffectiveTFLoggerOpts retrieves the LoggerOpts of the logger for the named SDK subsystem,
+// including the omit and mask rules and the extensions inherited from the SDK root logger.
+// The result is meant for writing logs, not to be saved into a new context.Context.
+func GetSDKSubsystemEffectiveTFLoggerOpts(ctx context.Context, subsystem string) LoggerOpts {
+	root := GetSDKRootTFLoggerOpts(ctx)
+
+	return GetSDKSubsystemTFLoggerOpts(ctx, subsystem).withEffective(root)
 }
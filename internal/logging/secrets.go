@@ -0,0 +1,129 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"regexp"
+)
+
+// CommonSecretRule is a rule of the common secrets rule set, detecting a type
+// of secret by a *regexp.Regexp matching the part of a string to mask.
+type CommonSecretRule struct {
+	// Name identifies the type of secret detected by the rule.
+	Name string
+
+	// Regexp matches the part of a string to mask.
+	Regexp *regexp.Regexp
+}
+
+// commonSecretsRuleSets holds the versions of the common secrets rule set,
+// where index 0 holds version 1. Rule sets are never changed once released,
+// as that could change the log output of existing providers unexpectedly.
+// Instead, a new version is appended, which usually extends the rules of
+// the previous version.
+var commonSecretsRuleSets = [][]CommonSecretRule{
+	commonSecretsRulesV1,
+}
+
+// commonSecretsRulesV1 is version 1 of the common secrets rule set.
+var commonSecretsRulesV1 = []CommonSecretRule{
+	{
+		Name:   "aws_access_key_id",
+		Regexp: regexp.MustCompile(`\b(?:A3T[A-Z0-9]|AKIA|ASIA|ABIA|ACCA)[A-Z2-7]{16}\b`),
+	},
+	{
+		Name:   "aws_secret_access_key",
+		Regexp: regexp.MustCompile(`(?i)\baws_?secret_?access_?key["']?\s*[=:]\s*["']?[A-Za-z0-9/+=]{40}\b`),
+	},
+	{
+		Name:   "jwt",
+		Regexp: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]*`),
+	},
+	{
+		Name:   "bearer_token",
+		Regexp: regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]{20,}=*`),
+	},
+	{
+		Name:   "pem_private_key",
+		Regexp: regexp.MustCompile(`(?s)-----BEGIN[A-Z ]*PRIVATE KEY(?: BLOCK)?-----.*?-----END[A-Z ]*PRIVATE KEY(?: BLOCK)?-----`),
+	},
+	{
+		Name:   "basic_auth_url",
+		Regexp: regexp.MustCompile(`\b[A-Za-z][A-Za-z0-9+.-]*://[^\s/:@]+:[^\s/@]+@`),
+	},
+	{
+		Name:   "github_token",
+		Regexp: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{22,255})\b`),
+	},
+	{
+		Name:   "gitlab_token",
+		Regexp: regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}\b`),
+	},
+	{
+		Name:   "azure_storage_account_key",
+		Regexp: regexp.MustCompile(`(?i)\bAccountKey=[A-Za-z0-9+/]{86}==`),
+	},
+	{
+		Name:   "azure_sas_signature",
+		Regexp: regexp.MustCompile(`\bsig=[A-Za-z0-9%+/]{20,}(?:%3D|=)*`),
+	},
+	{
+		Name:   "azure_client_secret",
+		Regexp: regexp.MustCompile(`\b[A-Za-z0-9_~.-]{3}\dQ~[A-Za-z0-9_~.-]{31,34}\b`),
+	},
+	{
+		Name:   "google_api_key",
+		Regexp: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`),
+	},
+	{
+		Name:   "slack_token",
+		Regexp: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}\b`),
+	},
+	{
+		Name:   "terraform_cloud_token",
+		Regexp: regexp.MustCompile(`\b[A-Za-z0-9]{14}\.atlasv1\.[A-Za-z0-9_-]{60,}`),
+	},
+}
+
+// CommonSecretsLatestVersion is the latest version of the common secrets rule
+// set, which must be the number of versions in commonSecretsRuleSets.
+const CommonSecretsLatestVersion = 1
+
+// CommonSecretsRules returns the rules of `version` of the common secrets rule
+// set. Versions which are unknown to this module, such as versions released
+// in a newer module version, return the latest version.
+func CommonSecretsRules(version int) []CommonSecretRule {
+	if version < 1 || version > CommonSecretsLatestVersion {
+		version = CommonSecretsLatestVersion
+	}
+
+	return commonSecretsRuleSets[version-1]
+}
+
+// CommonSecretsRegexes returns the *regexp.Regexp of the rules of `version` of
+// the common secrets rule set.
+func CommonSecretsRegexes(version int) []*regexp.Regexp {
+	rules := CommonSecretsRules(version)
+	expressions := make([]*regexp.Regexp, 0, len(rules))
+
+	for _, rule := range rules {
+		expressions = append(expressions, rule.Regexp)
+	}
+
+	return expressions
+}
+
+// commonSecretRuleName returns the name of the rule of any version of the
+// common secrets rule set with the given *regexp.Regexp, if any.
+func commonSecretRuleName(r *regexp.Regexp) (string, bool) {
+	for _, rules := range commonSecretsRuleSets {
+		for _, rule := range rules {
+			if rule.Regexp == r {
+				return rule.Name, true
+			}
+		}
+	}
+
+	return "", false
+}
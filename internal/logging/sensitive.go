@@ -0,0 +1,117 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// SensitiveValue wraps a value which is always rendered as the mask
+// replacement string in log output, regardless of the field key it is logged
+// with, its nesting depth in field values, or the mask rules of the logger.
+// The wrapped value cannot be read back.
+//
+// Its String, GoString and MarshalJSON methods and all fmt verbs also render
+// the mask replacement string, so the wrapped value cannot leak into log
+// messages built with fmt.Sprintf or into encoded log output.
+type SensitiveValue struct {
+	value interface{}
+}
+
+// NewSensitiveValue returns a SensitiveValue wrapping `value`.
+func NewSensitiveValue(value interface{}) SensitiveValue {
+	return SensitiveValue{value: value}
+}
+
+// String returns the mask replacement string.
+func (SensitiveValue) String() string {
+	return logMaskingReplacementString
+}
+
+// GoString returns the mask replacement string.
+func (SensitiveValue) GoString() string {
+	return logMaskingReplacementString
+}
+
+// MarshalJSON returns the mask replacement string as a JSON string.
+func (SensitiveValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(logMaskingReplacementString)
+}
+
+// Format writes the mask replacement string for all fmt verbs, quoted for the
+// %q verb.
+func (SensitiveValue) Format(f fmt.State, verb rune) {
+	if verb == 'q' {
+		_, _ = f.Write([]byte(strconv.Quote(logMaskingReplacementString)))
+
+		return
+	}
+
+	_, _ = f.Write([]byte(logMaskingReplacementString))
+}
+
+// maskSensitiveValue returns `value` with any SensitiveValue replaced by the
+// mask replacement string, including in nested maps with string keys and
+// slices. Maps and slices containing a SensitiveValue are copied rather than
+// changed-in-place, as they may be owned by the caller. It returns true if any
+// SensitiveValue was replaced.
+func maskSensitiveValue(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case SensitiveValue, *SensitiveValue:
+		return logMaskingReplacementString, true
+	case map[string]interface{}:
+		var maskedMap map[string]interface{}
+
+		for k, mv := range v {
+			maskedValue, ok := maskSensitiveValue(mv)
+
+			if !ok {
+				continue
+			}
+
+			if maskedMap == nil {
+				maskedMap = make(map[string]interface{}, len(v))
+
+				for ck, cv := range v {
+					maskedMap[ck] = cv
+				}
+			}
+
+			maskedMap[k] = maskedValue
+		}
+
+		if maskedMap == nil {
+			return value, false
+		}
+
+		return maskedMap, true
+	case []interface{}:
+		var maskedSlice []interface{}
+
+		for i, ev := range v {
+			maskedValue, ok := maskSensitiveValue(ev)
+
+			if !ok {
+				continue
+			}
+
+			if maskedSlice == nil {
+				maskedSlice = make([]interface{}, len(v))
+				copy(maskedSlice, v)
+			}
+
+			maskedSlice[i] = maskedValue
+		}
+
+		if maskedSlice == nil {
+			return value, false
+		}
+
+		return maskedSlice, true
+	default:
+		return value, false
+	}
+}
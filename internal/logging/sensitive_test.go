@@ -0,0 +1,136 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+func TestSensitiveValue(t *testing.T) {
+	t.Parallel()
+
+	sensitive := logging.NewSensitiveValue("secret-value")
+
+	testCases := map[string]struct {
+		render   func() string
+		expected string
+	}{
+		"String": {
+			render:   sensitive.String,
+			expected: "***",
+		},
+		"GoString": {
+			render:   sensitive.GoString,
+			expected: "***",
+		},
+		"MarshalJSON": {
+			render: func() string {
+				got, err := json.Marshal(sensitive)
+
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return string(got)
+			},
+			expected: `"***"`,
+		},
+		"MarshalJSON-nested": {
+			render: func() string {
+				got, err := json.Marshal(map[string]interface{}{
+					"nested": []interface{}{struct{ Password logging.SensitiveValue }{sensitive}},
+				})
+
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				return string(got)
+			},
+			expected: `{"nested":[{"Password":"***"}]}`,
+		},
+		"fmt-verbs": {
+			render: func() string {
+				return fmt.Sprintf("%v %+v %#v %s %q %x %d %10s", sensitive, sensitive, sensitive, sensitive, sensitive, sensitive, sensitive, sensitive)
+			},
+			expected: `*** *** *** *** "***" *** *** ***`,
+		},
+		"fmt-pointer": {
+			render: func() string {
+				return fmt.Sprintf("%v", &sensitive)
+			},
+			expected: "***",
+		},
+		"fmt-nested": {
+			render: func() string {
+				return fmt.Sprintf("%+v %#v", struct{ Password logging.SensitiveValue }{sensitive}, []interface{}{sensitive})
+			},
+			expected: `{Password:***} []interface {}{***}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(testCase.expected, testCase.render()); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyMask_SensitiveValue(t *testing.T) {
+	t.Parallel()
+
+	nestedMap := map[string]interface{}{
+		"password": logging.NewSensitiveValue("secret-value"),
+		"user":     "test-user",
+	}
+	nestedSlice := []interface{}{"test-value", logging.NewSensitiveValue("secret-value")}
+
+	msg := "test message"
+	fields := map[string]interface{}{
+		"token":     logging.NewSensitiveValue("secret-value"),
+		"token_ptr": &[]logging.SensitiveValue{logging.NewSensitiveValue("secret-value")}[0],
+		"map":       nestedMap,
+		"slice":     nestedSlice,
+		"other":     "test-value",
+	}
+
+	if !(logging.LoggerOpts{}).ApplyMask(&msg, fields) {
+		t.Error("expected masking to be applied")
+	}
+
+	expectedFields := map[string]interface{}{
+		"token":     "***",
+		"token_ptr": "***",
+		"map": map[string]interface{}{
+			"password": "***",
+			"user":     "test-user",
+		},
+		"slice": []interface{}{"test-value", "***"},
+		"other": "test-value",
+	}
+
+	if diff := cmp.Diff(expectedFields, fields); diff != "" {
+		t.Errorf("unexpected fields difference: %s", diff)
+	}
+
+	// Nested maps and slices are copied rather than changed-in-place.
+	if _, ok := nestedMap["password"].(logging.SensitiveValue); !ok {
+		t.Errorf("expected nested map to be unchanged, got: %v", nestedMap)
+	}
+
+	if _, ok := nestedSlice[1].(logging.SensitiveValue); !ok {
+		t.Errorf("expected nested slice to be unchanged, got: %v", nestedSlice)
+	}
+}
@@ -58,3 +58,21 @@ func SetSink(ctx context.Context, logger hclog.Logger) context.Context {
 func SetSinkOptions(ctx context.Context, loggerOptions *hclog.LoggerOptions) context.Context {
 	return context.WithValue(ctx, SinkOptionsKey, loggerOptions)
 }
+
+// GetSinkWritesToStandardLogger returns true if the sink logger writes to the
+// standard library log package.
+func GetSinkWritesToStandardLogger(ctx context.Context) bool {
+	if GetSink(ctx) == nil {
+		return false
+	}
+
+	writesToStandardLogger, ok := ctx.Value(SinkStandardLoggerKey).(bool)
+
+	return ok && writesToStandardLogger
+}
+
+// SetSinkWritesToStandardLogger sets whether the sink logger writes to the
+// standard library log package.
+func SetSinkWritesToStandardLogger(ctx context.Context, writesToStandardLogger bool) context.Context {
+	return context.WithValue(ctx, SinkStandardLoggerKey, writesToStandardLogger)
+}
@@ -0,0 +1,46 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// SpanEvent is a log entry recorded as an event on the active trace span by
+// a SpanEventBridge.
+type SpanEvent = Entry
+
+// SpanEventBridge records log entries as events on the active trace span in
+// a context.Context, without requiring a dependency on a specific tracing
+// library, such as OpenTelemetry.
+//
+// The bridge is only called for entries that are not omitted and would be
+// written by the logger at its level, after masking has been applied to the
+// entries returned by the EntryHooks of the logger.
+type SpanEventBridge interface {
+	// AddSpanEvent records the log entry as an event on the active span in
+	// `ctx`, if any.
+	AddSpanEvent(ctx context.Context, event SpanEvent)
+
+	// SetSpanError sets the status of the active span in `ctx`, if any, to
+	// an error with the given description. It is called after
+	// AddSpanEvent for log entries at the error level.
+	SetSpanError(ctx context.Context, description string)
+}
+
+// recordSpanEvent records the log entry with the SpanEventBridge of the
+// LoggerOpts, if any.
+func (lo LoggerOpts) recordSpanEvent(ctx context.Context, event SpanEvent) {
+	if lo.SpanEventBridge == nil {
+		return
+	}
+
+	lo.SpanEventBridge.AddSpanEvent(ctx, event)
+
+	if event.Level == hclog.Error {
+		lo.SpanEventBridge.SetSpanError(ctx, event.Message)
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// StatsKey identifies the log entries counted together in the statistics
+// returned by StatsCollector.Snapshot.
+type StatsKey struct {
+	// LoggerName is the name or "@module" of the logger writing the entries.
+	LoggerName string
+
+	// Subsystem is the name of the subsystem of the logger writing the
+	// entries, or empty for root loggers.
+	Subsystem string
+
+	// Level is the level of the entries.
+	Level hclog.Level
+}
+
+// StatsCounts are the numbers of log entries counted for a StatsKey.
+type StatsCounts struct {
+	// Emitted is the number of entries written.
+	Emitted uint64
+
+	// Omitted is the number of entries not written because of omit rules or
+	// being dropped by an EntryHook. Entries the logger would not write at
+	// its level are not counted.
+	Omitted uint64
+
+	// OmittedByRule is the number of omitted entries by the identifier of
+	// the rule omitting them, such as "omit_log_with_field_keys:foo" or
+	// "entry_hook", which helps tuning omit rules. It is nil when no entries
+	// were omitted.
+	OmittedByRule map[string]uint64
+
+	// Masked is the number of entries with masked message or field values.
+	// Masked entries are also counted as emitted or omitted.
+	Masked uint64
+}
+
+// statsCounters are the atomic counters backing StatsCounts.
+type statsCounters struct {
+	emitted atomic.Uint64
+	omitted atomic.Uint64
+	masked  atomic.Uint64
+
+	// omittedByRule holds the *atomic.Uint64 counter of each rule
+	// identifier, which are only ever added, as with the loggers of a
+	// StatsCollector.
+	omittedByRule sync.Map
+}
+
+// emit counts an emitted entry. It does nothing on nil counters, such as for
+// loggers without a StatsCollector, as do the other methods.
+func (c *statsCounters) emit() {
+	if c == nil {
+		return
+	}
+
+	c.emitted.Add(1)
+}
+
+// mask counts an entry with masked message or field values.
+func (c *statsCounters) mask() {
+	if c == nil {
+		return
+	}
+
+	c.masked.Add(1)
+}
+
+// omit counts an entry omitted by the rule `ruleID`.
+func (c *statsCounters) omit(ruleID string) {
+	if c == nil {
+		return
+	}
+
+	c.omitted.Add(1)
+
+	value, ok := c.omittedByRule.Load(ruleID)
+
+	if !ok {
+		value, _ = c.omittedByRule.LoadOrStore(ruleID, &atomic.Uint64{})
+	}
+
+	if counter, ok := value.(*atomic.Uint64); ok {
+		counter.Add(1)
+	}
+}
+
+// reset discards the counts.
+func (c *statsCounters) reset() {
+	c.emitted.Store(0)
+	c.omitted.Store(0)
+	c.masked.Store(0)
+	c.omittedByRule.Clear()
+}
+
+// omittedByRuleSnapshot returns a copy of the omitted entry counts by rule
+// identifier, or nil if none were omitted.
+func (c *statsCounters) omittedByRuleSnapshot() map[string]uint64 {
+	var result map[string]uint64
+
+	c.omittedByRule.Range(func(key, value any) bool {
+		ruleID, ok := key.(string)
+
+		if !ok {
+			return true
+		}
+
+		counter, ok := value.(*atomic.Uint64)
+
+		if !ok {
+			return true
+		}
+
+		if result == nil {
+			result = make(map[string]uint64)
+		}
+
+		result[ruleID] = counter.Load()
+
+		return true
+	})
+
+	return result
+}
+
+// StatsCollector counts the log entries emitted, omitted and masked by the
+// loggers configured with it, such as with WithStats, and their subsystem
+// loggers. Loggers without a StatsCollector do not count log entries.
+//
+// Counting is performed without locking. It is safe for concurrent use.
+type StatsCollector struct {
+	// loggers holds the *loggerStats of each loggerStatsKey. A sync.Map is
+	// used, as keys are only ever added, once per logger, and loggers keep
+	// their *loggerStats, so counting does not require a lookup.
+	loggers sync.Map
+}
+
+// loggerStatsKey identifies the log entries of a logger.
+type loggerStatsKey struct {
+	loggerName string
+	subsystem  string
+}
+
+// loggerStats are the counters of the log entries of a logger, by level.
+type loggerStats struct {
+	collector *StatsCollector
+	key       loggerStatsKey
+	levels    [hclog.Off + 1]statsCounters
+}
+
+// NewStatsCollector returns a new StatsCollector, without any log entry
+// counts.
+func NewStatsCollector() *StatsCollector {
+	return &StatsCollector{}
+}
+
+// logger returns the counters of the logger named `loggerName`, of the
+// subsystem `subsystem`, if any, creating them if necessary.
+func (s *StatsCollector) logger(loggerName string, subsystem string) *loggerStats {
+	key := loggerStatsKey{
+		loggerName: loggerName,
+		subsystem:  subsystem,
+	}
+
+	value, ok := s.loggers.Load(key)
+
+	if !ok {
+		value, _ = s.loggers.LoadOrStore(key, &loggerStats{
+			collector: s,
+			key:       key,
+		})
+	}
+
+	ls, ok := value.(*loggerStats)
+
+	if !ok {
+		// this should never happen, as loggers only holds *loggerStats,
+		// but discarding the counts is preferable to panicking
+		return &loggerStats{
+			collector: s,
+			key:       key,
+		}
+	}
+
+	return ls
+}
+
+// Snapshot returns a copy of the log entry counts of the loggers configured
+// with the StatsCollector, since it was created or since the last Reset call.
+// Levels without any counted log entry are not included.
+func (s *StatsCollector) Snapshot() map[StatsKey]StatsCounts {
+	result := make(map[StatsKey]StatsCounts)
+
+	s.loggers.Range(func(_, value any) bool {
+		ls, ok := value.(*loggerStats)
+
+		if !ok {
+			return true
+		}
+
+		for level := range ls.levels {
+			counters := &ls.levels[level]
+			counts := StatsCounts{
+				Emitted:       counters.emitted.Load(),
+				Omitted:       counters.omitted.Load(),
+				OmittedByRule: counters.omittedByRuleSnapshot(),
+				Masked:        counters.masked.Load(),
+			}
+
+			if counts.Emitted == 0 && counts.Omitted == 0 {
+				continue
+			}
+
+			result[StatsKey{
+				LoggerName: ls.key.loggerName,
+				Subsystem:  ls.key.subsystem,
+				Level:      hclog.Level(level),
+			}] = counts
+		}
+
+		return true
+	})
+
+	return result
+}
+
+// Reset discards all log entry counts of the StatsCollector. Log entries
+// counted concurrently may be partially discarded.
+func (s *StatsCollector) Reset() {
+	s.loggers.Range(func(_, value any) bool {
+		ls, ok := value.(*loggerStats)
+
+		if !ok {
+			return true
+		}
+
+		for level := range ls.levels {
+			ls.levels[level].reset()
+		}
+
+		return true
+	})
+}
+
+// statsCounters returns the counters of the log entries at `level` of the
+// logger named `loggerName`, or nil if the LoggerOpts has no StatsCollector.
+// The counters cached by WithStatsLogger are used, if they belong to the
+// logger, which avoids a lookup for each log entry.
+func (lo LoggerOpts) statsCounters(loggerName string, level hclog.Level) *statsCounters {
+	if lo.Stats == nil || level < hclog.NoLevel || level > hclog.Off {
+		return nil
+	}
+
+	ls := lo.statsLogger
+
+	if ls == nil || ls.collector != lo.Stats || ls.key.loggerName != loggerName || ls.key.subsystem != lo.Subsystem {
+		ls = lo.Stats.logger(loggerName, lo.Subsystem)
+	}
+
+	return &ls.levels[level]
+}
+
+// WithStatsLogger returns the LoggerOpts of the logger named `loggerName`,
+// with the counters of its log entries cached, so counting them does not
+// require a lookup. The StatsCollector of the given root LoggerOpts is used,
+// unless the LoggerOpts has its own, as with WithInheritedExtensions. For a
+// root logger, the given root LoggerOpts are its own LoggerOpts.
+func (o LoggerOpts) WithStatsLogger(loggerName string, root LoggerOpts) LoggerOpts {
+	collector := o.Stats
+
+	if collector == nil {
+		collector = root.Stats
+	}
+
+	o.statsLogger = nil
+
+	if collector != nil {
+		o.statsLogger = collector.logger(loggerName, o.Subsystem)
+	}
+
+	return o
+}
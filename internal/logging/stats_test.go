@@ -0,0 +1,97 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+func TestStatsCollector(t *testing.T) {
+	t.Parallel()
+
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:   "test_stats_collector",
+		Level:  hclog.Debug,
+		Output: io.Discard,
+	})
+	collector := logging.NewStatsCollector()
+	lOpts := logging.LoggerOpts{
+		OmitLogWithMessageStrings: []string{"omitted"},
+		MaskMessageStrings:        []string{"secret"},
+		Stats:                     collector,
+		Subsystem:                 "test_subsystem",
+	}
+
+	for _, msg := range []string{"test message", "test secret message", "test omitted message"} {
+		logging.OmitOrMask(context.Background(), logger, hclog.Info, lOpts, &msg, nil)
+	}
+
+	// Below the level of the logger, so never counted
+	msg := "test message"
+	logging.OmitOrMask(context.Background(), logger, hclog.Trace, lOpts, &msg, nil)
+
+	expected := map[logging.StatsKey]logging.StatsCounts{
+		{
+			LoggerName: "test_stats_collector",
+			Subsystem:  "test_subsystem",
+			Level:      hclog.Info,
+		}: {
+			Emitted: 2,
+			Omitted: 1,
+			OmittedByRule: map[string]uint64{
+				"omit_log_with_message_strings:omitted": 1,
+			},
+			Masked: 1,
+		},
+	}
+
+	if diff := cmp.Diff(expected, collector.Snapshot()); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+
+	collector.Reset()
+
+	if diff := cmp.Diff(map[logging.StatsKey]logging.StatsCounts{}, collector.Snapshot()); diff != "" {
+		t.Errorf("unexpected difference after reset: %s", diff)
+	}
+}
+
+func TestStatsCollector_WithStatsLogger(t *testing.T) {
+	t.Parallel()
+
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:   "test_stats_collector",
+		Output: io.Discard,
+	})
+	collector := logging.NewStatsCollector()
+	root := logging.LoggerOpts{
+		Stats: collector,
+	}
+	lOpts := logging.LoggerOpts{
+		Subsystem: "test_subsystem",
+	}.WithStatsLogger("test_stats_collector", root)
+
+	expected := map[logging.StatsKey]logging.StatsCounts{
+		{
+			LoggerName: "test_stats_collector",
+			Subsystem:  "test_subsystem",
+			Level:      hclog.Info,
+		}: {
+			Emitted: 1,
+		},
+	}
+
+	msg := "test message"
+	logging.OmitOrMask(context.Background(), logger, hclog.Info, lOpts.WithInheritedExtensions(root), &msg, nil)
+
+	if diff := cmp.Diff(expected, collector.Snapshot()); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/hclogutils"
+)
+
+// stdlogAdditionalLocationOffset is the number of stack frames of the
+// standard library log package between the caller of a *log.Logger method and
+// StdlogWriter.Write, e.g. log.Printf and (*log.Logger).output.
+const stdlogAdditionalLocationOffset = 2
+
+// stdlogTimestampRegexp matches characters commonly found in timestamp
+// formats at the beginning of log lines, matching go-hclog.
+var stdlogTimestampRegexp = regexp.MustCompile(`^[\d\s\:\/\.\+-TZ]*`)
+
+// StdlogWriter is an io.Writer for a *log.Logger, which logs each written line
+// with an hclog.Logger, after applying omission and masking with the given
+// LoggerOpts. This is the equivalent of the go-hclog StandardWriter, which
+// cannot apply omission and masking.
+type StdlogWriter struct {
+	ctx    context.Context
+	logger hclog.Logger
+	lOpts  LoggerOpts
+	opts   hclog.StandardLoggerOptions
+}
+
+// NewStdlogWriter returns a StdlogWriter logging with `logger` and `lOpts`.
+// The level of each line is determined by `opts`, as with the go-hclog
+// StandardWriter:
+//
+//   - ForceLevel logs all lines at that level, stripping any level prefix,
+//     such as `[DEBUG]`, from the line.
+//   - InferLevels logs lines with a level prefix at that level, stripping the
+//     prefix, and all other lines at the info level. With
+//     InferLevelsWithTimestamp, a timestamp before the prefix is ignored.
+//   - Otherwise all lines are logged at the info level.
+//
+// If `loggerOptions` is not nil, it is used to create a copy of `logger` with
+// the additional location offset of the log package, so the caller of the
+// *log.Logger method is included as the location of the log line.
+func NewStdlogWriter(ctx context.Context, logger hclog.Logger, loggerOptions *hclog.LoggerOptions, lOpts LoggerOpts, opts hclog.StandardLoggerOptions) *StdlogWriter {
+	if loggerOptions != nil {
+		stdlogLoggerOptions := hclogutils.LoggerOptionsCopy(loggerOptions)
+		stdlogLoggerOptions.AdditionalLocationOffset += stdlogAdditionalLocationOffset
+		stdlogLoggerOptions.Level = logger.GetLevel()
+		stdlogLoggerOptions.Name = logger.Name()
+
+		logger = hclog.New(stdlogLoggerOptions)
+	}
+
+	return &StdlogWriter{
+		ctx:    ctx,
+		logger: logger,
+		lOpts:  lOpts,
+		opts:   opts,
+	}
+}
+
+// Write logs `data` as a single log line, trimming trailing whitespace.
+func (w *StdlogWriter) Write(data []byte) (int, error) {
+	msg := string(bytes.TrimRight(data, " \t\n"))
+	level := hclog.Info
+
+	switch {
+	case w.opts.ForceLevel != hclog.NoLevel:
+		_, msg = stdlogPickLevel(msg)
+		level = w.opts.ForceLevel
+	case w.opts.InferLevels:
+		if w.opts.InferLevelsWithTimestamp {
+			msg = stdlogTimestampRegexp.ReplaceAllString(msg, "")
+		}
+
+		level, msg = stdlogPickLevel(msg)
+	}
+
+	additionalArgs, shouldOmit := OmitOrMask(w.ctx, w.logger, level, w.lOpts, &msg, nil)
+	if shouldOmit {
+		return len(data), nil
+	}
+
+	// The logger is called directly, rather than in a helper function, so
+	// the location offset is the same for each level.
+	switch level {
+	case hclog.Trace:
+		w.logger.Trace(msg, additionalArgs...)
+	case hclog.Debug:
+		w.logger.Debug(msg, additionalArgs...)
+	case hclog.Warn:
+		w.logger.Warn(msg, additionalArgs...)
+	case hclog.Error:
+		w.logger.Error(msg, additionalArgs...)
+	default:
+		w.logger.Info(msg, additionalArgs...)
+	}
+
+	return len(data), nil
+}
+
+// stdlogPickLevel returns the level of a log line based on conventional
+// prefixes, such as `[DEBUG]`, and the log line without the prefix. Log lines
+// without a prefix are at the info level.
+func stdlogPickLevel(msg string) (hclog.Level, string) {
+	switch {
+	case strings.HasPrefix(msg, "[TRACE]"):
+		return hclog.Trace, strings.TrimSpace(msg[7:])
+	case strings.HasPrefix(msg, "[DEBUG]"):
+		return hclog.Debug, strings.TrimSpace(msg[7:])
+	case strings.HasPrefix(msg, "[INFO]"):
+		return hclog.Info, strings.TrimSpace(msg[6:])
+	case strings.HasPrefix(msg, "[WARN]"):
+		return hclog.Warn, strings.TrimSpace(msg[6:])
+	case strings.HasPrefix(msg, "[ERROR]"):
+		return hclog.Error, strings.TrimSpace(msg[7:])
+	case strings.HasPrefix(msg, "[ERR]"):
+		return hclog.Error, strings.TrimSpace(msg[5:])
+	default:
+		return hclog.Info, msg
+	}
+}
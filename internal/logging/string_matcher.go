@@ -0,0 +1,284 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// stringMatcher finds all the strings of a set of patterns within a text in a
+// single pass, with an Aho-Corasick automaton compiled once for the set,
+// rather than searching the text once per pattern. Empty patterns never
+// match.
+//
+// It is safe for concurrent use and never modified once compiled, except for
+// its cache of the stringMatcher including inherited patterns.
+type stringMatcher struct {
+	// patterns are the patterns the stringMatcher was compiled for, in order,
+	// including empty and duplicate patterns, so the index of a match is the
+	// index of the pattern in the LoggerOpts.
+	patterns []string
+
+	// classes maps each byte to its class: bytes within any pattern have
+	// their own class, while all other bytes share the class 0.
+	classes [256]int32
+
+	// stride is the number of byte classes.
+	stride int32
+
+	// transitions is the automaton, with the next state for the state `s`
+	// and the byte class `c` at the index `s*stride+c`. It includes the
+	// transitions of the failure links, so matching a byte is a single
+	// lookup.
+	transitions []int32
+
+	// outputs is the index of the pattern equal to the text of each state,
+	// or -1. For duplicate patterns, it is the lowest index.
+	outputs []int32
+
+	// outputLinks is the nearest state of each state, following its failure
+	// links, with an output, or -1.
+	outputLinks []int32
+
+	// inherited caches the last stringMatcher compiled by withInherited.
+	inherited atomic.Pointer[inheritedStringMatcher]
+}
+
+// inheritedStringMatcher is the stringMatcher of the patterns of the root
+// stringMatcher followed by the patterns of a subsystem stringMatcher.
+type inheritedStringMatcher struct {
+	root    *stringMatcher
+	matcher *stringMatcher
+}
+
+// stringMatch is a match of the pattern `index` at `start` within a text.
+type stringMatch struct {
+	start  int
+	length int
+	index  int
+}
+
+// newStringMatcher returns a stringMatcher compiled for the given patterns.
+func newStringMatcher(patterns []string) *stringMatcher {
+	m := &stringMatcher{
+		patterns: slices.Clone(patterns),
+		stride:   1,
+	}
+
+	for _, p := range patterns {
+		for i := 0; i < len(p); i++ {
+			if m.classes[p[i]] == 0 {
+				m.classes[p[i]] = m.stride
+				m.stride++
+			}
+		}
+	}
+
+	// Build the trie of the patterns, where the transition 0 means no child
+	// as no transition of the trie leads back to the root state.
+	m.transitions = make([]int32, m.stride)
+	m.outputs = []int32{-1}
+
+	for i, p := range patterns {
+		if p == "" {
+			continue
+		}
+
+		state := int32(0)
+
+		for j := 0; j < len(p); j++ {
+			next := &m.transitions[state*m.stride+m.classes[p[j]]]
+
+			if *next == 0 {
+				*next = int32(len(m.outputs))
+				m.transitions = append(m.transitions, make([]int32, m.stride)...)
+				m.outputs = append(m.outputs, -1)
+			}
+
+			state = *next
+		}
+
+		if m.outputs[state] == -1 {
+			m.outputs[state] = int32(i)
+		}
+	}
+
+	// Add the failure links to the trie in breadth-first order, so the
+	// transitions of the failure link of a state, which is always less deep,
+	// are complete before the transitions of the state.
+	failures := make([]int32, len(m.outputs))
+	m.outputLinks = make([]int32, len(m.outputs))
+	m.outputLinks[0] = -1
+	queue := make([]int32, 0, len(m.outputs))
+
+	for c := int32(1); c < m.stride; c++ {
+		if child := m.transitions[c]; child != 0 {
+			m.outputLinks[child] = -1
+			queue = append(queue, child)
+		}
+	}
+
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+
+		for c := int32(1); c < m.stride; c++ {
+			child := m.transitions[state*m.stride+c]
+			failureNext := m.transitions[failures[state]*m.stride+c]
+
+			if child == 0 {
+				m.transitions[state*m.stride+c] = failureNext
+
+				continue
+			}
+
+			failures[child] = failureNext
+
+			if m.outputs[failureNext] != -1 {
+				m.outputLinks[child] = failureNext
+			} else {
+				m.outputLinks[child] = m.outputLinks[failureNext]
+			}
+
+			queue = append(queue, child)
+		}
+	}
+
+	return m
+}
+
+// compiledFor returns true if the stringMatcher was compiled for the given
+// patterns. The patterns are compared by content, as the exported pattern
+// fields of LoggerOpts can be replaced or edited without updating their
+// stringMatcher.
+func (m *stringMatcher) compiledFor(patterns []string) bool {
+	return m != nil && slices.Equal(m.patterns, patterns)
+}
+
+// withInherited returns the stringMatcher of the patterns of the given root
+// stringMatcher followed by the patterns of the stringMatcher, as with the
+// rules of a subsystem logger inheriting the rules of its root logger. The
+// result is cached until the root stringMatcher changes, so it is compiled
+// once per update of either logger rather than once per log entry.
+func (m *stringMatcher) withInherited(root *stringMatcher) *stringMatcher {
+	switch {
+	case root == nil || len(root.patterns) == 0:
+		return m
+	case m == nil || len(m.patterns) == 0:
+		return root
+	}
+
+	if inherited := m.inherited.Load(); inherited != nil && inherited.root == root {
+		return inherited.matcher
+	}
+
+	matcher := newStringMatcher(slices.Concat(root.patterns, m.patterns))
+
+	m.inherited.Store(&inheritedStringMatcher{
+		root:    root,
+		matcher: matcher,
+	})
+
+	return matcher
+}
+
+// replaceAll returns `text` with all the matches of the patterns replaced
+// with `replacement`, calling `matched`, if any, with the index of the
+// pattern of each match. It returns true if there was any match.
+//
+// Matches are replaced leftmost-longest: where matches overlap, the one
+// starting first is replaced, and the longest one among those starting at
+// the same position, so a pattern is replaced as a whole even if it contains
+// other patterns.
+func (m *stringMatcher) replaceAll(text string, replacement string, matched func(index int)) (string, bool) {
+	var matches []stringMatch
+
+	state := int32(0)
+
+	for i := 0; i < len(text); i++ {
+		state = m.transitions[state*m.stride+m.classes[text[i]]]
+
+		for s := state; s > 0; s = m.outputLinks[s] {
+			if index := m.outputs[s]; index != -1 {
+				length := len(m.patterns[index])
+
+				matches = append(matches, stringMatch{
+					start:  i + 1 - length,
+					length: length,
+					index:  int(index),
+				})
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return text, false
+	}
+
+	slices.SortFunc(matches, func(a, b stringMatch) int {
+		if a.start != b.start {
+			return a.start - b.start
+		}
+
+		if a.length != b.length {
+			return b.length - a.length
+		}
+
+		return a.index - b.index
+	})
+
+	var result strings.Builder
+
+	result.Grow(len(text))
+
+	end := 0
+
+	for _, match := range matches {
+		if match.start < end {
+			continue
+		}
+
+		if matched != nil {
+			matched(match.index)
+		}
+
+		result.WriteString(text[end:match.start])
+		result.WriteString(replacement)
+		end = match.start + match.length
+	}
+
+	result.WriteString(text[end:])
+
+	return result.String(), true
+}
+
+// currentStringMatcher returns the given stringMatcher, if it was compiled for
+// the given patterns, or otherwise a stringMatcher compiled for them, such as
+// for a LoggerOpts not configured with its options. It returns nil if there
+// are no patterns.
+func currentStringMatcher(m *stringMatcher, patterns []string) *stringMatcher {
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	if m.compiledFor(patterns) {
+		return m
+	}
+
+	return newStringMatcher(patterns)
+}
+
+// fieldValuesStringsMatcher returns the stringMatcher of the
+// MaskAllFieldValuesStrings, or nil if there are none.
+func (lo LoggerOpts) fieldValuesStringsMatcher() *stringMatcher {
+	return currentStringMatcher(lo.maskAllFieldValuesStringsMatcher, lo.MaskAllFieldValuesStrings)
+}
+
+// messageStringsMatcher returns the stringMatcher of the MaskMessageStrings,
+// or nil if there are none.
+func (lo LoggerOpts) messageStringsMatcher() *stringMatcher {
+	return currentStringMatcher(lo.maskMessageStringsMatcher, lo.MaskMessageStrings)
+}
@@ -0,0 +1,382 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging_test
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+func TestApplyMask_Strings(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		strings        []string
+		msg            string
+		expectedMsg    string
+		expectedMasked bool
+	}{
+		"no-match": {
+			strings:        []string{"foo", "bar"},
+			msg:            "pineapple mango",
+			expectedMsg:    "pineapple mango",
+			expectedMasked: false,
+		},
+		"single": {
+			strings:        []string{"foo", "bar"},
+			msg:            "banana foo apple",
+			expectedMsg:    "banana *** apple",
+			expectedMasked: true,
+		},
+		"multiple": {
+			strings:        []string{"foo", "bar"},
+			msg:            "foo bar foo",
+			expectedMsg:    "*** *** ***",
+			expectedMasked: true,
+		},
+		"adjacent": {
+			strings:        []string{"foo", "bar"},
+			msg:            "foobarfoo",
+			expectedMsg:    "*********",
+			expectedMasked: true,
+		},
+		"longest-first": {
+			strings:        []string{"secret", "secret-token"},
+			msg:            "value: secret-token, other: secret",
+			expectedMsg:    "value: ***, other: ***",
+			expectedMasked: true,
+		},
+		// Earlier releases, masking strings one at a time in configuration
+		// order, returned "***-token", as "secret" was masked first.
+		"longest-first-overlapping": {
+			strings:        []string{"secret", "secret-token"},
+			msg:            "secret-token",
+			expectedMsg:    "***",
+			expectedMasked: true,
+		},
+		"longest-first-suffix": {
+			strings:        []string{"token", "secret-token"},
+			msg:            "value: secret-token",
+			expectedMsg:    "value: ***",
+			expectedMasked: true,
+		},
+		"leftmost-first": {
+			strings:        []string{"bcd", "ab", "cd"},
+			msg:            "abcd",
+			expectedMsg:    "******",
+			expectedMasked: true,
+		},
+		"overlapping-same-string": {
+			strings:        []string{"aa"},
+			msg:            "aaaaa",
+			expectedMsg:    "******a",
+			expectedMasked: true,
+		},
+		"duplicate": {
+			strings:        []string{"foo", "foo"},
+			msg:            "foo",
+			expectedMsg:    "***",
+			expectedMasked: true,
+		},
+		"empty-string": {
+			strings:        []string{""},
+			msg:            "banana",
+			expectedMsg:    "banana",
+			expectedMasked: false,
+		},
+		"multi-byte": {
+			strings:        []string{"pässwörd"},
+			msg:            "the pässwörd is ✓",
+			expectedMsg:    "the *** is ✓",
+			expectedMasked: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// Compiled by the option and compiled when masking, such as for
+			// LoggerOpts not configured with the option, mask identically.
+			lOptsVariants := map[string]logging.LoggerOpts{
+				"option":  logging.ApplyLoggerOpts(logging.WithMaskMessageStrings(testCase.strings...)),
+				"literal": {MaskMessageStrings: testCase.strings},
+			}
+
+			for variant, lOpts := range lOptsVariants {
+				msg := testCase.msg
+
+				gotMasked := lOpts.ApplyMask(&msg)
+
+				if gotMasked != testCase.expectedMasked {
+					t.Errorf("%s: expected masked %t, got: %t", variant, testCase.expectedMasked, gotMasked)
+				}
+
+				if diff := cmp.Diff(testCase.expectedMsg, msg); diff != "" {
+					t.Errorf("%s: unexpected message difference: %s", variant, diff)
+				}
+			}
+
+			lOpts := logging.ApplyLoggerOpts(
+				logging.WithMaskMessageStrings(testCase.strings...),
+				logging.WithMaskAllFieldValuesStrings(testCase.strings...),
+			)
+			fields := map[string]interface{}{
+				"k1": testCase.msg,
+			}
+			msg := testCase.msg
+
+			lOpts.ApplyMask(&msg, fields)
+
+			if diff := cmp.Diff(testCase.expectedMsg, fields["k1"]); diff != "" {
+				t.Errorf("unexpected field value difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyMask_StringsInherited(t *testing.T) {
+	t.Parallel()
+
+	root := logging.ApplyLoggerOpts(
+		logging.WithMaskMessageStrings("secret"),
+		logging.WithMaskAllFieldValuesStrings("secret"),
+		logging.WithApplyRulesToSubsystems(),
+	)
+	subsystem := logging.ApplyLoggerOpts(
+		logging.WithMaskMessageStrings("secret-token"),
+		logging.WithMaskAllFieldValuesStrings("secret-token"),
+	)
+
+	// Masking twice verifies the cached inherited stringMatcher.
+	for i := 0; i < 2; i++ {
+		lOpts := subsystem.WithInheritedRules(root)
+		msg := "secret-token and secret"
+		fields := map[string]interface{}{
+			"k1": "secret-token and secret",
+		}
+
+		lOpts.ApplyMask(&msg, fields)
+
+		if diff := cmp.Diff("*** and ***", msg); diff != "" {
+			t.Errorf("unexpected message difference: %s", diff)
+		}
+
+		if diff := cmp.Diff("*** and ***", fields["k1"]); diff != "" {
+			t.Errorf("unexpected field value difference: %s", diff)
+		}
+	}
+
+	// Updating the root rules is reflected by the subsystem.
+	root = logging.WithMaskMessageStrings("and")(root.Copy())
+	lOpts := subsystem.WithInheritedRules(root)
+	msg := "secret-token and secret"
+
+	lOpts.ApplyMask(&msg)
+
+	if diff := cmp.Diff("*** *** ***", msg); diff != "" {
+		t.Errorf("unexpected message difference: %s", diff)
+	}
+}
+
+func TestApplyMask_StringsReplaced(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		replace func(logging.LoggerOpts) logging.LoggerOpts
+	}{
+		"new-slices": {
+			replace: func(lOpts logging.LoggerOpts) logging.LoggerOpts {
+				lOpts.MaskMessageStrings = []string{"new-secret"}
+				lOpts.MaskAllFieldValuesStrings = []string{"new-secret"}
+
+				return lOpts
+			},
+		},
+		"in-place": {
+			replace: func(lOpts logging.LoggerOpts) logging.LoggerOpts {
+				lOpts.MaskMessageStrings[0] = "new-secret"
+				lOpts.MaskAllFieldValuesStrings[0] = "new-secret"
+
+				return lOpts
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			lOpts := logging.ApplyLoggerOpts(
+				logging.WithMaskMessageStrings("old-secret"),
+				logging.WithMaskAllFieldValuesStrings("old-secret"),
+			)
+
+			// Replacing a pattern while keeping the number of patterns must
+			// not reuse the stringMatcher of the previous patterns.
+			lOpts = testCase.replace(lOpts.Copy())
+			msg := "new-secret and old-secret"
+			fields := map[string]interface{}{
+				"k1": "new-secret and old-secret",
+			}
+
+			lOpts.ApplyMask(&msg, fields)
+
+			if diff := cmp.Diff("*** and old-secret", msg); diff != "" {
+				t.Errorf("unexpected message difference: %s", diff)
+			}
+
+			if diff := cmp.Diff("*** and old-secret", fields["k1"]); diff != "" {
+				t.Errorf("unexpected field value difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyMask_StringsRandom(t *testing.T) {
+	t.Parallel()
+
+	random := rand.New(rand.NewSource(1))
+
+	randomString := func(maxLength int) string {
+		var s strings.Builder
+
+		for i := 0; i < 1+random.Intn(maxLength); i++ {
+			s.WriteByte("abc"[random.Intn(3)])
+		}
+
+		return s.String()
+	}
+
+	for i := 0; i < 1000; i++ {
+		patterns := make([]string, 1+random.Intn(8))
+
+		for j := range patterns {
+			patterns[j] = randomString(4)
+		}
+
+		msg := randomString(32)
+		expectedMsg := testLeftmostLongestReplace(msg, patterns)
+		lOpts := logging.ApplyLoggerOpts(logging.WithMaskMessageStrings(patterns...))
+		gotMsg := msg
+
+		lOpts.ApplyMask(&gotMsg)
+
+		if gotMsg != expectedMsg {
+			t.Fatalf("unexpected masking of %q with %q: expected %q, got %q", msg, patterns, expectedMsg, gotMsg)
+		}
+	}
+}
+
+// testLeftmostLongestReplace replaces the patterns within `s`, leftmost
+// first, then longest first, by comparing every pattern at every position.
+func testLeftmostLongestReplace(s string, patterns []string) string {
+	var result strings.Builder
+
+	for i := 0; i < len(s); {
+		longest := 0
+
+		for _, p := range patterns {
+			if len(p) > longest && strings.HasPrefix(s[i:], p) {
+				longest = len(p)
+			}
+		}
+
+		if longest == 0 {
+			result.WriteByte(s[i])
+			i++
+
+			continue
+		}
+
+		result.WriteString("***")
+		i += longest
+	}
+
+	return result.String()
+}
+
+func BenchmarkApplyMask_Strings(b *testing.B) {
+	for _, count := range []int{1, 10, 100, 1000} {
+		patterns := make([]string, count)
+
+		for i := range patterns {
+			patterns[i] = fmt.Sprintf("token-%08x", i*7919)
+		}
+
+		msg := "request to https://example.com/v1/instances with token " + patterns[count/2] + " succeeded"
+		fields := map[string]interface{}{
+			"k1": "pineapple mango",
+			"k2": "the value is " + patterns[count-1],
+			"k3": "https://example.com/v1/instances/i-0123456789abcdef0",
+			"k4": "a longer field value, which does not contain any of the masked strings at all",
+		}
+
+		b.Run(fmt.Sprintf("strings=%d", count), func(b *testing.B) {
+			lOpts := logging.ApplyLoggerOpts(
+				logging.WithMaskMessageStrings(patterns...),
+				logging.WithMaskAllFieldValuesStrings(patterns...),
+			)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for n := 0; n < b.N; n++ {
+				benchmarkMsg := msg
+				benchmarkFields := map[string]interface{}{
+					"k1": fields["k1"],
+					"k2": fields["k2"],
+					"k3": fields["k3"],
+					"k4": fields["k4"],
+				}
+
+				lOpts.ApplyMask(&benchmarkMsg, benchmarkFields)
+			}
+		})
+
+		// Replacing each string in turn, as before the strings were
+		// compiled, for comparison.
+		b.Run(fmt.Sprintf("strings=%d/replace-all", count), func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for n := 0; n < b.N; n++ {
+				benchmarkMsg := msg
+				benchmarkFields := map[string]interface{}{
+					"k1": fields["k1"],
+					"k2": fields["k2"],
+					"k3": fields["k3"],
+					"k4": fields["k4"],
+				}
+
+				for _, s := range patterns {
+					benchmarkMsg = strings.ReplaceAll(benchmarkMsg, s, "***")
+				}
+
+				for fk, fv := range benchmarkFields {
+					fvStr, ok := fv.(string)
+
+					if !ok {
+						continue
+					}
+
+					for _, s := range patterns {
+						if strings.Contains(fvStr, s) {
+							fvStr = strings.ReplaceAll(fvStr, s, "***")
+						}
+					}
+
+					benchmarkFields[fk] = fvStr
+				}
+			}
+		})
+	}
+}
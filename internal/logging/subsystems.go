@@ -0,0 +1,110 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SubsystemRegistry tracks the names of the subsystem loggers created for a
+// root logger, including the ones automatically created because they were
+// used before being created. A registry is created alongside each root logger
+// and shared by every context.Context derived from it, so it is safe for
+// concurrent use.
+type SubsystemRegistry struct {
+	// mutex protects subsystems and autoCreated from concurrent read and
+	// write panics.
+	mutex sync.RWMutex
+
+	// subsystems holds the names of the registered subsystems.
+	subsystems map[string]struct{}
+
+	// autoCreated holds the names of the registered subsystems which were
+	// automatically created, because they were used before being created.
+	autoCreated map[string]struct{}
+}
+
+// NewSubsystemRegistry returns an empty SubsystemRegistry.
+func NewSubsystemRegistry() *SubsystemRegistry {
+	return &SubsystemRegistry{
+		subsystems:  make(map[string]struct{}),
+		autoCreated: make(map[string]struct{}),
+	}
+}
+
+// Register records the named subsystem in the registry. Registering the same
+// subsystem multiple times has no additional effect.
+func (r *SubsystemRegistry) Register(subsystem string) {
+	r.mutex.Lock()
+
+	r.subsystems[subsystem] = struct{}{}
+
+	r.mutex.Unlock()
+}
+
+// RegisterAutoCreated records the named subsystem in the registry, as a
+// subsystem that was automatically created because it was used before being
+// created.
+func (r *SubsystemRegistry) RegisterAutoCreated(subsystem string) {
+	r.mutex.Lock()
+
+	r.subsystems[subsystem] = struct{}{}
+	r.autoCreated[subsystem] = struct{}{}
+
+	r.mutex.Unlock()
+}
+
+// Names returns the sorted names of all registered subsystems. It is safe to
+// call on a nil SubsystemRegistry, which has no subsystems.
+func (r *SubsystemRegistry) Names() []string {
+	if r == nil {
+		return nil
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return sortedKeys(r.subsystems)
+}
+
+// AutoCreatedNames returns the sorted names of all registered subsystems
+// which were automatically created. It is safe to call on a nil
+// SubsystemRegistry, which has no subsystems.
+func (r *SubsystemRegistry) AutoCreatedNames() []string {
+	if r == nil {
+		return nil
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return sortedKeys(r.autoCreated)
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	result := make([]string, 0, len(m))
+
+	for k := range m {
+		result = append(result, k)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// ParentSubsystems returns the names of the potential parent subsystems of a
+// nested subsystem, closest parent first. For example, the potential parents
+// of the "a.b.c" subsystem are "a.b" and "a".
+func ParentSubsystems(subsystem string) []string {
+	var result []string
+
+	for i := strings.LastIndex(subsystem, "."); i > 0; i = strings.LastIndex(subsystem[:i], ".") {
+		result = append(result, subsystem[:i])
+	}
+
+	return result
+}
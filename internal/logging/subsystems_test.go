@@ -0,0 +1,50 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+func TestParentSubsystems(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		subsystem string
+		expected  []string
+	}{
+		"not-nested": {
+			subsystem: "a",
+			expected:  nil,
+		},
+		"nested": {
+			subsystem: "a.b",
+			expected:  []string{"a"},
+		},
+		"deeply-nested": {
+			subsystem: "a.b.c",
+			expected:  []string{"a.b", "a"},
+		},
+		"leading-period": {
+			subsystem: ".a",
+			expected:  nil,
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := logging.ParentSubsystems(testCase.subsystem)
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
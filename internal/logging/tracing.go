@@ -0,0 +1,61 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package logging
+
+import "context"
+
+const (
+	// TraceIDFieldKey is the field key holding the ID of the active trace,
+	// as returned by a TraceContextExtractor.
+	TraceIDFieldKey = "trace_id"
+
+	// SpanIDFieldKey is the field key holding the ID of the active span, as
+	// returned by a TraceContextExtractor.
+	SpanIDFieldKey = "span_id"
+)
+
+// TraceContextExtractor extracts the IDs of the active trace and span from a
+// context.Context, which are then included as fields in each log output.
+// This allows linking log output with traces, without requiring a dependency
+// on a specific tracing library, such as OpenTelemetry.
+type TraceContextExtractor interface {
+	// ExtractTraceContext returns the IDs of the active trace and span in
+	// `ctx`. If `ctx` does not contain an active span, ok should be false.
+	ExtractTraceContext(ctx context.Context) (traceID string, spanID string, ok bool)
+}
+
+// traceContextFields adds the trace and span ID fields, as returned by the
+// TraceContextExtractor of the LoggerOpts, to the given additional fields of
+// a log entry. Fields with the same keys already in `fields` or in the Fields
+// of the LoggerOpts take precedence.
+func (lo LoggerOpts) traceContextFields(ctx context.Context, fields map[string]interface{}) {
+	if lo.TraceContextExtractor == nil || ctx == nil {
+		return
+	}
+
+	traceID, spanID, ok := lo.TraceContextExtractor.ExtractTraceContext(ctx)
+	if !ok {
+		return
+	}
+
+	if traceID != "" && !lo.hasField(fields, TraceIDFieldKey) {
+		fields[TraceIDFieldKey] = traceID
+	}
+
+	if spanID != "" && !lo.hasField(fields, SpanIDFieldKey) {
+		fields[SpanIDFieldKey] = spanID
+	}
+}
+
+// hasField returns true if the given additional fields of a log entry or the
+// Fields of the LoggerOpts contain the given key.
+func (lo LoggerOpts) hasField(fields map[string]interface{}, key string) bool {
+	if _, exists := fields[key]; exists {
+		return true
+	}
+
+	_, exists := lo.Fields[key]
+
+	return exists
+}
@@ -0,0 +1,114 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func TestWithFieldAllowlist(t *testing.T) {
+	t.Parallel()
+
+	fields := map[string]interface{}{
+		"k1": "v1",
+		"k2": "v2",
+		"k3": "v3",
+	}
+
+	testCases := map[string]struct {
+		logImpl        func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"subsystem-drop": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem, tflog.WithFieldAllowlist("k1"))
+				ctx = tflog.SubsystemSetField(ctx, testSubsystem, "subsystem", "test-subsystem")
+
+				tflog.SubsystemDebug(ctx, testSubsystem, "test subsystem message", fields)
+				tflog.Debug(ctx, "test message", fields)
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":               "debug",
+					"@message":             "test subsystem message",
+					"@module":              testSubsystemModule,
+					"k1":                   "v1",
+					tflog.RemovedFieldsKey: []interface{}{"k2", "k3", "subsystem"},
+				},
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "provider",
+					"k1":       "v1",
+					"k2":       "v2",
+					"k3":       "v3",
+				},
+			},
+		},
+		"subsystem-mask": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem, tflog.WithFieldAllowlistMasked("k1", "k2"))
+
+				tflog.SubsystemDebug(ctx, testSubsystem, "test subsystem message", fields)
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":               "debug",
+					"@message":             "test subsystem message",
+					"@module":              testSubsystemModule,
+					"k1":                   "v1",
+					"k2":                   "v2",
+					"k3":                   "***",
+					tflog.RemovedFieldsKey: []interface{}{"k3"},
+				},
+			},
+		},
+		"subsystem-omit": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem, tflog.WithFieldAllowlist("k1"), tflog.WithOmitLogWithFieldKeys("k3"))
+
+				tflog.SubsystemDebug(ctx, testSubsystem, "test omitted message", fields)
+				tflog.SubsystemDebug(ctx, testSubsystem, "test subsystem message", map[string]interface{}{"k1": "v1"})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test subsystem message",
+					"@module":  testSubsystemModule,
+					"k1":       "v1",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+
+			testCase.logImpl(ctx)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog_test
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		setup    func(context.Context) context.Context
+		expected tflog.Description
+	}{
+		"no-root-logger": {
+			setup: func(_ context.Context) context.Context {
+				return context.Background()
+			},
+			expected: tflog.Description{},
+		},
+		"no-configuration": {
+			setup: func(ctx context.Context) context.Context {
+				return ctx
+			},
+			expected: tflog.Description{
+				Name:                  "provider",
+				Level:                 hclog.Trace,
+				Subsystems:            []string{},
+				AutoCreatedSubsystems: []string{},
+			},
+		},
+		"fields-masked": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tflog.SetField(ctx, "k1", "v1")
+				ctx = tflog.SetField(ctx, "k2", "secret-v2")
+				ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "k2")
+
+				return ctx
+			},
+			expected: tflog.Description{
+				Name:  "provider",
+				Level: hclog.Trace,
+				Fields: map[string]interface{}{
+					"k1": "v1",
+					"k2": "***",
+				},
+				Subsystems:                   []string{},
+				AutoCreatedSubsystems:        []string{},
+				MaskFieldValuesWithFieldKeys: []string{"k2"},
+			},
+		},
+		"rules": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tflog.OmitLogWithFieldKeys(ctx, "k1")
+				ctx = tflog.OmitLogWithMessageRegexes(ctx, regexp.MustCompile("foo"))
+				ctx = tflog.OmitLogWithMessageStrings(ctx, "bar")
+				ctx = tflog.MaskAllFieldValuesRegexes(ctx, regexp.MustCompile("baz"))
+				ctx = tflog.MaskAllFieldValuesStrings(ctx, "secret1", "secret2")
+				ctx = tflog.MaskMessageRegexes(ctx, regexp.MustCompile("qux"))
+				ctx = tflog.MaskMessageStrings(ctx, "secret3")
+
+				return ctx
+			},
+			expected: tflog.Description{
+				Name:                           "provider",
+				Level:                          hclog.Trace,
+				Subsystems:                     []string{},
+				AutoCreatedSubsystems:          []string{},
+				OmitLogWithFieldKeys:           []string{"k1"},
+				OmitLogWithMessageRegexes:      []string{"foo"},
+				OmitLogWithMessageStrings:      []string{"bar"},
+				MaskAllFieldValuesRegexes:      []string{"baz"},
+				MaskAllFieldValuesStringsCount: 2,
+				MaskMessageRegexes:             []string{"qux"},
+				MaskMessageStringsCount:        1,
+			},
+		},
+		"subsystems": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tflog.NewSubsystem(ctx, "sub2")
+				ctx = tflog.NewSubsystem(ctx, "sub1")
+
+				return ctx
+			},
+			expected: tflog.Description{
+				Name:                  "provider",
+				Level:                 hclog.Trace,
+				Subsystems:            []string{"sub1", "sub2"},
+				AutoCreatedSubsystems: []string{},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+			ctx = testCase.setup(ctx)
+
+			got := tflog.Describe(ctx)
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSubsystemDescribe(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		setup    func(context.Context) context.Context
+		expected tflog.Description
+	}{
+		"no-subsystem-logger": {
+			setup: func(ctx context.Context) context.Context {
+				return ctx
+			},
+			expected: tflog.Description{},
+		},
+		"no-configuration": {
+			setup: func(ctx context.Context) context.Context {
+				return tflog.NewSubsystem(ctx, testSubsystem)
+			},
+			expected: tflog.Description{
+				Name:  testSubsystemModule,
+				Level: hclog.Trace,
+			},
+		},
+		"level": {
+			setup: func(ctx context.Context) context.Context {
+				return tflog.NewSubsystem(ctx, testSubsystem, tflog.WithLevel(hclog.Warn))
+			},
+			expected: tflog.Description{
+				Name:  testSubsystemModule,
+				Level: hclog.Warn,
+			},
+		},
+		"fields-and-rules": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tflog.SetField(ctx, "root-key", "root-value")
+				ctx = tflog.NewSubsystem(ctx, testSubsystem, tflog.WithRootFields())
+				ctx = tflog.SubsystemSetField(ctx, testSubsystem, "sub-key", "sub-value")
+				ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, testSubsystem, "root-value")
+
+				return ctx
+			},
+			expected: tflog.Description{
+				Name:  testSubsystemModule,
+				Level: hclog.Trace,
+				Fields: map[string]interface{}{
+					"root-key": "***",
+					"sub-key":  "sub-value",
+				},
+				MaskAllFieldValuesStringsCount: 1,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+			ctx = testCase.setup(ctx)
+
+			got := tflog.SubsystemDescribe(ctx, testSubsystem)
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
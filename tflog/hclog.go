@@ -0,0 +1,260 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog
+
+import (
+	"context"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/hclogutils"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+// HCLogger returns an hclog.Logger for the provider root logger in `ctx`, for
+// use with libraries which accept an hclog.Logger. Log output of the returned
+// hclog.Logger has the same semantics as the package-level logging functions,
+// including the fields set with SetField and masking and omission. Changes
+// made to the context.Context afterwards are not reflected in the returned
+// hclog.Logger.
+//
+// The hclog.Logger methods behave as follows:
+//
+//   - With includes the given key/value pairs as fields in all its log
+//     output, which are subject to masking and omission.
+//   - Named and ResetNamed change the logger name, i.e. the @module field.
+//   - SetLevel only changes the level of the returned hclog.Logger, not the
+//     one of the provider root logger.
+//   - StandardLogger and StandardWriter apply masking and omission, and
+//     include the caller of the *log.Logger method as the location.
+//
+// If `ctx` has no provider root logger, the returned hclog.Logger does not
+// log anything.
+func HCLogger(ctx context.Context) hclog.Logger {
+	logger := logging.GetProviderRootLogger(ctx)
+	if logger == nil {
+		// this essentially should never happen in production
+		// the root logger should be injected by the SDK,
+		// so really this is only likely in unit tests, at most
+		// so just making this a no-op is fine
+		return hclog.NewNullLogger()
+	}
+
+	return newHCLogAdapter(
+		ctx,
+		logger,
+		logging.GetProviderRootLoggerOptions(ctx),
+		logging.GetProviderRootTFLoggerOpts(ctx),
+	)
+}
+
+// SubsystemHCLogger returns an hclog.Logger for the subsystem logger
+// specified in `ctx`, with the same semantics as HCLogger. As with the
+// package-level subsystem logging functions, the subsystem logger is
+// automatically created if it was not created with NewSubsystem.
+func SubsystemHCLogger(ctx context.Context, subsystem string) hclog.Logger {
+	logger := logging.GetProviderSubsystemLogger(ctx, subsystem)
+	if logger == nil {
+		if logging.GetProviderRootLogger(ctx) == nil {
+			// logging isn't set up, nothing we can do, just silently fail
+			// this should basically never happen in production
+			return hclog.NewNullLogger()
+		}
+		// create a new logger if one doesn't exist
+		logger = autoCreateSubsystemLogger(ctx, subsystem)
+	}
+
+	return newHCLogAdapter(
+		ctx,
+		logger,
+		logging.GetProviderSubsystemLoggerOptions(ctx, subsystem),
+		logging.GetProviderSubsystemEffectiveTFLoggerOpts(ctx, subsystem),
+	)
+}
+
+// StdLogger returns a *log.Logger for the provider root logger in `ctx`, for
+// use with libraries which accept a *log.Logger. All log output is logged at
+// `level`, stripping any conventional level prefix, such as `[DEBUG]`, from
+// the log line. Otherwise it has the same semantics as HCLogger.
+func StdLogger(ctx context.Context, level hclog.Level) *log.Logger {
+	return HCLogger(ctx).StandardLogger(&hclog.StandardLoggerOptions{
+		ForceLevel: level,
+	})
+}
+
+// SubsystemStdLogger returns a *log.Logger for the subsystem logger specified
+// in `ctx`, with the same semantics as StdLogger.
+func SubsystemStdLogger(ctx context.Context, subsystem string, level hclog.Level) *log.Logger {
+	return SubsystemHCLogger(ctx, subsystem).StandardLogger(&hclog.StandardLoggerOptions{
+		ForceLevel: level,
+	})
+}
+
+// hclogAdapter is the hclog.Logger implementation of HCLogger, which logs
+// through the provider loggers with masking and omission.
+type hclogAdapter struct {
+	// ctx is the context.Context the hclogAdapter was created from, used to
+	// extract the IDs of the active trace and span.
+	ctx context.Context
+
+	logger        hclog.Logger
+	loggerOptions *hclog.LoggerOptions
+	lOpts         logging.LoggerOpts
+}
+
+var _ hclog.Logger = &hclogAdapter{}
+
+func newHCLogAdapter(ctx context.Context, logger hclog.Logger, loggerOptions *hclog.LoggerOptions, lOpts logging.LoggerOpts) *hclogAdapter {
+	return &hclogAdapter{
+		ctx: ctx,
+		// Copy the logger, so SetLevel does not affect the logger in the
+		// context.Context, as the provider loggers have independent levels.
+		logger:        logger.ResetNamed(logger.Name()),
+		loggerOptions: loggerOptions,
+		lOpts:         lOpts,
+	}
+}
+
+// Log logs `msg` at the given level, with `args` as key/value pairs.
+func (a *hclogAdapter) Log(level hclog.Level, msg string, args ...interface{}) {
+	additionalArgs, shouldOmit := logging.OmitOrMask(a.ctx, a.logger, level, a.lOpts, &msg, []map[string]interface{}{hclogutils.ArgsToFieldMap(args...)})
+	if shouldOmit {
+		return
+	}
+
+	a.logger.Log(level, msg, additionalArgs...)
+}
+
+// Trace logs `msg` at the trace level, with `args` as key/value pairs.
+func (a *hclogAdapter) Trace(msg string, args ...interface{}) {
+	additionalArgs, shouldOmit := logging.OmitOrMask(a.ctx, a.logger, hclog.Trace, a.lOpts, &msg, []map[string]interface{}{hclogutils.ArgsToFieldMap(args...)})
+	if shouldOmit {
+		return
+	}
+
+	a.logger.Trace(msg, additionalArgs...)
+}
+
+// Debug logs `msg` at the debug level, with `args` as key/value pairs.
+func (a *hclogAdapter) Debug(msg string, args ...interface{}) {
+	additionalArgs, shouldOmit := logging.OmitOrMask(a.ctx, a.logger, hclog.Debug, a.lOpts, &msg, []map[string]interface{}{hclogutils.ArgsToFieldMap(args...)})
+	if shouldOmit {
+		return
+	}
+
+	a.logger.Debug(msg, additionalArgs...)
+}
+
+// Info logs `msg` at the info level, with `args` as key/value pairs.
+func (a *hclogAdapter) Info(msg string, args ...interface{}) {
+	additionalArgs, shouldOmit := logging.OmitOrMask(a.ctx, a.logger, hclog.Info, a.lOpts, &msg, []map[string]interface{}{hclogutils.ArgsToFieldMap(args...)})
+	if shouldOmit {
+		return
+	}
+
+	a.logger.Info(msg, additionalArgs...)
+}
+
+// Warn logs `msg` at the warn level, with `args` as key/value pairs.
+func (a *hclogAdapter) Warn(msg string, args ...interface{}) {
+	additionalArgs, shouldOmit := logging.OmitOrMask(a.ctx, a.logger, hclog.Warn, a.lOpts, &msg, []map[string]interface{}{hclogutils.ArgsToFieldMap(args...)})
+	if shouldOmit {
+		return
+	}
+
+	a.logger.Warn(msg, additionalArgs...)
+}
+
+// Error logs `msg` at the error level, with `args` as key/value pairs.
+func (a *hclogAdapter) Error(msg string, args ...interface{}) {
+	additionalArgs, shouldOmit := logging.OmitOrMask(a.ctx, a.logger, hclog.Error, a.lOpts, &msg, []map[string]interface{}{hclogutils.ArgsToFieldMap(args...)})
+	if shouldOmit {
+		return
+	}
+
+	a.logger.Error(msg, additionalArgs...)
+}
+
+func (a *hclogAdapter) IsTrace() bool { return a.logger.IsTrace() }
+func (a *hclogAdapter) IsDebug() bool { return a.logger.IsDebug() }
+func (a *hclogAdapter) IsInfo() bool  { return a.logger.IsInfo() }
+func (a *hclogAdapter) IsWarn() bool  { return a.logger.IsWarn() }
+func (a *hclogAdapter) IsError() bool { return a.logger.IsError() }
+
+// ImpliedArgs returns the fields of the logger as key/value pairs, sorted by
+// key.
+func (a *hclogAdapter) ImpliedArgs() []interface{} {
+	keys := make([]string, 0, len(a.lOpts.Fields))
+
+	for key := range a.lOpts.Fields {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	result := make([]interface{}, 0, len(keys)*2)
+
+	for _, key := range keys {
+		result = append(result, key, a.lOpts.Fields[key])
+	}
+
+	return result
+}
+
+// With returns a copy of the logger which includes `args` as fields in all
+// its log output.
+func (a *hclogAdapter) With(args ...interface{}) hclog.Logger {
+	result := *a
+
+	// Copy to prevent slice/map aliasing issues.
+	// Reference: https://github.com/hashicorp/terraform-plugin-log/issues/131
+	result.lOpts = logging.WithFields(hclogutils.ArgsToFieldMap(args...))(a.lOpts.Copy())
+
+	return &result
+}
+
+func (a *hclogAdapter) Name() string {
+	return a.logger.Name()
+}
+
+// Named returns a copy of the logger with `name` appended to its name.
+func (a *hclogAdapter) Named(name string) hclog.Logger {
+	result := *a
+	result.logger = a.logger.Named(name)
+
+	return &result
+}
+
+// ResetNamed returns a copy of the logger with its name replaced by `name`.
+func (a *hclogAdapter) ResetNamed(name string) hclog.Logger {
+	result := *a
+	result.logger = a.logger.ResetNamed(name)
+
+	return &result
+}
+
+func (a *hclogAdapter) SetLevel(level hclog.Level) {
+	a.logger.SetLevel(level)
+}
+
+func (a *hclogAdapter) GetLevel() hclog.Level {
+	return a.logger.GetLevel()
+}
+
+// StandardLogger returns a *log.Logger writing to StandardWriter.
+func (a *hclogAdapter) StandardLogger(opts *hclog.StandardLoggerOptions) *log.Logger {
+	return log.New(a.StandardWriter(opts), "", 0)
+}
+
+// StandardWriter returns an io.Writer which logs each written line with the
+// logger, determining the level with `opts` as in go-hclog.
+func (a *hclogAdapter) StandardWriter(opts *hclog.StandardLoggerOptions) io.Writer {
+	if opts == nil {
+		opts = &hclog.StandardLoggerOptions{}
+	}
+
+	return logging.NewStdlogWriter(a.ctx, a.logger, a.loggerOptions, a.lOpts, *opts)
+}
@@ -0,0 +1,49 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog
+
+import (
+	"github.com/hashicorp/go-hclog"
+)
+
+func ExampleHCLogger() {
+	// virtually no plugin developers will need to worry about
+	// instantiating loggers, as the libraries they're using will take care
+	// of that, but we're not using those libraries in these examples. So
+	// we need to do the injection ourselves. Plugin developers will
+	// basically never need to do this, so the next line can safely be
+	// considered setup for the example and ignored. Instead, use the
+	// context passed in by the framework or library you're using.
+	exampleCtx := getExampleContext()
+
+	// non-example-setup code begins here
+	exampleCtx = SetField(exampleCtx, "foo", 123)
+	logger := HCLogger(exampleCtx).Named("my-library")
+
+	// logger can be passed to any library accepting an hclog.Logger
+	logger.Info("example log message", "bar", "baz")
+
+	// Output:
+	// {"@level":"info","@message":"example log message","@module":"provider.my-library","bar":"baz","foo":123}
+}
+
+func ExampleStdLogger() {
+	// virtually no plugin developers will need to worry about
+	// instantiating loggers, as the libraries they're using will take care
+	// of that, but we're not using those libraries in these examples. So
+	// we need to do the injection ourselves. Plugin developers will
+	// basically never need to do this, so the next line can safely be
+	// considered setup for the example and ignored. Instead, use the
+	// context passed in by the framework or library you're using.
+	exampleCtx := getExampleContext()
+
+	// non-example-setup code begins here
+	logger := StdLogger(exampleCtx, hclog.Debug)
+
+	// logger can be passed to any library accepting a *log.Logger
+	logger.Printf("example log message with %d", 123)
+
+	// Output:
+	// {"@level":"debug","@message":"example log message with 123","@module":"provider"}
+}
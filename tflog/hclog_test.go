@@ -0,0 +1,471 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func TestHCLogger(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		logImpl        func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"no-root-logger": {
+			logImpl: func(_ context.Context) {
+				tflog.HCLogger(context.Background()).Info("test message")
+			},
+			expectedOutput: nil,
+		},
+		"levels": {
+			logImpl: func(ctx context.Context) {
+				logger := tflog.HCLogger(ctx)
+
+				logger.Trace("test trace message", "test-key", "test-value")
+				logger.Debug("test debug message")
+				logger.Info("test info message")
+				logger.Warn("test warn message")
+				logger.Error("test error message")
+				logger.Log(hclog.Info, "test log message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test trace message",
+					"@module":  "provider",
+					"test-key": "test-value",
+				},
+				{
+					"@level":   "debug",
+					"@message": "test debug message",
+					"@module":  "provider",
+				},
+				{
+					"@level":   "info",
+					"@message": "test info message",
+					"@module":  "provider",
+				},
+				{
+					"@level":   "warn",
+					"@message": "test warn message",
+					"@module":  "provider",
+				},
+				{
+					"@level":   "error",
+					"@message": "test error message",
+					"@module":  "provider",
+				},
+				{
+					"@level":   "info",
+					"@message": "test log message",
+					"@module":  "provider",
+				},
+			},
+		},
+		"fields-and-masking": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.SetField(ctx, "root-key", "root-value")
+				ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "secret-key", "with-secret-key")
+				ctx = tflog.MaskMessageStrings(ctx, "hunter2")
+
+				tflog.HCLogger(ctx).With("with-secret-key", "with-secret-value").Info("password is hunter2", "secret-key", "secret-value")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":          "info",
+					"@message":        "password is ***",
+					"@module":         "provider",
+					"root-key":        "root-value",
+					"secret-key":      "***",
+					"with-secret-key": "***",
+				},
+			},
+		},
+		"omission": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.OmitLogWithFieldKeys(ctx, "omit-key")
+
+				logger := tflog.HCLogger(ctx)
+
+				logger.Info("test omitted message", "omit-key", "omit-value")
+				logger.With("omit-key", "omit-value").Info("test omitted message")
+				logger.Info("test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "info",
+					"@message": "test message",
+					"@module":  "provider",
+				},
+			},
+		},
+		"extra-value-at-end": {
+			logImpl: func(ctx context.Context) {
+				tflog.HCLogger(ctx).Info("test message", "test-key", "test-value", "test-extra-value")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":             "info",
+					"@message":           "test message",
+					"@module":            "provider",
+					"test-key":           "test-value",
+					"EXTRA_VALUE_AT_END": "test-extra-value",
+				},
+			},
+		},
+		"named": {
+			logImpl: func(ctx context.Context) {
+				logger := tflog.HCLogger(ctx).Named("test-name")
+
+				logger.Info("test named message")
+				logger.ResetNamed("test-reset-name").Info("test reset named message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "info",
+					"@message": "test named message",
+					"@module":  "provider.test-name",
+				},
+				{
+					"@level":   "info",
+					"@message": "test reset named message",
+					"@module":  "test-reset-name",
+				},
+			},
+		},
+		"set-level": {
+			logImpl: func(ctx context.Context) {
+				logger := tflog.HCLogger(ctx)
+				logger.SetLevel(hclog.Warn)
+
+				logger.Info("test omitted message")
+				logger.Warn("test warn message")
+				tflog.Info(ctx, "test root message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "warn",
+					"@message": "test warn message",
+					"@module":  "provider",
+				},
+				{
+					"@level":   "info",
+					"@message": "test root message",
+					"@module":  "provider",
+				},
+			},
+		},
+		"subsystem": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, "test_subsystem")
+				ctx = tflog.SubsystemSetField(ctx, "test_subsystem", "subsystem-key", "subsystem-value")
+
+				tflog.SubsystemHCLogger(ctx, "test_subsystem").Info("test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":        "info",
+					"@message":      "test message",
+					"@module":       "provider.test_subsystem",
+					"subsystem-key": "subsystem-value",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+
+			testCase.logImpl(ctx)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestHCLogger_ImpliedArgs(t *testing.T) {
+	t.Parallel()
+
+	var outputBuffer bytes.Buffer
+
+	ctx := loggertest.ProviderRoot(context.Background(), &outputBuffer)
+	ctx = tflog.SetField(ctx, "b-key", "b-value")
+
+	got := tflog.HCLogger(ctx).With("a-key", "a-value").ImpliedArgs()
+	expected := []interface{}{"a-key", "a-value", "b-key", "b-value"}
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		logImpl        func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"no-root-logger": {
+			logImpl: func(_ context.Context) {
+				tflog.StdLogger(context.Background(), hclog.Info).Println("test message")
+			},
+			expectedOutput: nil,
+		},
+		"force-level": {
+			logImpl: func(ctx context.Context) {
+				logger := tflog.StdLogger(ctx, hclog.Warn)
+
+				logger.Println("test message")
+				logger.Println("[DEBUG] test prefixed message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "warn",
+					"@message": "test message",
+					"@module":  "provider",
+				},
+				{
+					"@level":   "warn",
+					"@message": "test prefixed message",
+					"@module":  "provider",
+				},
+			},
+		},
+		"infer-levels": {
+			logImpl: func(ctx context.Context) {
+				logger := tflog.HCLogger(ctx).StandardLogger(&hclog.StandardLoggerOptions{
+					InferLevels: true,
+				})
+
+				logger.Println("[DEBUG] test debug message")
+				logger.Println("[ERR] test error message")
+				logger.Println("test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test debug message",
+					"@module":  "provider",
+				},
+				{
+					"@level":   "error",
+					"@message": "test error message",
+					"@module":  "provider",
+				},
+				{
+					"@level":   "info",
+					"@message": "test message",
+					"@module":  "provider",
+				},
+			},
+		},
+		"fields-and-masking": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.SetField(ctx, "root-key", "root-value")
+				ctx = tflog.MaskMessageStrings(ctx, "hunter2")
+				ctx = tflog.OmitLogWithMessageStrings(ctx, "omitted")
+
+				logger := tflog.StdLogger(ctx, hclog.Info)
+
+				logger.Println("test omitted message")
+				logger.Printf("password is %s", "hunter2")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "info",
+					"@message": "password is ***",
+					"@module":  "provider",
+					"root-key": "root-value",
+				},
+			},
+		},
+		"level-filtering": {
+			logImpl: func(ctx context.Context) {
+				logger := tflog.HCLogger(ctx)
+				logger.SetLevel(hclog.Info)
+
+				stdLogger := logger.StandardLogger(&hclog.StandardLoggerOptions{
+					InferLevels: true,
+				})
+
+				stdLogger.Println("[DEBUG] test debug message")
+				stdLogger.Println("[INFO] test info message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "info",
+					"@message": "test info message",
+					"@module":  "provider",
+				},
+			},
+		},
+		"subsystem-named": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, "test_subsystem")
+
+				tflog.SubsystemStdLogger(ctx, "test_subsystem", hclog.Debug).Println("test subsystem message")
+				tflog.HCLogger(ctx).Named("test-name").StandardLogger(nil).Println("test named message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test subsystem message",
+					"@module":  "provider.test_subsystem",
+				},
+				{
+					"@level":   "info",
+					"@message": "test named message",
+					"@module":  "provider.test-name",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+
+			testCase.logImpl(ctx)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestHCLogger_Location(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		logImpl func(context.Context) int
+	}{
+		"hclogger": {
+			logImpl: func(ctx context.Context) int {
+				_, _, line, _ := runtime.Caller(0)
+				tflog.HCLogger(ctx).Info("test message")
+
+				return line + 1
+			},
+		},
+		"hclogger-log": {
+			logImpl: func(ctx context.Context) int {
+				_, _, line, _ := runtime.Caller(0)
+				tflog.HCLogger(ctx).With("test-key", "test-value").Log(hclog.Info, "test message")
+
+				return line + 1
+			},
+		},
+		"hclogger-named": {
+			logImpl: func(ctx context.Context) int {
+				_, _, line, _ := runtime.Caller(0)
+				tflog.HCLogger(ctx).Named("test-name").Info("test message")
+
+				return line + 1
+			},
+		},
+		"stdlogger-println": {
+			logImpl: func(ctx context.Context) int {
+				_, _, line, _ := runtime.Caller(0)
+				tflog.StdLogger(ctx, hclog.Info).Println("test message")
+
+				return line + 1
+			},
+		},
+		"stdlogger-printf": {
+			logImpl: func(ctx context.Context) int {
+				_, _, line, _ := runtime.Caller(0)
+				tflog.StdLogger(ctx, hclog.Info).Printf("test %s", "message")
+
+				return line + 1
+			},
+		},
+		"subsystem-stdlogger": {
+			logImpl: func(ctx context.Context) int {
+				ctx = tflog.NewSubsystem(ctx, "test_subsystem")
+
+				_, _, line, _ := runtime.Caller(0)
+				tflog.SubsystemStdLogger(ctx, "test_subsystem", hclog.Info).Println("test message")
+
+				return line + 1
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRootWithLocation(ctx, &outputBuffer)
+
+			expectedLine := testCase.logImpl(ctx)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if len(got) != 1 {
+				t.Fatalf("expected 1 log entry, got: %v", got)
+			}
+
+			caller, ok := got[0]["@caller"].(string)
+
+			if !ok {
+				t.Fatalf("expected @caller string field, got: %v", got[0])
+			}
+
+			expectedSuffix := fmt.Sprintf("/tflog/hclog_test.go:%d", expectedLine)
+
+			if !strings.HasSuffix(caller, expectedSuffix) {
+				t.Errorf("expected @caller with suffix %q, got: %q", expectedSuffix, caller)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+// recordingEntryHook is an EntryHook recording the entries it processes,
+// prefixed with its name, without changing them.
+func recordingEntryHook(name string, recorded *[]string) tflog.EntryHook {
+	return tflog.EntryHookFunc(func(_ context.Context, entry tflog.Entry) (tflog.Entry, bool) {
+		*recorded = append(*recorded, name+": "+entry.LoggerName+": "+entry.Message)
+
+		return entry, true
+	})
+}
+
+func TestWithEntryHooks(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		rootHooks        func(*[]string) []tflog.EntryHook
+		log              func(context.Context, *[]string)
+		expectedRecorded []string
+		expectedOutput   []map[string]interface{}
+	}{
+		"no-hooks": {
+			log: func(ctx context.Context, _ *[]string) {
+				tflog.Trace(ctx, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "provider",
+				},
+			},
+		},
+		"enrich": {
+			rootHooks: func(_ *[]string) []tflog.EntryHook {
+				return []tflog.EntryHook{
+					tflog.EntryHookFunc(func(_ context.Context, entry tflog.Entry) (tflog.Entry, bool) {
+						entry.Fields["level_string"] = entry.Level.String()
+
+						return entry, true
+					}),
+				}
+			},
+			log: func(ctx context.Context, _ *[]string) {
+				tflog.Debug(ctx, "test message", map[string]interface{}{"k1": "v1"})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":       "debug",
+					"@message":     "test message",
+					"@module":      "provider",
+					"k1":           "v1",
+					"level_string": "debug",
+				},
+			},
+		},
+		"transform": {
+			rootHooks: func(_ *[]string) []tflog.EntryHook {
+				return []tflog.EntryHook{
+					tflog.EntryHookFunc(func(_ context.Context, entry tflog.Entry) (tflog.Entry, bool) {
+						entry.Message = "transformed: " + entry.Message
+						entry.Fields = map[string]interface{}{"replaced": true}
+
+						return entry, true
+					}),
+				}
+			},
+			log: func(ctx context.Context, _ *[]string) {
+				tflog.Info(ctx, "test message", map[string]interface{}{"k1": "v1"})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "info",
+					"@message": "transformed: test message",
+					"@module":  "provider",
+					"replaced": true,
+				},
+			},
+		},
+		"drop": {
+			rootHooks: func(recorded *[]string) []tflog.EntryHook {
+				return []tflog.EntryHook{
+					tflog.EntryHookFunc(func(_ context.Context, entry tflog.Entry) (tflog.Entry, bool) {
+						return entry, entry.Message != "dropped message"
+					}),
+					recordingEntryHook("after-drop", recorded),
+				}
+			},
+			log: func(ctx context.Context, _ *[]string) {
+				tflog.Warn(ctx, "dropped message")
+				tflog.Warn(ctx, "kept message")
+			},
+			expectedRecorded: []string{
+				"after-drop: provider: kept message",
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "warn",
+					"@message": "kept message",
+					"@module":  "provider",
+				},
+			},
+		},
+		"masked-and-omitted": {
+			rootHooks: func(recorded *[]string) []tflog.EntryHook {
+				return []tflog.EntryHook{
+					recordingEntryHook("root", recorded),
+				}
+			},
+			log: func(ctx context.Context, _ *[]string) {
+				ctx = tflog.MaskMessageStrings(ctx, "secret")
+				ctx = tflog.OmitLogWithMessageStrings(ctx, "omitted")
+
+				tflog.Error(ctx, "test secret message")
+				tflog.Error(ctx, "test omitted message")
+			},
+			expectedRecorded: []string{
+				"root: provider: test *** message",
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "error",
+					"@message": "test *** message",
+					"@module":  "provider",
+				},
+			},
+		},
+		"hook-added-values-masked": {
+			rootHooks: func(_ *[]string) []tflog.EntryHook {
+				return []tflog.EntryHook{
+					tflog.EntryHookFunc(func(_ context.Context, entry tflog.Entry) (tflog.Entry, bool) {
+						entry.Message += " with hook-secret"
+						entry.Fields["password"] = "hook-secret"
+						entry.Fields["token"] = tflog.Sensitive("hook-token")
+
+						return entry, true
+					}),
+				}
+			},
+			log: func(ctx context.Context, _ *[]string) {
+				ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "password")
+				ctx = tflog.MaskMessageStrings(ctx, "hook-secret")
+
+				tflog.Debug(ctx, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message with ***",
+					"@module":  "provider",
+					"password": "***",
+					"token":    "***",
+				},
+			},
+		},
+		"hook-metadata-fields-not-masked": {
+			log: func(ctx context.Context, recorded *[]string) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem,
+					tflog.WithEntryHooks(recordingEntryHook("subsystem", recorded)),
+					tflog.WithMaskAllFieldValuesStrings("debug_body"),
+					tflog.WithOmitLogWithFieldKeys("debug_body"),
+					tflog.WithOmitDryRun(),
+				)
+
+				tflog.SubsystemDebug(ctx, testSubsystem, "test message", map[string]interface{}{
+					"debug_body": "test-body",
+				})
+			},
+			expectedRecorded: []string{
+				"subsystem: " + testSubsystemModule + ": test message",
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                "debug",
+					"@message":              "test message",
+					"@module":               testSubsystemModule,
+					"debug_body":            "test-body",
+					tflog.WouldOmitFieldKey: "omit_log_with_field_keys:debug_body",
+				},
+			},
+		},
+		"subsystem": {
+			rootHooks: func(recorded *[]string) []tflog.EntryHook {
+				return []tflog.EntryHook{
+					recordingEntryHook("root", recorded),
+				}
+			},
+			log: func(ctx context.Context, recorded *[]string) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem, tflog.WithEntryHooks(recordingEntryHook("subsystem", recorded)))
+
+				tflog.SubsystemTrace(ctx, testSubsystem, "test message")
+			},
+			expectedRecorded: []string{
+				"subsystem: " + testSubsystemModule + ": test message",
+				"root: " + testSubsystemModule + ": test message",
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  testSubsystemModule,
+				},
+			},
+		},
+		"subsystem-level": {
+			rootHooks: func(recorded *[]string) []tflog.EntryHook {
+				return []tflog.EntryHook{
+					recordingEntryHook("root", recorded),
+				}
+			},
+			log: func(ctx context.Context, _ *[]string) {
+				ctx = tflog.NewSubsystem(ctx, "test_subsystem_hooks_level", tflog.WithLevel(hclog.Error))
+
+				tflog.SubsystemWarn(ctx, "test_subsystem_hooks_level", "test message")
+			},
+			expectedRecorded: nil,
+			expectedOutput:   nil,
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+			var recorded []string
+			var rootHooks []tflog.EntryHook
+
+			if testCase.rootHooks != nil {
+				rootHooks = testCase.rootHooks(&recorded)
+			}
+
+			ctx := tfsdklog.NewRootProviderLogger(
+				context.Background(),
+				logging.WithoutLocation(),
+				logging.WithoutTimestamp(),
+				logging.WithOutput(&outputBuffer),
+				tfsdklog.WithEntryHooks(rootHooks...),
+			)
+
+			testCase.log(ctx, &recorded)
+
+			if diff := cmp.Diff(testCase.expectedRecorded, recorded); diff != "" {
+				t.Errorf("unexpected recorded difference: %s", diff)
+			}
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func TestFieldKeyMatchers(t *testing.T) {
+	t.Parallel()
+
+	fields := map[string]interface{}{
+		"Password":      "test-password",
+		"db_password":   "test-db-password",
+		"password_hash": "test-hash",
+		"debug_body":    "test-body",
+		"user":          "test-user",
+	}
+
+	testCases := map[string]struct {
+		logImpl        func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"mask": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.MaskFieldValuesWithFieldKeyMatchers(ctx, tflog.FieldKeyGlob("*password*").CaseInsensitive(), tflog.FieldKeyPrefix("debug_"))
+
+				tflog.Debug(ctx, "test message", fields)
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":        "debug",
+					"@message":      "test message",
+					"@module":       "provider",
+					"Password":      "***",
+					"db_password":   "***",
+					"password_hash": "***",
+					"debug_body":    "***",
+					"user":          "test-user",
+				},
+			},
+		},
+		"mask-equal-fold-suffix": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.MaskFieldValuesWithFieldKeyMatchers(ctx, tflog.FieldKeyEqualFold("PASSWORD"), tflog.FieldKeySuffix("_hash"))
+
+				tflog.Debug(ctx, "test message", fields)
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":        "debug",
+					"@message":      "test message",
+					"@module":       "provider",
+					"Password":      "***",
+					"db_password":   "test-db-password",
+					"password_hash": "***",
+					"debug_body":    "test-body",
+					"user":          "test-user",
+				},
+			},
+		},
+		"omit": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.OmitLogWithFieldKeyMatchers(ctx, tflog.FieldKeyPrefix("debug_"))
+
+				tflog.Debug(ctx, "test omitted message", fields)
+				tflog.Debug(ctx, "test message", map[string]interface{}{
+					"user": "test-user",
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "provider",
+					"user":     "test-user",
+				},
+			},
+		},
+		"subsystem": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem)
+				ctx = tflog.SubsystemMaskFieldValuesWithFieldKeyMatchers(ctx, testSubsystem, tflog.FieldKeyGlob("*password*").CaseInsensitive())
+				ctx = tflog.SubsystemOmitLogWithFieldKeyMatchers(ctx, testSubsystem, tflog.FieldKeySuffix("_body"))
+
+				tflog.SubsystemDebug(ctx, testSubsystem, "test omitted message", fields)
+				tflog.SubsystemDebug(ctx, testSubsystem, "test message", map[string]interface{}{
+					"DB_PASSWORD": "test-db-password",
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":      "debug",
+					"@message":    "test message",
+					"@module":     testSubsystemModule,
+					"DB_PASSWORD": "***",
+				},
+			},
+		},
+		"subsystem-options": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem,
+					tflog.WithMaskFieldValuesWithFieldKeyMatchers(tflog.FieldKeyGlob("*password*").CaseInsensitive()),
+					tflog.WithOmitLogWithFieldKeyMatchers(tflog.FieldKeySuffix("_body")),
+				)
+
+				tflog.SubsystemDebug(ctx, testSubsystem, "test omitted message", fields)
+				tflog.SubsystemDebug(ctx, testSubsystem, "test message", map[string]interface{}{
+					"DB_PASSWORD": "test-db-password",
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":      "debug",
+					"@message":    "test message",
+					"@module":     testSubsystemModule,
+					"DB_PASSWORD": "***",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+
+			testCase.logImpl(ctx)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
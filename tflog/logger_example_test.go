@@ -0,0 +1,47 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog
+
+func ExampleLogger() {
+	// virtually no plugin developers will need to worry about
+	// instantiating loggers, as the libraries they're using will take care
+	// of that, but we're not using those libraries in these examples. So
+	// we need to do the injection ourselves. Plugin developers will
+	// basically never need to do this, so the next line can safely be
+	// considered setup for the example and ignored. Instead, use the
+	// context passed in by the framework or library you're using.
+	exampleCtx := getExampleContext()
+
+	// non-example-setup code begins here
+	logger := Logger(exampleCtx).With("foo", 123)
+
+	// all messages logged with logger will now have foo=123
+	// automatically included
+	logger.Trace("example log message")
+	logger.Debug("another example log message")
+
+	// Output:
+	// {"@level":"trace","@message":"example log message","@module":"provider","foo":123}
+	// {"@level":"debug","@message":"another example log message","@module":"provider","foo":123}
+}
+
+func ExampleSubsystemLogger() {
+	// virtually no plugin developers will need to worry about
+	// instantiating loggers, as the libraries they're using will take care
+	// of that, but we're not using those libraries in these examples. So
+	// we need to do the injection ourselves. Plugin developers will
+	// basically never need to do this, so the next line can safely be
+	// considered setup for the example and ignored. Instead, use the
+	// context passed in by the framework or library you're using.
+	exampleCtx := getExampleContext()
+
+	// non-example-setup code begins here
+	subCtx := NewSubsystem(exampleCtx, "my-subsystem")
+	logger := SubsystemLogger(subCtx, "my-subsystem")
+
+	logger.Info("example log message")
+
+	// Output:
+	// {"@level":"info","@message":"example log message","@module":"provider.my-subsystem"}
+}
@@ -0,0 +1,99 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func TestWithMaskAudit(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		logImpl        func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"subsystem": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem, tflog.WithMaskAudit(), tflog.WithMaskMessageStrings("hunter2"))
+
+				tflog.SubsystemDebug(ctx, testSubsystem, "test message hunter2", map[string]interface{}{
+					"password": tflog.Sensitive("hunter2"),
+				})
+				tflog.Debug(ctx, "test message", map[string]interface{}{
+					"password": tflog.Sensitive("hunter2"),
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message ***",
+					"@module":  testSubsystemModule,
+					"password": "***",
+					tflog.MaskedFieldKey: map[string]interface{}{
+						"fields":               []interface{}{"password"},
+						"message_replacements": float64(1),
+					},
+				},
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "provider",
+					"password": "***",
+				},
+			},
+		},
+		"subsystem-rule-ids": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem, tflog.WithMaskAuditRuleIDs(), tflog.WithMaskFieldValuesWithFieldKeyMatchers(tflog.FieldKeyGlob("*password*")))
+
+				tflog.SubsystemDebug(ctx, testSubsystem, "test message", map[string]interface{}{
+					"db_password": "hunter2",
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":      "debug",
+					"@message":    "test message",
+					"@module":     testSubsystemModule,
+					"db_password": "***",
+					tflog.MaskedFieldKey: map[string]interface{}{
+						"fields": []interface{}{"db_password"},
+						"rules":  []interface{}{"mask_field_values_with_field_key_matchers:glob:*password*"},
+					},
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+
+			testCase.logImpl(ctx)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
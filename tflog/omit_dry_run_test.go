@@ -0,0 +1,130 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func TestOmitDryRun(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		logImpl        func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"root": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.OmitLogWithMessageRegexes(ctx, regexp.MustCompile("^Polling"))
+				ctx = tflog.OmitDryRun(ctx)
+
+				tflog.Debug(ctx, "Polling status")
+				tflog.Debug(ctx, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                "debug",
+					"@message":              "Polling status",
+					"@module":               "provider",
+					tflog.WouldOmitFieldKey: "omit_log_with_message_regexes:^Polling",
+				},
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "provider",
+				},
+			},
+		},
+		"root-rules-and-masking": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.OmitDryRun(ctx)
+				ctx = tflog.OmitLogWithRules(ctx, tflog.OmitRuleFieldValues("http_status", 200).BelowLevel(hclog.Info))
+				ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "token")
+
+				tflog.Debug(ctx, "test message", map[string]interface{}{
+					"http_status": 200,
+					"token":       "test-token",
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                "debug",
+					"@message":              "test message",
+					"@module":               "provider",
+					"http_status":           float64(200),
+					"token":                 "***",
+					tflog.WouldOmitFieldKey: "omit_log_with_rules:field_values:http_status (below INFO)",
+				},
+			},
+		},
+		"subsystem": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem)
+				ctx = tflog.SubsystemOmitLogWithFieldKeys(ctx, testSubsystem, "debug_body")
+				ctx = tflog.SubsystemOmitDryRun(ctx, testSubsystem)
+				ctx = tflog.OmitLogWithFieldKeys(ctx, "debug_body")
+
+				tflog.SubsystemDebug(ctx, testSubsystem, "test subsystem message", map[string]interface{}{"debug_body": "test-body"})
+				tflog.Debug(ctx, "test omitted message", map[string]interface{}{"debug_body": "test-body"})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                "debug",
+					"@message":              "test subsystem message",
+					"@module":               testSubsystemModule,
+					"debug_body":            "test-body",
+					tflog.WouldOmitFieldKey: "omit_log_with_field_keys:debug_body",
+				},
+			},
+		},
+		"subsystem-option": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem, tflog.WithOmitDryRun(), tflog.WithOmitLogWithMessageStrings("status"))
+
+				tflog.SubsystemDebug(ctx, testSubsystem, "Polling status")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                "debug",
+					"@message":              "Polling status",
+					"@module":               testSubsystemModule,
+					tflog.WouldOmitFieldKey: "omit_log_with_message_strings:status",
+				},
+			},
+		},
+		"subsystem-inherited": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.OmitLogWithMessageStrings(ctx, "status")
+				ctx = tflog.OmitDryRun(ctx)
+				ctx = tflog.ApplyRulesToSubsystems(ctx)
+				ctx = tflog.NewSubsystem(ctx, testSubsystem)
+
+				tflog.SubsystemDebug(ctx, testSubsystem, "Polling status")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                "debug",
+					"@message":              "Polling status",
+					"@module":               testSubsystemModule,
+					tflog.WouldOmitFieldKey: "omit_log_with_message_strings:status",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			loggertest.CheckOutput(t, loggertest.ProviderRoot, testCase.logImpl, testCase.expectedOutput)
+		})
+	}
+}
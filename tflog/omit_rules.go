@@ -0,0 +1,59 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+// WouldOmitFieldKey is the field key identifying the rule which would omit a
+// log, such as "omit_log_with_message_regexes:^Polling", included by loggers
+// in dry-run mode, enabled with OmitDryRun, SubsystemOmitDryRun or
+// WithOmitDryRun, instead of omitting the log.
+const WouldOmitFieldKey = logging.WouldOmitFieldKey
+
+// OmitLogFunc returns true if a log entry at `level`, with the message `msg`
+// and the fields `fields`, should be omitted. The message and fields are
+// given before masking. The fields must not be modified.
+type OmitLogFunc = logging.OmitLogFunc
+
+// OmitRule is a predicate omitting log entries, for the OmitLogWithRules
+// functions and option. Rules omit entries by field value or with an
+// OmitLogFunc, which allows silencing noisy success paths precisely, such as
+// HTTP responses with a 200 status code.
+//
+// Use the BelowLevel method of a rule to only omit entries below a level,
+// e.g. OmitRuleFieldValues("http_status", 200).BelowLevel(hclog.Info) only
+// omits trace and debug entries with the http_status field equal to 200.
+type OmitRule = logging.OmitRule
+
+// OmitRuleFieldValues returns an OmitRule omitting log entries with the field
+// `key` equal to any of `values`. Values are compared by their default
+// formatting, as with fmt.Sprint, so the value 200 matches field values of any
+// integer type, as well as the string "200".
+func OmitRuleFieldValues(key string, values ...interface{}) OmitRule {
+	return logging.NewOmitRuleFieldValues(key, values...)
+}
+
+// OmitRuleFieldValueRegexes returns an OmitRule omitting log entries with the
+// field `key` matching any of the given *regexp.Regexp. Field values which are
+// not strings are matched by their default formatting, as with fmt.Sprint.
+func OmitRuleFieldValueRegexes(key string, expressions ...*regexp.Regexp) OmitRule {
+	return logging.NewOmitRuleFieldValueRegexes(key, expressions...)
+}
+
+// OmitRuleMessageRegexes returns an OmitRule omitting log entries with a
+// message matching any of the given *regexp.Regexp. Unlike
+// OmitLogWithMessageRegexes, the rule can be limited to entries below a level.
+func OmitRuleMessageRegexes(expressions ...*regexp.Regexp) OmitRule {
+	return logging.NewOmitRuleMessageRegexes(expressions...)
+}
+
+// OmitRuleFunc returns an OmitRule omitting log entries for which `fn`
+// returns true.
+func OmitRuleFunc(fn OmitLogFunc) OmitRule {
+	return logging.NewOmitRuleFunc(fn)
+}
@@ -0,0 +1,156 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog_test
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func TestOmitLogWithRules(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		logImpl        func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"field-values-below-level": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.OmitLogWithRules(ctx, tflog.OmitRuleFieldValues("http_status", 200).BelowLevel(hclog.Info))
+
+				tflog.Debug(ctx, "test omitted message", map[string]interface{}{"http_status": 200})
+				tflog.Debug(ctx, "test message", map[string]interface{}{"http_status": 404})
+				tflog.Info(ctx, "test info message", map[string]interface{}{"http_status": 200})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":      "debug",
+					"@message":    "test message",
+					"@module":     "provider",
+					"http_status": float64(404),
+				},
+				{
+					"@level":      "info",
+					"@message":    "test info message",
+					"@module":     "provider",
+					"http_status": float64(200),
+				},
+			},
+		},
+		"field-value-regexes": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.SetField(ctx, "path", "/health")
+				ctx = tflog.OmitLogWithRules(ctx, tflog.OmitRuleFieldValueRegexes("path", regexp.MustCompile("^/health")))
+
+				tflog.Debug(ctx, "test omitted message")
+				tflog.Debug(ctx, "test message", map[string]interface{}{"path": "/v1"})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "provider",
+					"path":     "/v1",
+				},
+			},
+		},
+		"message-regexes-below-level": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.OmitLogWithRules(ctx, tflog.OmitRuleMessageRegexes(regexp.MustCompile("^Polling")).BelowLevel(hclog.Warn))
+
+				tflog.Info(ctx, "Polling status")
+				tflog.Warn(ctx, "Polling timed out")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "warn",
+					"@message": "Polling timed out",
+					"@module":  "provider",
+				},
+			},
+		},
+		"func": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.OmitLogWithRules(ctx, tflog.OmitRuleFunc(func(level hclog.Level, msg string, fields map[string]interface{}) bool {
+					return level == hclog.Trace && fields["attempt"] != nil
+				}))
+
+				tflog.Trace(ctx, "test omitted message", map[string]interface{}{"attempt": 1})
+				tflog.Debug(ctx, "test message", map[string]interface{}{"attempt": 1})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "provider",
+					"attempt":  float64(1),
+				},
+			},
+		},
+		"subsystem": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem)
+				ctx = tflog.SubsystemOmitLogWithRules(ctx, testSubsystem, tflog.OmitRuleFieldValues("http_status", 200))
+
+				tflog.SubsystemDebug(ctx, testSubsystem, "test omitted message", map[string]interface{}{"http_status": 200})
+				tflog.Debug(ctx, "test message", map[string]interface{}{"http_status": 200})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":      "debug",
+					"@message":    "test message",
+					"@module":     "provider",
+					"http_status": float64(200),
+				},
+			},
+		},
+		"subsystem-option": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem, tflog.WithOmitLogWithRules(tflog.OmitRuleFieldValues("http_status", 200)))
+
+				tflog.SubsystemDebug(ctx, testSubsystem, "test omitted message", map[string]interface{}{"http_status": 200})
+				tflog.SubsystemDebug(ctx, testSubsystem, "test message", map[string]interface{}{"http_status": 500})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":      "debug",
+					"@message":    "test message",
+					"@module":     testSubsystemModule,
+					"http_status": float64(500),
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+
+			testCase.logImpl(ctx)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+const (
+	// FieldOperationName is the field key for the name of an operation
+	// started with StartOperation or SubsystemStartOperation.
+	FieldOperationName = "tf_op"
+
+	// FieldOperationID is the field key for the unique identifier of an
+	// operation.
+	FieldOperationID = "tf_op_id"
+
+	// FieldOperationParentID is the field key for the identifier of the
+	// operation an operation was started in, if any.
+	FieldOperationParentID = "tf_op_parent_id"
+
+	// FieldOperationDurationMs is the field key for the number of
+	// milliseconds between starting an operation and calling its DoneFunc.
+	FieldOperationDurationMs = "tf_op_duration_ms"
+
+	// FieldOperationOutcome is the field key for the outcome of an
+	// operation, either OperationOutcomeSuccess or OperationOutcomeError.
+	FieldOperationOutcome = "tf_op_outcome"
+
+	// FieldOperationError is the field key for the error an operation
+	// finished with.
+	FieldOperationError = "tf_op_error"
+
+	// OperationOutcomeSuccess is the FieldOperationOutcome value of an
+	// operation finished without error.
+	OperationOutcomeSuccess = "success"
+
+	// OperationOutcomeError is the FieldOperationOutcome value of an
+	// operation finished with an error.
+	OperationOutcomeError = "error"
+)
+
+// DoneFunc finishes an operation started with StartOperation or
+// SubsystemStartOperation, writing its duration and outcome as log output.
+// A non-nil `err` marks the operation as failed. Only the first call of a
+// DoneFunc writes log output.
+type DoneFunc func(err error)
+
+// OperationOption configures the log output of an operation started with
+// StartOperation or SubsystemStartOperation.
+type OperationOption func(*operationOpts)
+
+// operationOpts holds the configuration of an operation.
+type operationOpts struct {
+	level      hclog.Level
+	errorLevel hclog.Level
+}
+
+// WithOperationLevel returns an OperationOption that sets the level of the
+// log output of an operation finished without error. The default is
+// hclog.Debug.
+func WithOperationLevel(level hclog.Level) OperationOption {
+	return func(o *operationOpts) {
+		o.level = level
+	}
+}
+
+// WithOperationErrorLevel returns an OperationOption that sets the level of
+// the log output of an operation finished with an error. The default is
+// hclog.Error.
+func WithOperationErrorLevel(level hclog.Level) OperationOption {
+	return func(o *operationOpts) {
+		o.errorLevel = level
+	}
+}
+
+// StartOperation starts timing the operation `name`, e.g. "read_instance",
+// and returns a new context.Context for the operation along with a DoneFunc
+// to call when it finishes. The DoneFunc writes "Operation finished" as log
+// output of the provider root logger, with the duration of the operation, its
+// outcome and its error as fields.
+//
+// The name and a unique identifier of the operation are set as fields of the
+// provider root logger in the returned context.Context, as if set with
+// SetField, so all log output within the operation can be correlated to it.
+// Operations started within another operation, either with StartOperation or
+// SubsystemStartOperation, also include the identifier of the enclosing
+// operation as the tf_op_parent_id field.
+//
+//	ctx, done := tflog.StartOperation(ctx, "read_instance")
+//	instance, err := client.ReadInstance(ctx, id)
+//	done(err)
+func StartOperation(ctx context.Context, name string, options ...OperationOption) (context.Context, DoneFunc) {
+	opts := newOperationOpts(options...)
+	start := time.Now()
+
+	ctx, fields := startOperation(ctx, name)
+
+	for key, value := range fields {
+		ctx = SetField(ctx, key, value)
+	}
+
+	var done atomic.Bool
+
+	// The DoneFunc calls the logger directly, so the location of the log
+	// output is the caller of the DoneFunc.
+	return ctx, func(err error) {
+		if done.Swap(true) {
+			return
+		}
+
+		logger := logging.GetProviderRootLogger(ctx)
+		if logger == nil {
+			// this essentially should never happen in production
+			// the root logger for provider code should be injected
+			// by whatever SDK the provider developer is using, so
+			// really this is only likely in unit tests, at most
+			// so just making this a no-op is fine
+			return
+		}
+
+		level, msg, resultFields := opts.result(start, err)
+
+		additionalArgs, shouldOmit := logging.OmitOrMask(ctx, logger, level, logging.GetProviderRootTFLoggerOpts(ctx), &msg, []map[string]interface{}{resultFields})
+		if shouldOmit {
+			return
+		}
+
+		logger.Log(level, msg, additionalArgs...)
+	}
+}
+
+// SubsystemStartOperation is StartOperation for the subsystem logger
+// specified in `ctx`. The fields of the operation are set as if with
+// SubsystemSetField, and the DoneFunc writes log output of the subsystem
+// logger. As with the package-level subsystem logging functions, the
+// subsystem logger is automatically created if it was not created with
+// NewSubsystem.
+func SubsystemStartOperation(ctx context.Context, subsystem, name string, options ...OperationOption) (context.Context, DoneFunc) {
+	opts := newOperationOpts(options...)
+	start := time.Now()
+
+	ctx, fields := startOperation(ctx, name)
+
+	for key, value := range fields {
+		ctx = SubsystemSetField(ctx, subsystem, key, value)
+	}
+
+	var done atomic.Bool
+
+	// The DoneFunc calls the logger directly, so the location of the log
+	// output is the caller of the DoneFunc.
+	return ctx, func(err error) {
+		if done.Swap(true) {
+			return
+		}
+
+		logger := logging.GetProviderSubsystemLogger(ctx, subsystem)
+		if logger == nil {
+			if logging.GetProviderRootLogger(ctx) == nil {
+				// logging isn't set up, nothing we can do, just silently fail
+				// this should basically never happen in production
+				return
+			}
+			// create a new logger if one doesn't exist
+			logger = autoCreateSubsystemLogger(ctx, subsystem)
+		}
+
+		level, msg, resultFields := opts.result(start, err)
+
+		additionalArgs, shouldOmit := logging.OmitOrMask(ctx, logger, level, logging.GetProviderSubsystemEffectiveTFLoggerOpts(ctx, subsystem), &msg, []map[string]interface{}{resultFields})
+		if shouldOmit {
+			return
+		}
+
+		logger.Log(level, msg, additionalArgs...)
+	}
+}
+
+// newOperationOpts returns the operationOpts with the defaults and `options`
+// applied.
+func newOperationOpts(options ...OperationOption) operationOpts {
+	opts := operationOpts{
+		level:      hclog.Debug,
+		errorLevel: hclog.Error,
+	}
+
+	for _, option := range options {
+		option(&opts)
+	}
+
+	return opts
+}
+
+// startOperation returns a new context.Context with a new operation as the
+// innermost operation, and the fields identifying the operation.
+func startOperation(ctx context.Context, name string) (context.Context, map[string]interface{}) {
+	operationID := logging.NewOperationID()
+
+	fields := map[string]interface{}{
+		FieldOperationName: name,
+		FieldOperationID:   operationID,
+	}
+
+	if parentID := logging.GetOperationID(ctx); parentID != "" {
+		fields[FieldOperationParentID] = parentID
+	}
+
+	return logging.SetOperationID(ctx, operationID), fields
+}
+
+// result returns the level, message and fields of the log output of an
+// operation started at `start` and finished with `err`.
+func (o operationOpts) result(start time.Time, err error) (hclog.Level, string, map[string]interface{}) {
+	fields := map[string]interface{}{
+		FieldOperationDurationMs: time.Since(start).Milliseconds(),
+		FieldOperationOutcome:    OperationOutcomeSuccess,
+	}
+
+	if err == nil {
+		return o.level, "Operation finished", fields
+	}
+
+	fields[FieldOperationOutcome] = OperationOutcomeError
+	fields[FieldOperationError] = err.Error()
+
+	return o.errorLevel, "Operation finished", fields
+}
@@ -0,0 +1,30 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog
+
+import (
+	"errors"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+func ExampleStartOperation() {
+	// virtually no plugin developers will need to worry about
+	// instantiating loggers, as the libraries they're using will take care
+	// of that, but we're not using those libraries in these examples. So
+	// we need to do the injection ourselves. Plugin developers will
+	// basically never need to do this, so the next line can safely be
+	// considered setup for the example and ignored. Instead, use the
+	// context passed in by the framework or library you're using.
+	exampleCtx := getExampleContext()
+
+	// non-example-setup code begins here
+	ctx, done := StartOperation(exampleCtx, "read_instance", WithOperationLevel(hclog.Info))
+
+	// all messages logged with ctx will now have the tf_op and tf_op_id
+	// fields automatically included
+	Trace(ctx, "reading instance")
+
+	done(errors.New("instance not found"))
+}
@@ -0,0 +1,339 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func TestStartOperation(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		logImpl        func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"success": {
+			logImpl: func(ctx context.Context) {
+				ctx, done := tflog.StartOperation(ctx, "read_instance")
+
+				tflog.Trace(ctx, "test message")
+
+				done(nil)
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                 "trace",
+					"@message":               "test message",
+					"@module":                "provider",
+					tflog.FieldOperationName: "read_instance",
+					tflog.FieldOperationID:   "OPERATION_ID_1",
+				},
+				{
+					"@level":                       "debug",
+					"@message":                     "Operation finished",
+					"@module":                      "provider",
+					tflog.FieldOperationName:       "read_instance",
+					tflog.FieldOperationID:         "OPERATION_ID_1",
+					tflog.FieldOperationDurationMs: float64(0),
+					tflog.FieldOperationOutcome:    tflog.OperationOutcomeSuccess,
+				},
+			},
+		},
+		"error": {
+			logImpl: func(ctx context.Context) {
+				_, done := tflog.StartOperation(ctx, "read_instance")
+
+				done(errors.New("test error"))
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                       "error",
+					"@message":                     "Operation finished",
+					"@module":                      "provider",
+					tflog.FieldOperationName:       "read_instance",
+					tflog.FieldOperationID:         "OPERATION_ID_1",
+					tflog.FieldOperationDurationMs: float64(0),
+					tflog.FieldOperationOutcome:    tflog.OperationOutcomeError,
+					tflog.FieldOperationError:      "test error",
+				},
+			},
+		},
+		"levels": {
+			logImpl: func(ctx context.Context) {
+				_, done := tflog.StartOperation(ctx, "read_instance", tflog.WithOperationLevel(hclog.Info))
+				done(nil)
+
+				_, done = tflog.StartOperation(ctx, "update_instance", tflog.WithOperationErrorLevel(hclog.Warn))
+				done(errors.New("test error"))
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                       "info",
+					"@message":                     "Operation finished",
+					"@module":                      "provider",
+					tflog.FieldOperationName:       "read_instance",
+					tflog.FieldOperationID:         "OPERATION_ID_1",
+					tflog.FieldOperationDurationMs: float64(0),
+					tflog.FieldOperationOutcome:    tflog.OperationOutcomeSuccess,
+				},
+				{
+					"@level":                       "warn",
+					"@message":                     "Operation finished",
+					"@module":                      "provider",
+					tflog.FieldOperationName:       "update_instance",
+					tflog.FieldOperationID:         "OPERATION_ID_2",
+					tflog.FieldOperationDurationMs: float64(0),
+					tflog.FieldOperationOutcome:    tflog.OperationOutcomeError,
+					tflog.FieldOperationError:      "test error",
+				},
+			},
+		},
+		"done-multiple-calls": {
+			logImpl: func(ctx context.Context) {
+				_, done := tflog.StartOperation(ctx, "read_instance")
+
+				done(nil)
+				done(errors.New("test error"))
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                       "debug",
+					"@message":                     "Operation finished",
+					"@module":                      "provider",
+					tflog.FieldOperationName:       "read_instance",
+					tflog.FieldOperationID:         "OPERATION_ID_1",
+					tflog.FieldOperationDurationMs: float64(0),
+					tflog.FieldOperationOutcome:    tflog.OperationOutcomeSuccess,
+				},
+			},
+		},
+		"nested": {
+			logImpl: func(ctx context.Context) {
+				ctx, done := tflog.StartOperation(ctx, "read_instance")
+
+				nestedCtx, nestedDone := tflog.StartOperation(ctx, "get_instance")
+				tflog.Trace(nestedCtx, "test message")
+				nestedDone(nil)
+
+				done(nil)
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                     "trace",
+					"@message":                   "test message",
+					"@module":                    "provider",
+					tflog.FieldOperationName:     "get_instance",
+					tflog.FieldOperationID:       "OPERATION_ID_2",
+					tflog.FieldOperationParentID: "OPERATION_ID_1",
+				},
+				{
+					"@level":                       "debug",
+					"@message":                     "Operation finished",
+					"@module":                      "provider",
+					tflog.FieldOperationName:       "get_instance",
+					tflog.FieldOperationID:         "OPERATION_ID_2",
+					tflog.FieldOperationParentID:   "OPERATION_ID_1",
+					tflog.FieldOperationDurationMs: float64(0),
+					tflog.FieldOperationOutcome:    tflog.OperationOutcomeSuccess,
+				},
+				{
+					"@level":                       "debug",
+					"@message":                     "Operation finished",
+					"@module":                      "provider",
+					tflog.FieldOperationName:       "read_instance",
+					tflog.FieldOperationID:         "OPERATION_ID_1",
+					tflog.FieldOperationDurationMs: float64(0),
+					tflog.FieldOperationOutcome:    tflog.OperationOutcomeSuccess,
+				},
+			},
+		},
+		"fields-and-masking": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.SetField(ctx, "instance_id", "i-123")
+				ctx = tflog.MaskAllFieldValuesStrings(ctx, "secret")
+				ctx, done := tflog.StartOperation(ctx, "read_instance")
+
+				done(errors.New("secret error"))
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                       "error",
+					"@message":                     "Operation finished",
+					"@module":                      "provider",
+					"instance_id":                  "i-123",
+					tflog.FieldOperationName:       "read_instance",
+					tflog.FieldOperationID:         "OPERATION_ID_1",
+					tflog.FieldOperationDurationMs: float64(0),
+					tflog.FieldOperationOutcome:    tflog.OperationOutcomeError,
+					tflog.FieldOperationError:      "*** error",
+				},
+			},
+		},
+		"omitted": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.OmitLogWithFieldKeys(ctx, tflog.FieldOperationDurationMs)
+				_, done := tflog.StartOperation(ctx, "read_instance")
+
+				done(nil)
+			},
+			expectedOutput: nil,
+		},
+		"subsystem": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem)
+				ctx, done := tflog.StartOperation(ctx, "read_instance")
+
+				subsystemCtx, subsystemDone := tflog.SubsystemStartOperation(ctx, testSubsystem, "api_call")
+				tflog.SubsystemTrace(subsystemCtx, testSubsystem, "test message")
+				subsystemDone(errors.New("test error"))
+
+				done(nil)
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                     "trace",
+					"@message":                   "test message",
+					"@module":                    testSubsystemModule,
+					tflog.FieldOperationName:     "api_call",
+					tflog.FieldOperationID:       "OPERATION_ID_2",
+					tflog.FieldOperationParentID: "OPERATION_ID_1",
+				},
+				{
+					"@level":                       "error",
+					"@message":                     "Operation finished",
+					"@module":                      testSubsystemModule,
+					tflog.FieldOperationName:       "api_call",
+					tflog.FieldOperationID:         "OPERATION_ID_2",
+					tflog.FieldOperationParentID:   "OPERATION_ID_1",
+					tflog.FieldOperationDurationMs: float64(0),
+					tflog.FieldOperationOutcome:    tflog.OperationOutcomeError,
+					tflog.FieldOperationError:      "test error",
+				},
+				{
+					"@level":                       "debug",
+					"@message":                     "Operation finished",
+					"@module":                      "provider",
+					tflog.FieldOperationName:       "read_instance",
+					tflog.FieldOperationID:         "OPERATION_ID_1",
+					tflog.FieldOperationDurationMs: float64(0),
+					tflog.FieldOperationOutcome:    tflog.OperationOutcomeSuccess,
+				},
+			},
+		},
+		"subsystem-level": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem, tflog.WithLevel(hclog.Warn))
+				_, done := tflog.SubsystemStartOperation(ctx, testSubsystem, "api_call")
+
+				done(nil)
+			},
+			expectedOutput: nil,
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+
+			testCase.logImpl(ctx)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			normalizeOperationEntries(got)
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestStartOperation_Location(t *testing.T) {
+	t.Parallel()
+
+	var outputBuffer bytes.Buffer
+
+	ctx := context.Background()
+	ctx = loggertest.ProviderRootWithLocation(ctx, &outputBuffer)
+
+	_, done := tflog.StartOperation(ctx, "read_instance")
+
+	_, _, line, _ := runtime.Caller(0)
+	done(nil)
+
+	got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+	if err != nil {
+		t.Fatalf("unable to read multiple line JSON: %s", err)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 log entry, got: %v", got)
+	}
+
+	caller, ok := got[0]["@caller"].(string)
+
+	if !ok {
+		t.Fatalf("expected @caller string, got: %v", got[0]["@caller"])
+	}
+
+	if expected := fmt.Sprintf("/tflog/operation_test.go:%d", line+1); !strings.HasSuffix(caller, expected) {
+		t.Errorf("expected @caller with suffix %q, got: %q", expected, caller)
+	}
+}
+
+// normalizeOperationEntries replaces the random operation IDs in `entries`
+// with OPERATION_ID_N, numbered by first appearance, and the durations with 0.
+func normalizeOperationEntries(entries []map[string]interface{}) {
+	operationIDs := make(map[interface{}]string)
+
+	normalizeID := func(entry map[string]interface{}, key string) {
+		id, ok := entry[key]
+
+		if !ok {
+			return
+		}
+
+		if _, ok := operationIDs[id]; !ok {
+			operationIDs[id] = fmt.Sprintf("OPERATION_ID_%d", len(operationIDs)+1)
+		}
+
+		entry[key] = operationIDs[id]
+	}
+
+	// Parent operations are started first, so number them first.
+	for _, entry := range entries {
+		normalizeID(entry, tflog.FieldOperationParentID)
+	}
+
+	for _, entry := range entries {
+		normalizeID(entry, tflog.FieldOperationID)
+
+		if _, ok := entry[tflog.FieldOperationDurationMs]; ok {
+			entry[tflog.FieldOperationDurationMs] = float64(0)
+		}
+	}
+}
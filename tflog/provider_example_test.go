@@ -365,3 +365,29 @@ func ExampleMaskLogStrings() {
 	// Output:
 	// {"@level":"trace","@message":"example log ***","@module":"provider","k1":"*** plus some text","k2":"*** plus more text"}
 }
+
+func ExampleApplyRulesToSubsystems() {
+	// virtually no plugin developers will need to worry about
+	// instantiating loggers, as the libraries they're using will take care
+	// of that, but we're not using those libraries in these examples. So
+	// we need to do the injection ourselves. Plugin developers will
+	// basically never need to do this, so the next line can safely be
+	// considered setup for the example and ignored. Instead, use the
+	// context passed in by the framework or library you're using.
+	exampleCtx := getExampleContext()
+
+	// non-example-setup code begins here
+	exampleCtx = ApplyRulesToSubsystems(exampleCtx)
+	exampleCtx = NewSubsystem(exampleCtx, "my-subsystem")
+	exampleCtx = MaskFieldValuesWithFieldKeys(exampleCtx, "field1")
+
+	// all messages logged with exampleCtx, including the ones of
+	// subsystems, will now have field1=***
+	SubsystemTrace(exampleCtx, "my-subsystem", "example log message", map[string]interface{}{
+		"field1": 123,
+		"field2": 456,
+	})
+
+	// Output:
+	// {"@level":"trace","@message":"example log message","@module":"provider.my-subsystem","field1":"***","field2":456}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -1858,3 +1859,114 @@ func TestMaskLogStrings(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyRulesToSubsystems(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		applyRulesToSubsystems bool
+		subsystemOptions       []logging.Option
+		expectedOutput         []map[string]interface{}
+	}{
+		"disabled": {
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "root message with ***",
+					"@module":  "provider",
+					"k1":       "***",
+				},
+				{
+					"@level":   "trace",
+					"@message": "subsystem message with secret-value",
+					"@module":  testSubsystemModule,
+					"k1":       "v1",
+				},
+				{
+					"@level":   "trace",
+					"@message": "omitted subsystem message",
+					"@module":  testSubsystemModule,
+				},
+			},
+		},
+		"enabled": {
+			applyRulesToSubsystems: true,
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "root message with ***",
+					"@module":  "provider",
+					"k1":       "***",
+				},
+				{
+					"@level":   "trace",
+					"@message": "subsystem message with ***",
+					"@module":  testSubsystemModule,
+					"k1":       "***",
+				},
+			},
+		},
+		"enabled-subsystem-excluded": {
+			applyRulesToSubsystems: true,
+			subsystemOptions: []logging.Option{
+				tflog.WithoutInheritedRules(),
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "root message with ***",
+					"@module":  "provider",
+					"k1":       "***",
+				},
+				{
+					"@level":   "trace",
+					"@message": "subsystem message with secret-value",
+					"@module":  testSubsystemModule,
+					"k1":       "v1",
+				},
+				{
+					"@level":   "trace",
+					"@message": "omitted subsystem message",
+					"@module":  testSubsystemModule,
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+			ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "k1")
+
+			if testCase.applyRulesToSubsystems {
+				ctx = tflog.ApplyRulesToSubsystems(ctx)
+			}
+
+			// Subsystem created before the root logger rules are added
+			ctx = tflog.NewSubsystem(ctx, testSubsystem, testCase.subsystemOptions...)
+
+			ctx = tflog.MaskMessageStrings(ctx, "secret-value")
+			ctx = tflog.OmitLogWithMessageStrings(ctx, "omitted")
+
+			tflog.Trace(ctx, "root message with secret-value", map[string]interface{}{"k1": "v1"})
+			tflog.SubsystemTrace(ctx, testSubsystem, "subsystem message with secret-value", map[string]interface{}{"k1": "v1"})
+			tflog.SubsystemTrace(ctx, testSubsystem, "omitted subsystem message")
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
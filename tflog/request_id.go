@@ -0,0 +1,18 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+// RequestID returns the correlation ID of the request, which the SDK includes
+// as the tf_req_id field in all log output, e.g. to pass it on to outgoing API
+// calls. If `ctx` does not contain a correlation ID, it returns an empty
+// string.
+func RequestID(ctx context.Context) string {
+	return logging.GetRequestID(ctx)
+}
@@ -0,0 +1,51 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+// CommonSecretsVersion is the latest version of the common secrets rule set
+// installed by MaskCommonSecrets. Released versions of the rule set never
+// change, so pinning a version with MaskCommonSecretsVersion ensures masking
+// is not extended by upgrading this module.
+const CommonSecretsVersion = logging.CommonSecretsLatestVersion
+
+// MaskCommonSecrets returns a new context.Context that has a modified logger
+// that masks (replaces) with asterisks (`***`) all message and field value
+// substrings matching the latest version of the common secrets rule set, as
+// if calling MaskLogRegexes with its regular expressions.
+//
+// The rule set covers common credential formats, such as AWS access keys,
+// bearer tokens and JWTs, PEM encoded private keys, URLs with basic
+// authentication credentials, and GitHub, GitLab, Azure, Google, Slack and
+// HCP Terraform tokens.
+//
+// Each call to this function is additive:
+// the regexp to mask by are added to the existing configuration.
+func MaskCommonSecrets(ctx context.Context) context.Context {
+	return MaskCommonSecretsVersion(ctx, CommonSecretsVersion)
+}
+
+// MaskCommonSecretsVersion is MaskCommonSecrets with `version` of the common
+// secrets rule set. Versions newer than CommonSecretsVersion, which are
+// unknown to this module, install the latest version instead.
+func MaskCommonSecretsVersion(ctx context.Context, version int) context.Context {
+	return MaskLogRegexes(ctx, logging.CommonSecretsRegexes(version)...)
+}
+
+// SubsystemMaskCommonSecrets is MaskCommonSecrets for the subsystem logger
+// specified in `ctx`.
+func SubsystemMaskCommonSecrets(ctx context.Context, subsystem string) context.Context {
+	return SubsystemMaskCommonSecretsVersion(ctx, subsystem, CommonSecretsVersion)
+}
+
+// SubsystemMaskCommonSecretsVersion is MaskCommonSecretsVersion for the
+// subsystem logger specified in `ctx`.
+func SubsystemMaskCommonSecretsVersion(ctx context.Context, subsystem string, version int) context.Context {
+	return SubsystemMaskLogRegexes(ctx, subsystem, logging.CommonSecretsRegexes(version)...)
+}
@@ -0,0 +1,23 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog
+
+import (
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+// SensitiveValue wraps a value which is always rendered as the mask
+// replacement string "***" in log output, regardless of the field key it is
+// logged with, its nesting depth in maps and slices of field values, or the
+// mask rules of the logger. Its String, GoString and MarshalJSON methods and
+// all fmt verbs also render "***", so the wrapped value cannot leak into log
+// messages built with fmt.Sprintf.
+type SensitiveValue = logging.SensitiveValue
+
+// Sensitive returns a SensitiveValue wrapping `value`, for values which must
+// never appear in log output, without having to configure mask rules for
+// their field keys or patterns ahead of time.
+func Sensitive(value interface{}) SensitiveValue {
+	return logging.NewSensitiveValue(value)
+}
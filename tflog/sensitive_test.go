@@ -0,0 +1,160 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func TestSensitive(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		logImpl        func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"field": {
+			logImpl: func(ctx context.Context) {
+				tflog.Debug(ctx, "test message", map[string]interface{}{
+					"any_key": tflog.Sensitive("secret-value"),
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "provider",
+					"any_key":  "***",
+				},
+			},
+		},
+		"nested-field": {
+			logImpl: func(ctx context.Context) {
+				tflog.Debug(ctx, "test message", map[string]interface{}{
+					"config": map[string]interface{}{
+						"credentials": []interface{}{
+							map[string]interface{}{
+								"name":  "test-name",
+								"value": tflog.Sensitive("secret-value"),
+							},
+						},
+					},
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "provider",
+					"config": map[string]interface{}{
+						"credentials": []interface{}{
+							map[string]interface{}{
+								"name":  "test-name",
+								"value": "***",
+							},
+						},
+					},
+				},
+			},
+		},
+		"struct-field": {
+			logImpl: func(ctx context.Context) {
+				tflog.Debug(ctx, "test message", map[string]interface{}{
+					"config": struct {
+						Password tflog.SensitiveValue
+					}{
+						Password: tflog.Sensitive("secret-value"),
+					},
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "provider",
+					"config":   map[string]interface{}{"Password": "***"},
+				},
+			},
+		},
+		"set-field": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.SetField(ctx, "password", tflog.Sensitive("secret-value"))
+
+				tflog.Debug(ctx, "test message")
+				tflog.Debug(ctx, "another test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "provider",
+					"password": "***",
+				},
+				{
+					"@level":   "debug",
+					"@message": "another test message",
+					"@module":  "provider",
+					"password": "***",
+				},
+			},
+		},
+		"message": {
+			logImpl: func(ctx context.Context) {
+				tflog.Debug(ctx, fmt.Sprintf("test message with %s and %v", tflog.Sensitive("secret-value"), tflog.Sensitive(123)))
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message with *** and ***",
+					"@module":  "provider",
+				},
+			},
+		},
+		"hclog-adapter-with": {
+			logImpl: func(ctx context.Context) {
+				tflog.HCLogger(ctx).With("password", tflog.Sensitive("secret-value")).Debug("test message", "nested", map[string]interface{}{"token": tflog.Sensitive("secret-value")})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "provider",
+					"nested":   map[string]interface{}{"token": "***"},
+					"password": "***",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+
+			testCase.logImpl(ctx)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog
+
+import (
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+// StatsCollector counts the log entries emitted, omitted and masked by the
+// loggers configured with it, by logger name, subsystem and level. This helps
+// finding which loggers write the most log entries, and whether omit and mask
+// rules apply as expected.
+//
+// A StatsCollector is configured for a subsystem logger with the WithStats
+// option of NewSubsystem, and can be configured for the provider root logger
+// by the SDK creating it. Subsystem loggers use the StatsCollector of the
+// provider root logger, unless configured with their own.
+//
+// Entries are counted once the logger would write them at its level, so
+// entries below the level of their logger are never counted. Counting is
+// performed without locking, and counts are kept until Reset is called.
+// Snapshot returns a copy of the counts.
+type StatsCollector = logging.StatsCollector
+
+// StatsKey identifies the log entries counted together by a StatsCollector:
+// the name of the logger writing them, its subsystem, if any, and their level.
+type StatsKey = logging.StatsKey
+
+// StatsCounts are the numbers of emitted, omitted and masked log entries for a
+// StatsKey, including the numbers of omitted log entries by the identifier of
+// the rule omitting them, such as "omit_log_with_field_keys:foo".
+type StatsCounts = logging.StatsCounts
+
+// NewStatsCollector returns a new StatsCollector, to configure subsystem
+// loggers with using WithStats.
+func NewStatsCollector() *StatsCollector {
+	return logging.NewStatsCollector()
+}
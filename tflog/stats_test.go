@@ -0,0 +1,102 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+func TestWithStats(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		log      func(context.Context, *tflog.StatsCollector)
+		expected map[tflog.StatsKey]tflog.StatsCounts
+	}{
+		"no-logs": {
+			log:      func(_ context.Context, _ *tflog.StatsCollector) {},
+			expected: map[tflog.StatsKey]tflog.StatsCounts{},
+		},
+		"subsystem": {
+			log: func(ctx context.Context, collector *tflog.StatsCollector) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem, tflog.WithStats(collector), tflog.WithLevel(hclog.Debug))
+
+				tflog.SubsystemTrace(ctx, testSubsystem, "test message below level")
+				tflog.SubsystemDebug(ctx, testSubsystem, "test message")
+				tflog.SubsystemWarn(ctx, testSubsystem, "test message")
+				tflog.Warn(ctx, "test root message")
+			},
+			expected: map[tflog.StatsKey]tflog.StatsCounts{
+				{LoggerName: testSubsystemModule, Subsystem: testSubsystem, Level: hclog.Debug}: {Emitted: 1},
+				{LoggerName: testSubsystemModule, Subsystem: testSubsystem, Level: hclog.Warn}:  {Emitted: 1},
+			},
+		},
+		"subsystem-omitted-and-masked": {
+			log: func(ctx context.Context, collector *tflog.StatsCollector) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem, tflog.WithStats(collector))
+				ctx = tflog.SubsystemOmitLogWithMessageStrings(ctx, testSubsystem, "omitted")
+				ctx = tflog.SubsystemMaskMessageStrings(ctx, testSubsystem, "secret")
+
+				tflog.SubsystemDebug(ctx, testSubsystem, "test omitted message")
+				tflog.SubsystemDebug(ctx, testSubsystem, "test secret message")
+			},
+			expected: map[tflog.StatsKey]tflog.StatsCounts{
+				{LoggerName: testSubsystemModule, Subsystem: testSubsystem, Level: hclog.Debug}: {
+					Emitted:       1,
+					Omitted:       1,
+					OmittedByRule: map[string]uint64{"omit_log_with_message_strings:omitted": 1},
+					Masked:        1,
+				},
+			},
+		},
+		"provider-root": {
+			log: func(_ context.Context, collector *tflog.StatsCollector) {
+				var outputBuffer bytes.Buffer
+
+				ctx := tfsdklog.NewRootProviderLogger(
+					context.Background(),
+					logging.WithOutput(&outputBuffer),
+					tfsdklog.WithStats(collector),
+				)
+				ctx = tflog.NewSubsystem(ctx, testSubsystem)
+
+				tflog.Info(ctx, "test message")
+				tflog.SubsystemInfo(ctx, testSubsystem, "test message")
+			},
+			expected: map[tflog.StatsKey]tflog.StatsCounts{
+				{LoggerName: "provider", Level: hclog.Info}:                                    {Emitted: 1},
+				{LoggerName: testSubsystemModule, Subsystem: testSubsystem, Level: hclog.Info}: {Emitted: 1},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			collector := tflog.NewStatsCollector()
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+
+			testCase.log(ctx, collector)
+
+			if diff := cmp.Diff(testCase.expected, collector.Snapshot()); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -6,8 +6,10 @@ package tflog
 import (
 	"context"
 	"regexp"
+	"strings"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/fieldutils"
 	"github.com/hashicorp/terraform-plugin-log/internal/hclogutils"
 	"github.com/hashicorp/terraform-plugin-log/internal/logging"
 )
@@ -21,8 +23,17 @@ import (
 // concerns that are sometimes helpful to log, but may generate unwanted noise
 // at other times.
 //
-// The only Options supported for subsystems are the Options for setting the
-// level and additional location offset of the logger.
+// Subsystems can be nested by separating names with a period. For example,
+// the "service.resource" subsystem logger is created from the "service"
+// subsystem logger, if it exists in `ctx`, and is named
+// `provider.service.resource`. A nested subsystem logger inherits the
+// level, location, timestamp and output configuration, as well as the fields
+// and omit and mask rules, of its parent subsystem logger. Otherwise, a
+// subsystem logger inherits the level, location, timestamp and output
+// configuration of the root logger.
+//
+// Options can override the inherited configuration, set the name of the
+// logger, or add fields and omit and mask rules to the subsystem logger.
 func NewSubsystem(ctx context.Context, subsystem string, options ...logging.Option) context.Context {
 	logger := logging.GetProviderRootLogger(ctx)
 
@@ -35,49 +46,138 @@ func NewSubsystem(ctx context.Context, subsystem string, options ...logging.Opti
 		return ctx
 	}
 
-	rootLoggerOptions := logging.GetProviderRootLoggerOptions(ctx)
-	subLoggerTFLoggerOpts := logging.ApplyLoggerOpts(options...)
+	parentLoggerOptions := logging.GetProviderRootLoggerOptions(ctx)
+	parentTFLoggerOpts := logging.LoggerOpts{}
+	subLoggerName := subsystem
 
-	// If root logger options are not available,
+	// Nested subsystems are created from their closest existing parent
+	// subsystem logger, falling back to the root logger.
+	for _, parent := range logging.ParentSubsystems(subsystem) {
+		parentLogger := logging.GetProviderSubsystemLogger(ctx, parent)
+
+		if parentLogger == nil {
+			continue
+		}
+
+		logger = parentLogger
+		parentLoggerOptions = logging.GetProviderSubsystemLoggerOptions(ctx, parent)
+		parentTFLoggerOpts = logging.GetProviderSubsystemTFLoggerOpts(ctx, parent)
+		subLoggerName = strings.TrimPrefix(subsystem, parent+".")
+
+		break
+	}
+
+	subLoggerTFLoggerOpts := logging.ApplySubsystemLoggerOpts(parentLoggerOptions, parentTFLoggerOpts, options...)
+	subLoggerTFLoggerOpts.Subsystem = subsystem
+
+	// If parent logger options are not available,
 	// fallback to creating a logger named like the given subsystem.
-	// This will preserve the root logger options,
-	// but cannot make changes beyond setting the level
+	// This will preserve the parent logger options,
+	// but cannot make changes beyond setting the level and name
 	// due to limitations with the hclog.Logger interface.
 	var subLogger hclog.Logger
-	if rootLoggerOptions == nil {
-		subLogger = logger.Named(subsystem)
+	var subLoggerOptions *hclog.LoggerOptions
+	if parentLoggerOptions == nil {
+		if subLoggerTFLoggerOpts.Name != "" {
+			subLogger = logger.ResetNamed(subLoggerTFLoggerOpts.Name)
+		} else {
+			subLogger = logger.Named(subLoggerName)
+		}
 
 		if subLoggerTFLoggerOpts.AdditionalLocationOffset != 1 {
 			logger.Warn("Unable to create logging subsystem with AdditionalLocationOffset due to missing root logger options")
 		}
+
+		// Set the configured log level
+		if subLoggerTFLoggerOpts.Level != hclog.NoLevel {
+			subLogger.SetLevel(subLoggerTFLoggerOpts.Level)
+		}
 	} else {
-		subLoggerOptions := hclogutils.LoggerOptionsCopy(rootLoggerOptions)
-		subLoggerOptions.Name = subLoggerOptions.Name + "." + subsystem
+		subLoggerOptions = hclogutils.LoggerOptionsCopy(parentLoggerOptions)
+		subLoggerOptions.Name = subLoggerOptions.Name + "." + subLoggerName
+		subLoggerOptions.IncludeLocation = subLoggerTFLoggerOpts.IncludeLocation
+		subLoggerOptions.DisableTime = !subLoggerTFLoggerOpts.IncludeTime
+		subLoggerOptions.Output = subLoggerTFLoggerOpts.Output
+
+		if subLoggerTFLoggerOpts.Name != "" {
+			subLoggerOptions.Name = subLoggerTFLoggerOpts.Name
+		}
 
 		if subLoggerTFLoggerOpts.AdditionalLocationOffset != 1 {
 			subLoggerOptions.AdditionalLocationOffset = subLoggerTFLoggerOpts.AdditionalLocationOffset
 		}
 
-		subLogger = hclog.New(subLoggerOptions)
-	}
+		// Set the configured log level
+		if subLoggerTFLoggerOpts.Level != hclog.NoLevel {
+			subLoggerOptions.Level = subLoggerTFLoggerOpts.Level
+		}
 
-	// Set the configured log level
-	if subLoggerTFLoggerOpts.Level != hclog.NoLevel {
-		subLogger.SetLevel(subLoggerTFLoggerOpts.Level)
+		subLogger = hclog.New(subLoggerOptions)
 	}
 
-	// Propagate root fields to the subsystem logger
+	// Propagate root fields to the subsystem logger, without overriding
+	// the fields of the subsystem logger itself
 	if subLoggerTFLoggerOpts.IncludeRootFields {
 		loggerTFOpts := logging.GetProviderRootTFLoggerOpts(ctx)
-		subLoggerTFLoggerOpts = logging.WithFields(loggerTFOpts.Fields)(subLoggerTFLoggerOpts)
+		subLoggerTFLoggerOpts.Fields = fieldutils.MergeFieldMaps(loggerTFOpts.Fields, subLoggerTFLoggerOpts.Fields)
+	}
+
+	// Track the subsystem in the registry of the root logger
+	if registry := logging.GetProviderSubsystemRegistry(ctx); registry != nil {
+		registry.Register(subsystem)
 	}
 
+	// Cache the log entry counters of the subsystem logger, if any
+	subLoggerTFLoggerOpts = subLoggerTFLoggerOpts.WithStatsLogger(subLogger.Name(), logging.GetProviderRootTFLoggerOpts(ctx))
+
 	// Set the subsystem LoggerOpts in the context
 	ctx = logging.SetProviderSubsystemTFLoggerOpts(ctx, subsystem, subLoggerTFLoggerOpts)
+	ctx = logging.SetProviderSubsystemLoggerOptions(ctx, subsystem, subLoggerOptions)
 
 	return logging.SetProviderSubsystemLogger(ctx, subsystem, subLogger)
 }
 
+// HasSubsystem returns true if `ctx` contains the subsystem logger created with
+// NewSubsystem. This is useful for libraries which should only create a
+// subsystem logger with their defaults if the caller did not already create
+// it with its own options.
+func HasSubsystem(ctx context.Context, subsystem string) bool {
+	return logging.GetProviderSubsystemLogger(ctx, subsystem) != nil
+}
+
+// Subsystems returns the sorted names of all subsystem loggers created for the
+// provider root logger in `ctx`, including the ones automatically created
+// because they were used before being created with NewSubsystem.
+//
+// Subsystems are tracked per root logger, so subsystems created with any
+// context.Context derived from the one containing the root logger are
+// included.
+func Subsystems(ctx context.Context) []string {
+	return logging.GetProviderSubsystemRegistry(ctx).Names()
+}
+
+// AutoCreatedSubsystems returns the sorted names of all subsystem loggers of
+// the provider root logger in `ctx` which were automatically created,
+// because they were used before being created with NewSubsystem. Log output
+// of those subsystem loggers includes a `new_logger_warning` field.
+//
+// This is useful for tests to assert that all subsystems are properly
+// created before being used.
+func AutoCreatedSubsystems(ctx context.Context) []string {
+	return logging.GetProviderSubsystemRegistry(ctx).AutoCreatedNames()
+}
+
+// autoCreateSubsystemLogger creates and returns the logger for a subsystem
+// that is used before being created with NewSubsystem, recording it in the
+// registry of the root logger.
+func autoCreateSubsystemLogger(ctx context.Context, subsystem string) hclog.Logger {
+	if registry := logging.GetProviderSubsystemRegistry(ctx); registry != nil {
+		registry.RegisterAutoCreated(subsystem)
+	}
+
+	return logging.GetProviderSubsystemLogger(NewSubsystem(ctx, subsystem), subsystem).With("new_logger_warning", logging.NewProviderSubsystemLoggerWarning)
+}
+
 // SubsystemSetField returns a new context.Context that has a modified logger for
 // the specified subsystem in it which will include key and value as fields
 // in all its log output.
@@ -108,10 +208,10 @@ func SubsystemTrace(ctx context.Context, subsystem, msg string, additionalFields
 			return
 		}
 		// create a new logger if one doesn't exist
-		logger = logging.GetProviderSubsystemLogger(NewSubsystem(ctx, subsystem), subsystem).With("new_logger_warning", logging.NewProviderSubsystemLoggerWarning)
+		logger = autoCreateSubsystemLogger(ctx, subsystem)
 	}
 
-	additionalArgs, shouldOmit := logging.OmitOrMask(logging.GetProviderSubsystemTFLoggerOpts(ctx, subsystem), &msg, additionalFields)
+	additionalArgs, shouldOmit := logging.OmitOrMask(ctx, logger, hclog.Trace, logging.GetProviderSubsystemEffectiveTFLoggerOpts(ctx, subsystem), &msg, additionalFields)
 	if shouldOmit {
 		return
 	}
@@ -133,10 +233,10 @@ func SubsystemDebug(ctx context.Context, subsystem, msg string, additionalFields
 			return
 		}
 		// create a new logger if one doesn't exist
-		logger = logging.GetProviderSubsystemLogger(NewSubsystem(ctx, subsystem), subsystem).With("new_logger_warning", logging.NewProviderSubsystemLoggerWarning)
+		logger = autoCreateSubsystemLogger(ctx, subsystem)
 	}
 
-	additionalArgs, shouldOmit := logging.OmitOrMask(logging.GetProviderSubsystemTFLoggerOpts(ctx, subsystem), &msg, additionalFields)
+	additionalArgs, shouldOmit := logging.OmitOrMask(ctx, logger, hclog.Debug, logging.GetProviderSubsystemEffectiveTFLoggerOpts(ctx, subsystem), &msg, additionalFields)
 	if shouldOmit {
 		return
 	}
@@ -158,10 +258,10 @@ func SubsystemInfo(ctx context.Context, subsystem, msg string, additionalFields
 			return
 		}
 		// create a new logger if one doesn't exist
-		logger = logging.GetProviderSubsystemLogger(NewSubsystem(ctx, subsystem), subsystem).With("new_logger_warning", logging.NewProviderSubsystemLoggerWarning)
+		logger = autoCreateSubsystemLogger(ctx, subsystem)
 	}
 
-	additionalArgs, shouldOmit := logging.OmitOrMask(logging.GetProviderSubsystemTFLoggerOpts(ctx, subsystem), &msg, additionalFields)
+	additionalArgs, shouldOmit := logging.OmitOrMask(ctx, logger, hclog.Info, logging.GetProviderSubsystemEffectiveTFLoggerOpts(ctx, subsystem), &msg, additionalFields)
 	if shouldOmit {
 		return
 	}
@@ -183,10 +283,10 @@ func SubsystemWarn(ctx context.Context, subsystem, msg string, additionalFields
 			return
 		}
 		// create a new logger if one doesn't exist
-		logger = logging.GetProviderSubsystemLogger(NewSubsystem(ctx, subsystem), subsystem).With("new_logger_warning", logging.NewProviderSubsystemLoggerWarning)
+		logger = autoCreateSubsystemLogger(ctx, subsystem)
 	}
 
-	additionalArgs, shouldOmit := logging.OmitOrMask(logging.GetProviderSubsystemTFLoggerOpts(ctx, subsystem), &msg, additionalFields)
+	additionalArgs, shouldOmit := logging.OmitOrMask(ctx, logger, hclog.Warn, logging.GetProviderSubsystemEffectiveTFLoggerOpts(ctx, subsystem), &msg, additionalFields)
 	if shouldOmit {
 		return
 	}
@@ -208,10 +308,10 @@ func SubsystemError(ctx context.Context, subsystem, msg string, additionalFields
 			return
 		}
 		// create a new logger if one doesn't exist
-		logger = logging.GetProviderSubsystemLogger(NewSubsystem(ctx, subsystem), subsystem).With("new_logger_warning", logging.NewProviderSubsystemLoggerWarning)
+		logger = autoCreateSubsystemLogger(ctx, subsystem)
 	}
 
-	additionalArgs, shouldOmit := logging.OmitOrMask(logging.GetProviderSubsystemTFLoggerOpts(ctx, subsystem), &msg, additionalFields)
+	additionalArgs, shouldOmit := logging.OmitOrMask(ctx, logger, hclog.Error, logging.GetProviderSubsystemEffectiveTFLoggerOpts(ctx, subsystem), &msg, additionalFields)
 	if shouldOmit {
 		return
 	}
@@ -243,6 +343,30 @@ func SubsystemOmitLogWithFieldKeys(ctx context.Context, subsystem string, keys .
 	return logging.SetProviderSubsystemTFLoggerOpts(ctx, subsystem, lOpts)
 }
 
+// SubsystemOmitLogWithFieldKeyMatchers returns a new context.Context that
+// has a modified logger for the specified subsystem that will omit to write
+// any log when any key within its fields matches any of the given
+// FieldKeyMatcher.
+//
+// Each call to this function is additive:
+// the matchers to omit by are added to the existing configuration.
+//
+// Example:
+//
+//	configuration = `[FieldKeyPrefix("debug_")]`
+//
+//	log1 = `{ msg = "...", fields = { 'debug_body': '...', 'bar': '...' }`  -> omitted
+//	log2 = `{ msg = "...", fields = { 'bar': '...' }`                       -> printed
+func SubsystemOmitLogWithFieldKeyMatchers(ctx context.Context, subsystem string, matchers ...FieldKeyMatcher) context.Context {
+	lOpts := logging.GetProviderSubsystemTFLoggerOpts(ctx, subsystem)
+
+	// Copy to prevent slice/map aliasing issues.
+	// Reference: https://github.com/hashicorp/terraform-plugin-log/issues/131
+	lOpts = logging.WithOmitLogWithFieldKeyMatchers(matchers...)(lOpts.Copy())
+
+	return logging.SetProviderSubsystemTFLoggerOpts(ctx, subsystem, lOpts)
+}
+
 // SubsystemOmitLogWithMessageRegexes returns a new context.Context that has a modified logger
 // that will omit to write any log that has a message matching any of the
 // given *regexp.Regexp.
@@ -290,6 +414,45 @@ func SubsystemOmitLogWithMessageStrings(ctx context.Context, subsystem string, m
 	return logging.SetProviderSubsystemTFLoggerOpts(ctx, subsystem, lOpts)
 }
 
+// SubsystemOmitLogWithRules returns a new context.Context that has a modified
+// logger for the specified subsystem that will omit to write any log that any
+// of the given OmitRule omits, such as a log with a field equal to a value,
+// optionally only below a level.
+//
+// Each call to this function is additive:
+// the rules to omit by are added to the existing configuration.
+//
+// Example:
+//
+//	configuration = `[OmitRuleFieldValues("http_status", 200).BelowLevel(hclog.Info)]`
+//
+//	log1 = `{ level = "debug", msg = "...", fields = { 'http_status': 200 }`  -> omitted
+//	log2 = `{ level = "debug", msg = "...", fields = { 'http_status': 404 }`  -> printed
+//	log3 = `{ level = "info", msg = "...", fields = { 'http_status': 200 }`   -> printed
+func SubsystemOmitLogWithRules(ctx context.Context, subsystem string, rules ...OmitRule) context.Context {
+	lOpts := logging.GetProviderSubsystemTFLoggerOpts(ctx, subsystem)
+
+	// Copy to prevent slice/map aliasing issues.
+	// Reference: https://github.com/hashicorp/terraform-plugin-log/issues/131
+	lOpts = logging.WithOmitLogWithRules(rules...)(lOpts.Copy())
+
+	return logging.SetProviderSubsystemTFLoggerOpts(ctx, subsystem, lOpts)
+}
+
+// SubsystemOmitDryRun returns a new context.Context that has a modified
+// logger for the given subsystem, which writes the logs its omit rules would
+// omit rather than omitting them. Each of those logs is tagged with the
+// WouldOmitFieldKey field, identifying the rule which would omit it.
+func SubsystemOmitDryRun(ctx context.Context, subsystem string) context.Context {
+	lOpts := logging.GetProviderSubsystemTFLoggerOpts(ctx, subsystem)
+
+	// Copy to prevent slice/map aliasing issues.
+	// Reference: https://github.com/hashicorp/terraform-plugin-log/issues/131
+	lOpts = logging.WithOmitDryRun()(lOpts.Copy())
+
+	return logging.SetProviderSubsystemTFLoggerOpts(ctx, subsystem, lOpts)
+}
+
 // SubsystemMaskFieldValuesWithFieldKeys returns a new context.Context that has a modified logger
 // that masks (replaces) with asterisks (`***`) any argument value where the
 // key matches one of the given keys.
@@ -314,11 +477,61 @@ func SubsystemMaskFieldValuesWithFieldKeys(ctx context.Context, subsystem string
 	return logging.SetProviderSubsystemTFLoggerOpts(ctx, subsystem, lOpts)
 }
 
+// SubsystemMaskFieldValuesWithFieldKeyMatchers returns a new context.Context
+// that has a modified logger for the specified subsystem that masks
+// (replaces) with asterisks (`***`) any field value where the key matches any
+// of the given FieldKeyMatcher.
+//
+// Each call to this function is additive:
+// the matchers to mask by are added to the existing configuration.
+//
+// Example:
+//
+//	configuration = `[FieldKeyGlob("*password*").CaseInsensitive()]`
+//
+//	log1 = `{ msg = "...", fields = { 'Password': '***', 'bar': '...' }`  -> masked value
+//	log2 = `{ msg = "...", fields = { 'bar': '...' }`                     -> as-is value
+//	log3 = `{ msg = "...", fields = { 'db_password': '***' }`             -> masked value
+func SubsystemMaskFieldValuesWithFieldKeyMatchers(ctx context.Context, subsystem string, matchers ...FieldKeyMatcher) context.Context {
+	lOpts := logging.GetProviderSubsystemTFLoggerOpts(ctx, subsystem)
+
+	// Copy to prevent slice/map aliasing issues.
+	// Reference: https://github.com/hashicorp/terraform-plugin-log/issues/131
+	lOpts = logging.WithMaskFieldValuesWithFieldKeyMatchers(matchers...)(lOpts.Copy())
+
+	return logging.SetProviderSubsystemTFLoggerOpts(ctx, subsystem, lOpts)
+}
+
+// SubsystemMaskFieldValuesWithFunc returns a new context.Context that has a
+// modified logger for the given subsystem that replaces the value of any field
+// with one of the given keys, or of all fields when no keys are given, with
+// the value returned by `fn`. Field values of any type, not only strings, are
+// given to `fn`, which must not modify them, but return a new value instead.
+//
+// Functions run after the masking by field key and before the masking by
+// regexp and strings.
+//
+// Each call to this function is additive:
+// the function is added to the existing configuration, running after the
+// functions added before.
+func SubsystemMaskFieldValuesWithFunc(ctx context.Context, subsystem string, keys []string, fn func(interface{}) interface{}) context.Context {
+	lOpts := logging.GetProviderSubsystemTFLoggerOpts(ctx, subsystem)
+
+	// Copy to prevent slice/map aliasing issues.
+	// Reference: https://github.com/hashicorp/terraform-plugin-log/issues/131
+	lOpts = logging.WithMaskFieldValuesWithFunc(keys, fn)(lOpts.Copy())
+
+	return logging.SetProviderSubsystemTFLoggerOpts(ctx, subsystem, lOpts)
+}
+
 // SubsystemMaskAllFieldValuesRegexes returns a new context.Context that has a modified logger
 // that masks (replaces) with asterisks (`***`) all field value substrings,
 // matching one of the given *regexp.Regexp.
 //
-// Note that the replacement will happen, only for field values that are of type string.
+// Note that the replacement will happen, only for field values that are strings,
+// or that are rendered as text: []byte, error, fmt.Stringer and numbers. Those
+// are replaced with their masked text, while values without any replacement
+// keep their type.
 //
 // Each call to this function is additive:
 // the regexp to mask by are added to the existing configuration.
@@ -344,11 +557,17 @@ func SubsystemMaskAllFieldValuesRegexes(ctx context.Context, subsystem string, e
 // that masks (replaces) with asterisks (`***`) all field value substrings,
 // equal to one of the given strings.
 //
-// Note that the replacement will happen, only for field values that are of type string.
+// Note that the replacement will happen, only for field values that are strings,
+// or that are rendered as text: []byte, error, fmt.Stringer and numbers. Those
+// are replaced with their masked text, while values without any replacement
+// keep their type.
 //
 // Each call to this function is additive:
 // the regexp to mask by are added to the existing configuration.
 //
+// Where the strings overlap, the longest one is masked first, such as
+// `secret-token` rather than only `secret` within it.
+//
 // Example:
 //
 //	configuration = `[regexp.MustCompile("(foo|bar)")]`
@@ -397,6 +616,9 @@ func SubsystemMaskMessageRegexes(ctx context.Context, subsystem string, expressi
 // Each call to this function is additive:
 // the string to mask by are added to the existing configuration.
 //
+// Where the strings overlap, the longest one is masked first, such as
+// `secret-token` rather than only `secret` within it.
+//
 // Example:
 //
 //	configuration = `['foo', 'bar']`
@@ -414,6 +636,24 @@ func SubsystemMaskMessageStrings(ctx context.Context, subsystem string, matching
 	return logging.SetProviderSubsystemTFLoggerOpts(ctx, subsystem, lOpts)
 }
 
+// SubsystemMaskMessageWithFunc returns a new context.Context that has a
+// modified logger for the given subsystem that replaces the log message with
+// the one returned by `fn`. Functions run before the masking by regexp and
+// strings.
+//
+// Each call to this function is additive:
+// the function is added to the existing configuration, running after the
+// functions added before.
+func SubsystemMaskMessageWithFunc(ctx context.Context, subsystem string, fn func(string) string) context.Context {
+	lOpts := logging.GetProviderSubsystemTFLoggerOpts(ctx, subsystem)
+
+	// Copy to prevent slice/map aliasing issues.
+	// Reference: https://github.com/hashicorp/terraform-plugin-log/issues/131
+	lOpts = logging.WithMaskMessageWithFunc(fn)(lOpts.Copy())
+
+	return logging.SetProviderSubsystemTFLoggerOpts(ctx, subsystem, lOpts)
+}
+
 // SubsystemMaskLogRegexes is a shortcut to invoke SubsystemMaskMessageRegexes and SubsystemMaskAllFieldValuesRegexes using the same input.
 // Refer to those functions for details.
 func SubsystemMaskLogRegexes(ctx context.Context, subsystem string, expressions ...*regexp.Regexp) context.Context {
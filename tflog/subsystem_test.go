@@ -10,7 +10,9 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -1886,3 +1888,423 @@ func TestSubsystemMaskLogStrings(t *testing.T) {
 		})
 	}
 }
+
+func TestSubsystems(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		setup    func(context.Context) context.Context
+		expected []string
+	}{
+		"no-root-logger": {
+			setup: func(_ context.Context) context.Context {
+				return context.Background()
+			},
+			expected: nil,
+		},
+		"no-subsystems": {
+			setup: func(ctx context.Context) context.Context {
+				return ctx
+			},
+			expected: []string{},
+		},
+		"subsystems": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tflog.NewSubsystem(ctx, "sub2")
+				ctx = tflog.NewSubsystem(ctx, "sub1")
+				ctx = tflog.NewSubsystem(ctx, "sub1")
+
+				return ctx
+			},
+			expected: []string{"sub1", "sub2"},
+		},
+		"subsystems-auto-created": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tflog.NewSubsystem(ctx, "sub2")
+				tflog.SubsystemTrace(ctx, "sub1", "test message")
+
+				return ctx
+			},
+			expected: []string{"sub1", "sub2"},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+			ctx = testCase.setup(ctx)
+
+			got := tflog.Subsystems(ctx)
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestAutoCreatedSubsystems(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		setup    func(context.Context) context.Context
+		expected []string
+	}{
+		"no-root-logger": {
+			setup: func(_ context.Context) context.Context {
+				return context.Background()
+			},
+			expected: nil,
+		},
+		"no-subsystems": {
+			setup: func(ctx context.Context) context.Context {
+				return ctx
+			},
+			expected: []string{},
+		},
+		"subsystems-created": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tflog.NewSubsystem(ctx, "sub1")
+				tflog.SubsystemTrace(ctx, "sub1", "test message")
+
+				return ctx
+			},
+			expected: []string{},
+		},
+		"subsystems-auto-created": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tflog.NewSubsystem(ctx, "sub1")
+				tflog.SubsystemTrace(ctx, "sub2", "test message")
+				tflog.SubsystemError(ctx, "sub3", "test message")
+
+				return ctx
+			},
+			expected: []string{"sub2", "sub3"},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+			ctx = testCase.setup(ctx)
+
+			got := tflog.AutoCreatedSubsystems(ctx)
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestHasSubsystem(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		setup    func(context.Context) context.Context
+		expected bool
+	}{
+		"no-root-logger": {
+			setup: func(_ context.Context) context.Context {
+				return tflog.NewSubsystem(context.Background(), testSubsystem)
+			},
+			expected: false,
+		},
+		"no-subsystem": {
+			setup: func(ctx context.Context) context.Context {
+				return ctx
+			},
+			expected: false,
+		},
+		"other-subsystem": {
+			setup: func(ctx context.Context) context.Context {
+				return tflog.NewSubsystem(ctx, "other")
+			},
+			expected: false,
+		},
+		"subsystem": {
+			setup: func(ctx context.Context) context.Context {
+				return tflog.NewSubsystem(ctx, testSubsystem)
+			},
+			expected: true,
+		},
+		"subsystem-auto-created": {
+			setup: func(ctx context.Context) context.Context {
+				tflog.SubsystemTrace(ctx, testSubsystem, "test message")
+
+				return ctx
+			},
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+			ctx = testCase.setup(ctx)
+
+			got := tflog.HasSubsystem(ctx, testSubsystem)
+
+			if got != testCase.expected {
+				t.Errorf("expected %t, got: %t", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewSubsystem_Options(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		subsystem      string
+		options        []logging.Option
+		logMessage     string
+		fields         map[string]interface{}
+		expectedOutput []map[string]interface{}
+	}{
+		"no-options": {
+			subsystem:  "tflog_options_none",
+			logMessage: "test message",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "provider.tflog_options_none",
+				},
+			},
+		},
+		"log-name": {
+			subsystem:  "tflog_options_log_name",
+			options:    []logging.Option{tflog.WithLogName("custom")},
+			logMessage: "test message",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "custom",
+				},
+			},
+		},
+		"level": {
+			subsystem:      "tflog_options_level",
+			options:        []logging.Option{tflog.WithLevel(hclog.Warn)},
+			logMessage:     "test message",
+			expectedOutput: nil,
+		},
+		"fields": {
+			subsystem: "tflog_options_fields",
+			options: []logging.Option{
+				tflog.WithField("k1", "v1"),
+				tflog.WithFields(map[string]interface{}{"k2": "v2"}),
+			},
+			logMessage: "test message",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "provider.tflog_options_fields",
+					"k1":       "v1",
+					"k2":       "v2",
+				},
+			},
+		},
+		"omit-rules": {
+			subsystem: "tflog_options_omit",
+			options: []logging.Option{
+				tflog.WithOmitLogWithFieldKeys("k1"),
+				tflog.WithOmitLogWithMessageRegexes(regexp.MustCompile("foo")),
+				tflog.WithOmitLogWithMessageStrings("bar"),
+			},
+			logMessage:     "test message with foo",
+			expectedOutput: nil,
+		},
+		"mask-rules": {
+			subsystem: "tflog_options_mask",
+			options: []logging.Option{
+				tflog.WithMaskFieldValuesWithFieldKeys("k1"),
+				tflog.WithMaskAllFieldValuesRegexes(regexp.MustCompile("v[0-9]")),
+				tflog.WithMaskAllFieldValuesStrings("secret"),
+				tflog.WithMaskMessageRegexes(regexp.MustCompile("foo")),
+				tflog.WithMaskMessageStrings("bar"),
+			},
+			logMessage: "test message with foo and bar",
+			fields: map[string]interface{}{
+				"k1": "value",
+				"k2": "v2",
+				"k3": "a secret value",
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message with *** and ***",
+					"@module":  "provider.tflog_options_mask",
+					"k1":       "***",
+					"k2":       "***",
+					"k3":       "a *** value",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+			ctx = tflog.NewSubsystem(ctx, testCase.subsystem, testCase.options...)
+
+			tflog.SubsystemTrace(ctx, testCase.subsystem, testCase.logMessage, testCase.fields)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestNewSubsystem_Nested(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		setup          func(context.Context) context.Context
+		subsystem      string
+		logMessage     string
+		expectedOutput []map[string]interface{}
+	}{
+		"no-parent": {
+			setup: func(ctx context.Context) context.Context {
+				return tflog.NewSubsystem(ctx, "tflog_nested_none.child")
+			},
+			subsystem:  "tflog_nested_none.child",
+			logMessage: "test message",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "provider.tflog_nested_none.child",
+				},
+			},
+		},
+		"parent": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tflog.NewSubsystem(ctx, "tflog_nested_parent",
+					tflog.WithField("parent-key", "parent-value"),
+					tflog.WithMaskMessageStrings("secret"),
+				)
+
+				return tflog.NewSubsystem(ctx, "tflog_nested_parent.child",
+					tflog.WithField("child-key", "child-value"),
+				)
+			},
+			subsystem:  "tflog_nested_parent.child",
+			logMessage: "test message with secret",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":     "trace",
+					"@message":   "test message with ***",
+					"@module":    "provider.tflog_nested_parent.child",
+					"child-key":  "child-value",
+					"parent-key": "parent-value",
+				},
+			},
+		},
+		"parent-level": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tflog.NewSubsystem(ctx, "tflog_nested_level", tflog.WithLevel(hclog.Warn))
+
+				return tflog.NewSubsystem(ctx, "tflog_nested_level.child")
+			},
+			subsystem:      "tflog_nested_level.child",
+			logMessage:     "test message",
+			expectedOutput: nil,
+		},
+		"grandparent": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tflog.NewSubsystem(ctx, "tflog_nested_grandparent", tflog.WithField("grandparent-key", "grandparent-value"))
+
+				return tflog.NewSubsystem(ctx, "tflog_nested_grandparent.parent.child")
+			},
+			subsystem:  "tflog_nested_grandparent.parent.child",
+			logMessage: "test message",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":          "trace",
+					"@message":        "test message",
+					"@module":         "provider.tflog_nested_grandparent.parent.child",
+					"grandparent-key": "grandparent-value",
+				},
+			},
+		},
+		"parent-fields-not-modified": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tflog.NewSubsystem(ctx, "tflog_nested_unmodified", tflog.WithField("parent-key", "parent-value"))
+				ctx = tflog.NewSubsystem(ctx, "tflog_nested_unmodified.child", tflog.WithField("child-key", "child-value"))
+
+				return ctx
+			},
+			subsystem:  "tflog_nested_unmodified",
+			logMessage: "test message",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":     "trace",
+					"@message":   "test message",
+					"@module":    "provider.tflog_nested_unmodified",
+					"parent-key": "parent-value",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+			ctx = testCase.setup(ctx)
+
+			tflog.SubsystemTrace(ctx, testCase.subsystem, testCase.logMessage)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tfloghttp provides an http.RoundTripper which writes HTTP requests
+// and responses as log output of a provider subsystem logger, with redaction
+// of sensitive headers and masking of request and response bodies by the
+// mask rules of the subsystem logger.
+package tfloghttp
@@ -0,0 +1,56 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfloghttp
+
+import (
+	"net/http"
+)
+
+// DefaultMaxBodySize is the default maximum number of bytes of request and
+// response bodies included in the log output.
+const DefaultMaxBodySize = 64 * 1024
+
+// defaultRedactedHeaders are the headers redacted in the log output, unless
+// WithoutDefaultRedactedHeaders is used.
+var defaultRedactedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+}
+
+// Option configures a Transport created with NewTransport.
+type Option func(*Transport)
+
+// WithMaxBodySize returns an option that will limit the number of bytes of
+// request and response bodies included in the log output to `size`. Longer
+// bodies are truncated, which is indicated by the tf_http_req_body_truncated
+// and tf_http_res_body_truncated fields. A size of 0 or less disables the
+// logging of bodies. The default is DefaultMaxBodySize.
+func WithMaxBodySize(size int) Option {
+	return func(t *Transport) {
+		t.maxBodySize = size
+	}
+}
+
+// WithRedactedHeaders returns an option that will redact the values of the
+// given request and response headers in the log output, in addition to the
+// Authorization, Cookie, Proxy-Authorization, and Set-Cookie headers.
+// Header names are case-insensitive.
+func WithRedactedHeaders(headers ...string) Option {
+	return func(t *Transport) {
+		for _, header := range headers {
+			t.redactedHeaders[http.CanonicalHeaderKey(header)] = struct{}{}
+		}
+	}
+}
+
+// WithoutDefaultRedactedHeaders returns an option that will no longer redact
+// the values of the Authorization, Cookie, Proxy-Authorization, and Set-Cookie
+// headers in the log output, unless given with WithRedactedHeaders.
+func WithoutDefaultRedactedHeaders() Option {
+	return func(t *Transport) {
+		t.withoutDefaultRedactedHeaders = true
+	}
+}
@@ -0,0 +1,386 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfloghttp
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// FieldHTTPOperationType is the field key for the type of HTTP
+	// operation, either OperationHTTPRequest or OperationHTTPResponse.
+	FieldHTTPOperationType = "tf_http_op_type"
+
+	// FieldHTTPTransactionID is the field key for a unique identifier of an
+	// HTTP request and its response.
+	FieldHTTPTransactionID = "tf_http_trans_id"
+
+	// FieldHTTPRequestMethod is the field key for the HTTP request method.
+	FieldHTTPRequestMethod = "tf_http_req_method"
+
+	// FieldHTTPRequestURI is the field key for the HTTP request URI.
+	FieldHTTPRequestURI = "tf_http_req_uri"
+
+	// FieldHTTPRequestProtoVersion is the field key for the HTTP request
+	// protocol version.
+	FieldHTTPRequestProtoVersion = "tf_http_req_version"
+
+	// FieldHTTPRequestBody is the field key for the HTTP request body.
+	FieldHTTPRequestBody = "tf_http_req_body"
+
+	// FieldHTTPRequestBodyTruncated is the field key set to true when the
+	// HTTP request body exceeds the maximum body size.
+	FieldHTTPRequestBodyTruncated = "tf_http_req_body_truncated"
+
+	// FieldHTTPRequestBodyError is the field key for the error reading the
+	// HTTP request body for the log output, in which case the body only
+	// includes the bytes read before the error.
+	FieldHTTPRequestBodyError = "tf_http_req_body_error"
+
+	// FieldHTTPResponseProtoVersion is the field key for the HTTP response
+	// protocol version.
+	FieldHTTPResponseProtoVersion = "tf_http_res_version"
+
+	// FieldHTTPResponseStatusCode is the field key for the HTTP response
+	// status code.
+	FieldHTTPResponseStatusCode = "tf_http_res_status_code"
+
+	// FieldHTTPResponseStatusReason is the field key for the HTTP response
+	// status reason, e.g. "Not Found".
+	FieldHTTPResponseStatusReason = "tf_http_res_status_reason"
+
+	// FieldHTTPResponseBody is the field key for the HTTP response body.
+	FieldHTTPResponseBody = "tf_http_res_body"
+
+	// FieldHTTPResponseBodyTruncated is the field key set to true when the
+	// HTTP response body exceeds the maximum body size.
+	FieldHTTPResponseBodyTruncated = "tf_http_res_body_truncated"
+
+	// FieldHTTPResponseBodyError is the field key for the error reading the
+	// HTTP response body for the log output, in which case the body only
+	// includes the bytes read before the error.
+	FieldHTTPResponseBodyError = "tf_http_res_body_error"
+
+	// FieldHTTPDurationMs is the field key for the number of milliseconds
+	// between sending the HTTP request and receiving the HTTP response.
+	FieldHTTPDurationMs = "tf_http_duration_ms"
+
+	// FieldHTTPError is the field key for the error of an HTTP request which
+	// did not receive a response.
+	FieldHTTPError = "tf_http_error"
+
+	// FieldHTTPRequestHeaderPrefix is the prefix of the field keys for the
+	// HTTP request headers, followed by the lowercase header name, e.g.
+	// "http.request.header.content-type".
+	FieldHTTPRequestHeaderPrefix = "http.request.header."
+
+	// FieldHTTPResponseHeaderPrefix is the prefix of the field keys for the
+	// HTTP response headers, followed by the lowercase header name, e.g.
+	// "http.response.header.content-type".
+	FieldHTTPResponseHeaderPrefix = "http.response.header."
+
+	// OperationHTTPRequest is the FieldHTTPOperationType value of HTTP
+	// request log output.
+	OperationHTTPRequest = "request"
+
+	// OperationHTTPResponse is the FieldHTTPOperationType value of HTTP
+	// response log output.
+	OperationHTTPResponse = "response"
+)
+
+// redactedValue replaces the values of redacted headers in the log output.
+const redactedValue = "***"
+
+// unmaskedBodyValue replaces the bodies which cannot be masked by the mask
+// rules of the subsystem logger in the log output.
+const unmaskedBodyValue = "[body not logged: unable to apply field key mask rules]"
+
+// Transport is an http.RoundTripper which writes each HTTP request and its
+// response as debug log output of a provider subsystem logger in the
+// context.Context of the request, before passing the request on to an
+// underlying http.RoundTripper.
+//
+// Headers are included as fields with the lowercase header name prefixed by
+// FieldHTTPRequestHeaderPrefix or FieldHTTPResponseHeaderPrefix as field key,
+// with values of sensitive headers redacted. Bodies are included up to a
+// maximum size. Complete JSON bodies are masked by the mask rules of the
+// subsystem logger at any depth, e.g. the values of nested JSON object keys
+// given to tflog.SubsystemMaskFieldValuesWithFieldKeys are masked. Other
+// bodies, including truncated JSON bodies, are masked as text by the message
+// and field value mask rules, or replaced by a placeholder when the subsystem
+// logger masks field values by field key, as those rules cannot apply to
+// text.
+//
+// Bodies are read before passing them on, so Transport should not be used
+// for streaming requests or responses. If reading a body fails, the error is
+// included in the log output, and the body passed on returns the bytes read
+// before the error followed by the error, so reading it fails as before.
+type Transport struct {
+	subsystem string
+	transport http.RoundTripper
+
+	maxBodySize                   int
+	redactedHeaders               map[string]struct{}
+	withoutDefaultRedactedHeaders bool
+}
+
+var _ http.RoundTripper = &Transport{}
+
+// NewTransport returns a Transport writing log output to `subsystem`, which
+// should be created with tflog.NewSubsystem, and passing requests on to
+// `transport`. If `transport` is nil, http.DefaultTransport is used.
+func NewTransport(subsystem string, transport http.RoundTripper, options ...Option) *Transport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	t := &Transport{
+		subsystem:       subsystem,
+		transport:       transport,
+		maxBodySize:     DefaultMaxBodySize,
+		redactedHeaders: make(map[string]struct{}),
+	}
+
+	for _, option := range options {
+		option(t)
+	}
+
+	if !t.withoutDefaultRedactedHeaders {
+		for _, header := range defaultRedactedHeaders {
+			t.redactedHeaders[header] = struct{}{}
+		}
+	}
+
+	return t
+}
+
+// RoundTrip writes `req` as log output, executes it with the underlying
+// http.RoundTripper, and writes the response or error as log output.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	logger := tflog.SubsystemLogger(ctx, t.subsystem).With(FieldHTTPTransactionID, newTransactionID())
+	lOpts := logging.GetProviderSubsystemEffectiveTFLoggerOpts(ctx, t.subsystem)
+
+	requestFields := map[string]interface{}{
+		FieldHTTPOperationType:       OperationHTTPRequest,
+		FieldHTTPRequestMethod:       req.Method,
+		FieldHTTPRequestURI:          req.URL.String(),
+		FieldHTTPRequestProtoVersion: req.Proto,
+	}
+
+	t.headerFields(requestFields, FieldHTTPRequestHeaderPrefix, req.Header)
+
+	if req.Body != nil && req.Body != http.NoBody && t.maxBodySize > 0 {
+		// Avoid modifying the request of the caller
+		req = req.Clone(ctx)
+
+		body, truncated, readCloser, err := t.readBody(req.Body)
+
+		req.Body = readCloser
+
+		// Incomplete bodies are masked like truncated bodies
+		requestFields[FieldHTTPRequestBody] = bodyFieldValue(lOpts, req.Header, body, truncated || err != nil)
+
+		if truncated {
+			requestFields[FieldHTTPRequestBodyTruncated] = true
+		}
+
+		if err != nil {
+			requestFields[FieldHTTPRequestBodyError] = err.Error()
+		}
+	}
+
+	logger.Debug("Sending HTTP Request", requestFields)
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	duration := time.Since(start)
+
+	if err != nil {
+		logger.Debug("HTTP Request Error", map[string]interface{}{
+			FieldHTTPOperationType: OperationHTTPResponse,
+			FieldHTTPDurationMs:    duration.Milliseconds(),
+			FieldHTTPError:         err.Error(),
+		})
+
+		return resp, err
+	}
+
+	responseFields := map[string]interface{}{
+		FieldHTTPOperationType:        OperationHTTPResponse,
+		FieldHTTPDurationMs:           duration.Milliseconds(),
+		FieldHTTPResponseProtoVersion: resp.Proto,
+		FieldHTTPResponseStatusCode:   resp.StatusCode,
+		FieldHTTPResponseStatusReason: strings.TrimPrefix(resp.Status, strconv.Itoa(resp.StatusCode)+" "),
+	}
+
+	t.headerFields(responseFields, FieldHTTPResponseHeaderPrefix, resp.Header)
+
+	if resp.Body != nil && resp.Body != http.NoBody && t.maxBodySize > 0 {
+		body, truncated, readCloser, err := t.readBody(resp.Body)
+
+		resp.Body = readCloser
+
+		// Incomplete bodies are masked like truncated bodies
+		responseFields[FieldHTTPResponseBody] = bodyFieldValue(lOpts, resp.Header, body, truncated || err != nil)
+
+		if truncated {
+			responseFields[FieldHTTPResponseBodyTruncated] = true
+		}
+
+		if err != nil {
+			responseFields[FieldHTTPResponseBodyError] = err.Error()
+		}
+	}
+
+	logger.Debug("Received HTTP Response", responseFields)
+
+	return resp, nil
+}
+
+// headerFields sets the values of `header` in `fields`, with the lowercase
+// header name prefixed by `prefix` as field key, redacting the values of
+// sensitive headers.
+func (t *Transport) headerFields(fields map[string]interface{}, prefix string, header http.Header) {
+	for name, values := range header {
+		key := prefix + strings.ToLower(name)
+
+		if _, ok := t.redactedHeaders[http.CanonicalHeaderKey(name)]; ok {
+			fields[key] = redactedValue
+
+			continue
+		}
+
+		fields[key] = strings.Join(values, ", ")
+	}
+}
+
+// readBody reads up to the maximum body size of `body`, and returns the read
+// bytes, whether `body` is longer than the maximum body size, and an
+// io.ReadCloser replacing `body` which still returns the complete body. If
+// reading `body` fails, it returns the bytes read before the error, and the
+// io.ReadCloser returns them followed by the error, so the error is left to
+// the reader of the body.
+func (t *Transport) readBody(body io.ReadCloser) ([]byte, bool, io.ReadCloser, error) {
+	// Read one additional byte to determine whether the body is truncated
+	read, err := io.ReadAll(io.LimitReader(body, int64(t.maxBodySize)+1))
+
+	rest := io.Reader(body)
+
+	if err != nil {
+		rest = errReader{err: err}
+	}
+
+	readCloser := struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.MultiReader(bytes.NewReader(read), rest),
+		Closer: body,
+	}
+
+	if err != nil {
+		return read, false, readCloser, err
+	}
+
+	if len(read) > t.maxBodySize {
+		return read[:t.maxBodySize], true, readCloser, nil
+	}
+
+	return read, false, readCloser, nil
+}
+
+// bodyFieldValue returns the log field value of a request or response body.
+// Complete JSON bodies are masked by the mask rules of `lOpts` at any depth,
+// while other bodies are masked as text, unless `lOpts` masks field values by
+// field key, which cannot apply to text, so the body is replaced instead.
+func bodyFieldValue(lOpts logging.LoggerOpts, header http.Header, body []byte, truncated bool) string {
+	if !truncated && isJSON(header) {
+		if value, ok := jsonBodyFieldValue(lOpts, body); ok {
+			return value
+		}
+	}
+
+	if lOpts.MasksFieldValuesWithFieldKeys() {
+		return unmaskedBodyValue
+	}
+
+	value := strings.ToValidUTF8(string(body), "�")
+
+	lOpts.ApplyMask(&value)
+
+	return value
+}
+
+// jsonBodyFieldValue returns the log field value of a JSON body, masked by
+// the mask rules of `lOpts` at any depth. It returns false if the body is not
+// valid JSON.
+func jsonBodyFieldValue(lOpts logging.LoggerOpts, body []byte) (string, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+
+	if err := decoder.Decode(&value); err != nil {
+		return "", false
+	}
+
+	value, masked := lOpts.ApplyMaskNested(value)
+
+	if !masked {
+		return strings.ToValidUTF8(string(body), "�"), true
+	}
+
+	maskedBody, err := json.Marshal(value)
+
+	if err != nil {
+		return "", false
+	}
+
+	return string(maskedBody), true
+}
+
+// isJSON returns true if the Content-Type header is a JSON media type.
+func isJSON(header http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// newTransactionID returns a random identifier for an HTTP request and its
+// response.
+func newTransactionID() string {
+	id := make([]byte, 16)
+
+	// crypto/rand.Read never returns an error
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+// errReader is an io.Reader always returning an error.
+type errReader struct {
+	err error
+}
+
+// Read returns the error of the errReader.
+func (r errReader) Read(_ []byte) (int, error) {
+	return 0, r.err
+}
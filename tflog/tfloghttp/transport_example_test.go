@@ -0,0 +1,37 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfloghttp_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflog/tfloghttp"
+)
+
+func ExampleNewTransport() {
+	// The context.Context passed in by the framework or library you're
+	// using, which contains the provider root logger.
+	ctx := context.Background()
+
+	ctx = tflog.NewSubsystem(ctx, "api")
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, "api", "password")
+
+	client := &http.Client{
+		Transport: tfloghttp.NewTransport("api", http.DefaultTransport,
+			tfloghttp.WithRedactedHeaders("X-Api-Key"),
+			tfloghttp.WithMaxBodySize(4096),
+		),
+	}
+
+	// The context.Context of the request determines the logger.
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.example.com/", nil)
+
+	if err != nil {
+		return
+	}
+
+	_, _ = client, req
+}
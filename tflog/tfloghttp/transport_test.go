@@ -0,0 +1,781 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfloghttp_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflog/tfloghttp"
+)
+
+const testSubsystem = "test_http"
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		setup           func(context.Context) context.Context
+		options         []tfloghttp.Option
+		handler         http.HandlerFunc
+		newRequest      func(ctx context.Context, url string) (*http.Request, error)
+		expectedBody    string
+		expectedRequest string
+		expectedOutput  []map[string]interface{}
+	}{
+		"get": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte("test response"))
+			},
+			newRequest: func(ctx context.Context, url string) (*http.Request, error) {
+				return http.NewRequestWithContext(ctx, http.MethodGet, url+"/test", nil)
+			},
+			expectedBody: "test response",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                               "debug",
+					"@message":                             "Sending HTTP Request",
+					"@module":                              "provider." + testSubsystem,
+					tfloghttp.FieldHTTPOperationType:       tfloghttp.OperationHTTPRequest,
+					tfloghttp.FieldHTTPRequestMethod:       "GET",
+					tfloghttp.FieldHTTPRequestURI:          "SERVER/test",
+					tfloghttp.FieldHTTPTransactionID:       "TRANSACTION",
+					tfloghttp.FieldHTTPRequestProtoVersion: "HTTP/1.1",
+				},
+				{
+					"@level":                                "debug",
+					"@message":                              "Received HTTP Response",
+					"@module":                               "provider." + testSubsystem,
+					"http.response.header.content-length":   "13",
+					"http.response.header.content-type":     "text/plain",
+					tfloghttp.FieldHTTPOperationType:        tfloghttp.OperationHTTPResponse,
+					tfloghttp.FieldHTTPDurationMs:           "DURATION",
+					tfloghttp.FieldHTTPResponseBody:         "test response",
+					tfloghttp.FieldHTTPResponseProtoVersion: "HTTP/1.1",
+					tfloghttp.FieldHTTPResponseStatusCode:   float64(200),
+					tfloghttp.FieldHTTPResponseStatusReason: "OK",
+					tfloghttp.FieldHTTPTransactionID:        "TRANSACTION",
+				},
+			},
+		},
+		"redacted-headers": {
+			options: []tfloghttp.Option{
+				tfloghttp.WithRedactedHeaders("x-api-key"),
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Set-Cookie", "session=secret")
+				w.WriteHeader(http.StatusNoContent)
+			},
+			newRequest: func(ctx context.Context, url string) (*http.Request, error) {
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+				if err != nil {
+					return nil, err
+				}
+
+				req.Header.Set("Authorization", "Bearer secret")
+				req.Header.Set("Cookie", "session=secret")
+				req.Header.Set("X-Api-Key", "secret")
+				req.Header.Set("X-Request-Id", "test-request-id")
+
+				return req, nil
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                               "debug",
+					"@message":                             "Sending HTTP Request",
+					"@module":                              "provider." + testSubsystem,
+					"http.request.header.authorization":    "***",
+					"http.request.header.cookie":           "***",
+					"http.request.header.x-api-key":        "***",
+					"http.request.header.x-request-id":     "test-request-id",
+					tfloghttp.FieldHTTPOperationType:       tfloghttp.OperationHTTPRequest,
+					tfloghttp.FieldHTTPRequestMethod:       "GET",
+					tfloghttp.FieldHTTPRequestURI:          "SERVER",
+					tfloghttp.FieldHTTPRequestProtoVersion: "HTTP/1.1",
+					tfloghttp.FieldHTTPTransactionID:       "TRANSACTION",
+				},
+				{
+					"@level":                                "debug",
+					"@message":                              "Received HTTP Response",
+					"@module":                               "provider." + testSubsystem,
+					"http.response.header.set-cookie":       "***",
+					tfloghttp.FieldHTTPOperationType:        tfloghttp.OperationHTTPResponse,
+					tfloghttp.FieldHTTPDurationMs:           "DURATION",
+					tfloghttp.FieldHTTPResponseProtoVersion: "HTTP/1.1",
+					tfloghttp.FieldHTTPResponseStatusCode:   float64(204),
+					tfloghttp.FieldHTTPResponseStatusReason: "No Content",
+					tfloghttp.FieldHTTPTransactionID:        "TRANSACTION",
+				},
+			},
+		},
+		"without-default-redacted-headers": {
+			options: []tfloghttp.Option{
+				tfloghttp.WithoutDefaultRedactedHeaders(),
+				tfloghttp.WithRedactedHeaders("Cookie"),
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+			newRequest: func(ctx context.Context, url string) (*http.Request, error) {
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+				if err != nil {
+					return nil, err
+				}
+
+				req.Header.Set("Authorization", "Bearer test")
+				req.Header.Set("Cookie", "session=secret")
+
+				return req, nil
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                               "debug",
+					"@message":                             "Sending HTTP Request",
+					"@module":                              "provider." + testSubsystem,
+					"http.request.header.authorization":    "Bearer test",
+					"http.request.header.cookie":           "***",
+					tfloghttp.FieldHTTPOperationType:       tfloghttp.OperationHTTPRequest,
+					tfloghttp.FieldHTTPRequestMethod:       "GET",
+					tfloghttp.FieldHTTPRequestURI:          "SERVER",
+					tfloghttp.FieldHTTPRequestProtoVersion: "HTTP/1.1",
+					tfloghttp.FieldHTTPTransactionID:       "TRANSACTION",
+				},
+				{
+					"@level":                                "debug",
+					"@message":                              "Received HTTP Response",
+					"@module":                               "provider." + testSubsystem,
+					tfloghttp.FieldHTTPOperationType:        tfloghttp.OperationHTTPResponse,
+					tfloghttp.FieldHTTPDurationMs:           "DURATION",
+					tfloghttp.FieldHTTPResponseProtoVersion: "HTTP/1.1",
+					tfloghttp.FieldHTTPResponseStatusCode:   float64(204),
+					tfloghttp.FieldHTTPResponseStatusReason: "No Content",
+					tfloghttp.FieldHTTPTransactionID:        "TRANSACTION",
+				},
+			},
+		},
+		"json-masking": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, testSubsystem, "password")
+				ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, testSubsystem, "hunter2")
+
+				return ctx
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/vnd.api+json; charset=utf-8")
+				w.WriteHeader(http.StatusCreated)
+				_, _ = io.Copy(w, r.Body)
+			},
+			newRequest: func(ctx context.Context, url string) (*http.Request, error) {
+				req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(`{"user":{"name":"test","password":"secret","tokens":["hunter2",1.50]}}`))
+
+				if err != nil {
+					return nil, err
+				}
+
+				req.Header.Set("Content-Type", "application/json")
+
+				return req, nil
+			},
+			expectedBody:    `{"user":{"name":"test","password":"secret","tokens":["hunter2",1.50]}}`,
+			expectedRequest: `{"user":{"name":"test","password":"secret","tokens":["hunter2",1.50]}}`,
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                               "debug",
+					"@message":                             "Sending HTTP Request",
+					"@module":                              "provider." + testSubsystem,
+					"http.request.header.content-type":     "application/json",
+					tfloghttp.FieldHTTPOperationType:       tfloghttp.OperationHTTPRequest,
+					tfloghttp.FieldHTTPRequestBody:         `{"user":{"name":"test","password":"***","tokens":["***",1.50]}}`,
+					tfloghttp.FieldHTTPRequestMethod:       "POST",
+					tfloghttp.FieldHTTPRequestURI:          "SERVER",
+					tfloghttp.FieldHTTPRequestProtoVersion: "HTTP/1.1",
+					tfloghttp.FieldHTTPTransactionID:       "TRANSACTION",
+				},
+				{
+					"@level":                                "debug",
+					"@message":                              "Received HTTP Response",
+					"@module":                               "provider." + testSubsystem,
+					"http.response.header.content-length":   "70",
+					"http.response.header.content-type":     "application/vnd.api+json; charset=utf-8",
+					tfloghttp.FieldHTTPOperationType:        tfloghttp.OperationHTTPResponse,
+					tfloghttp.FieldHTTPDurationMs:           "DURATION",
+					tfloghttp.FieldHTTPResponseBody:         `{"user":{"name":"test","password":"***","tokens":["***",1.50]}}`,
+					tfloghttp.FieldHTTPResponseProtoVersion: "HTTP/1.1",
+					tfloghttp.FieldHTTPResponseStatusCode:   float64(201),
+					tfloghttp.FieldHTTPResponseStatusReason: "Created",
+					tfloghttp.FieldHTTPTransactionID:        "TRANSACTION",
+				},
+			},
+		},
+		"max-body-size": {
+			options: []tfloghttp.Option{
+				tfloghttp.WithMaxBodySize(4),
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				w.WriteHeader(http.StatusOK)
+				_, _ = io.Copy(w, r.Body)
+			},
+			newRequest: func(ctx context.Context, url string) (*http.Request, error) {
+				return http.NewRequestWithContext(ctx, http.MethodPut, url, strings.NewReader("test body"))
+			},
+			expectedBody:    "test body",
+			expectedRequest: "test body",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                                "debug",
+					"@message":                              "Sending HTTP Request",
+					"@module":                               "provider." + testSubsystem,
+					tfloghttp.FieldHTTPOperationType:        tfloghttp.OperationHTTPRequest,
+					tfloghttp.FieldHTTPRequestBody:          "test",
+					tfloghttp.FieldHTTPRequestBodyTruncated: true,
+					tfloghttp.FieldHTTPRequestMethod:        "PUT",
+					tfloghttp.FieldHTTPRequestURI:           "SERVER",
+					tfloghttp.FieldHTTPRequestProtoVersion:  "HTTP/1.1",
+					tfloghttp.FieldHTTPTransactionID:        "TRANSACTION",
+				},
+				{
+					"@level":                                 "debug",
+					"@message":                               "Received HTTP Response",
+					"@module":                                "provider." + testSubsystem,
+					"http.response.header.content-length":    "9",
+					"http.response.header.content-type":      "text/plain",
+					tfloghttp.FieldHTTPOperationType:         tfloghttp.OperationHTTPResponse,
+					tfloghttp.FieldHTTPDurationMs:            "DURATION",
+					tfloghttp.FieldHTTPResponseBody:          "test",
+					tfloghttp.FieldHTTPResponseBodyTruncated: true,
+					tfloghttp.FieldHTTPResponseProtoVersion:  "HTTP/1.1",
+					tfloghttp.FieldHTTPResponseStatusCode:    float64(200),
+					tfloghttp.FieldHTTPResponseStatusReason:  "OK",
+					tfloghttp.FieldHTTPTransactionID:         "TRANSACTION",
+				},
+			},
+		},
+		"truncated-json-field-key-masking": {
+			setup: func(ctx context.Context) context.Context {
+				return tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, testSubsystem, "password")
+			},
+			options: []tfloghttp.Option{
+				tfloghttp.WithMaxBodySize(24),
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+			newRequest: func(ctx context.Context, url string) (*http.Request, error) {
+				req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(`{"name":"test","password":"secret"}`))
+
+				if err != nil {
+					return nil, err
+				}
+
+				req.Header.Set("Content-Type", "application/json")
+
+				return req, nil
+			},
+			expectedRequest: `{"name":"test","password":"secret"}`,
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                                "debug",
+					"@message":                              "Sending HTTP Request",
+					"@module":                               "provider." + testSubsystem,
+					"http.request.header.content-type":      "application/json",
+					tfloghttp.FieldHTTPOperationType:        tfloghttp.OperationHTTPRequest,
+					tfloghttp.FieldHTTPRequestBody:          "[body not logged: unable to apply field key mask rules]",
+					tfloghttp.FieldHTTPRequestBodyTruncated: true,
+					tfloghttp.FieldHTTPRequestMethod:        "POST",
+					tfloghttp.FieldHTTPRequestURI:           "SERVER",
+					tfloghttp.FieldHTTPRequestProtoVersion:  "HTTP/1.1",
+					tfloghttp.FieldHTTPTransactionID:        "TRANSACTION",
+				},
+				{
+					"@level":                                "debug",
+					"@message":                              "Received HTTP Response",
+					"@module":                               "provider." + testSubsystem,
+					tfloghttp.FieldHTTPOperationType:        tfloghttp.OperationHTTPResponse,
+					tfloghttp.FieldHTTPDurationMs:           "DURATION",
+					tfloghttp.FieldHTTPResponseProtoVersion: "HTTP/1.1",
+					tfloghttp.FieldHTTPResponseStatusCode:   float64(204),
+					tfloghttp.FieldHTTPResponseStatusReason: "No Content",
+					tfloghttp.FieldHTTPTransactionID:        "TRANSACTION",
+				},
+			},
+		},
+		"text-masking": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tflog.SubsystemMaskMessageStrings(ctx, testSubsystem, "hunter2")
+				ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, testSubsystem, regexp.MustCompile(`token=\w+`))
+
+				return ctx
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte("password=hunter2\xff token=secret"))
+			},
+			newRequest: func(ctx context.Context, url string) (*http.Request, error) {
+				return http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			},
+			expectedBody: "password=hunter2\xff token=secret",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                               "debug",
+					"@message":                             "Sending HTTP Request",
+					"@module":                              "provider." + testSubsystem,
+					tfloghttp.FieldHTTPOperationType:       tfloghttp.OperationHTTPRequest,
+					tfloghttp.FieldHTTPRequestMethod:       "GET",
+					tfloghttp.FieldHTTPRequestURI:          "SERVER",
+					tfloghttp.FieldHTTPRequestProtoVersion: "HTTP/1.1",
+					tfloghttp.FieldHTTPTransactionID:       "TRANSACTION",
+				},
+				{
+					"@level":                                "debug",
+					"@message":                              "Received HTTP Response",
+					"@module":                               "provider." + testSubsystem,
+					"http.response.header.content-length":   "30",
+					"http.response.header.content-type":     "text/plain",
+					tfloghttp.FieldHTTPOperationType:        tfloghttp.OperationHTTPResponse,
+					tfloghttp.FieldHTTPDurationMs:           "DURATION",
+					tfloghttp.FieldHTTPResponseBody:         "password=***� ***",
+					tfloghttp.FieldHTTPResponseProtoVersion: "HTTP/1.1",
+					tfloghttp.FieldHTTPResponseStatusCode:   float64(200),
+					tfloghttp.FieldHTTPResponseStatusReason: "OK",
+					tfloghttp.FieldHTTPTransactionID:        "TRANSACTION",
+				},
+			},
+		},
+		"max-body-size-zero": {
+			options: []tfloghttp.Option{
+				tfloghttp.WithMaxBodySize(0),
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				w.WriteHeader(http.StatusOK)
+				_, _ = io.Copy(w, r.Body)
+			},
+			newRequest: func(ctx context.Context, url string) (*http.Request, error) {
+				return http.NewRequestWithContext(ctx, http.MethodPut, url, strings.NewReader("test body"))
+			},
+			expectedBody:    "test body",
+			expectedRequest: "test body",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                               "debug",
+					"@message":                             "Sending HTTP Request",
+					"@module":                              "provider." + testSubsystem,
+					tfloghttp.FieldHTTPOperationType:       tfloghttp.OperationHTTPRequest,
+					tfloghttp.FieldHTTPRequestMethod:       "PUT",
+					tfloghttp.FieldHTTPRequestURI:          "SERVER",
+					tfloghttp.FieldHTTPRequestProtoVersion: "HTTP/1.1",
+					tfloghttp.FieldHTTPTransactionID:       "TRANSACTION",
+				},
+				{
+					"@level":                                "debug",
+					"@message":                              "Received HTTP Response",
+					"@module":                               "provider." + testSubsystem,
+					"http.response.header.content-length":   "9",
+					"http.response.header.content-type":     "text/plain",
+					tfloghttp.FieldHTTPOperationType:        tfloghttp.OperationHTTPResponse,
+					tfloghttp.FieldHTTPDurationMs:           "DURATION",
+					tfloghttp.FieldHTTPResponseProtoVersion: "HTTP/1.1",
+					tfloghttp.FieldHTTPResponseStatusCode:   float64(200),
+					tfloghttp.FieldHTTPResponseStatusReason: "OK",
+					tfloghttp.FieldHTTPTransactionID:        "TRANSACTION",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+			var gotRequest string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+
+				if err != nil {
+					t.Errorf("unable to read request body: %s", err)
+				}
+
+				gotRequest = string(body)
+				r.Body = io.NopCloser(bytes.NewReader(body))
+
+				// Prevent non-deterministic output
+				w.Header()["Date"] = nil
+
+				testCase.handler(w, r)
+			}))
+
+			defer server.Close()
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+			ctx = tflog.NewSubsystem(ctx, testSubsystem)
+
+			if testCase.setup != nil {
+				ctx = testCase.setup(ctx)
+			}
+
+			client := &http.Client{
+				Transport: tfloghttp.NewTransport(testSubsystem, nil, testCase.options...),
+			}
+
+			req, err := testCase.newRequest(ctx, server.URL)
+
+			if err != nil {
+				t.Fatalf("unable to create request: %s", err)
+			}
+
+			resp, err := client.Do(req)
+
+			if err != nil {
+				t.Fatalf("unexpected request error: %s", err)
+			}
+
+			defer resp.Body.Close()
+
+			gotBody, err := io.ReadAll(resp.Body)
+
+			if err != nil {
+				t.Fatalf("unable to read response body: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedBody, string(gotBody)); diff != "" {
+				t.Errorf("unexpected response body difference: %s", diff)
+			}
+
+			if diff := cmp.Diff(testCase.expectedRequest, gotRequest); diff != "" {
+				t.Errorf("unexpected request body difference: %s", diff)
+			}
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			normalizeOutput(t, got, server.URL)
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestTransport_Error(t *testing.T) {
+	t.Parallel()
+
+	var outputBuffer bytes.Buffer
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	serverURL := server.URL
+	server.Close()
+
+	ctx := context.Background()
+	ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+	ctx = tflog.NewSubsystem(ctx, testSubsystem)
+
+	client := &http.Client{
+		Transport: tfloghttp.NewTransport(testSubsystem, nil),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, serverURL, nil)
+
+	if err != nil {
+		t.Fatalf("unable to create request: %s", err)
+	}
+
+	resp, err := client.Do(req)
+
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected request error")
+	}
+
+	got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+	if err != nil {
+		t.Fatalf("unable to read multiple line JSON: %s", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 log entries, got: %v", got)
+	}
+
+	if got[1]["@message"] != "HTTP Request Error" {
+		t.Errorf("unexpected message: %v", got[1]["@message"])
+	}
+
+	gotError, ok := got[1][tfloghttp.FieldHTTPError].(string)
+
+	if !ok || !strings.Contains(gotError, "connect") {
+		t.Errorf("expected %s field with connection error, got: %v", tfloghttp.FieldHTTPError, got[1])
+	}
+
+	if _, ok := got[1][tfloghttp.FieldHTTPDurationMs].(float64); !ok {
+		t.Errorf("expected %s field, got: %v", tfloghttp.FieldHTTPDurationMs, got[1])
+	}
+}
+
+func TestTransport_BodyReadError(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		requestBody          string
+		responseBody         string
+		requestErr           error
+		responseErr          error
+		expectedRequestBody  string
+		expectedResponseBody string
+		expectedErr          error
+		expectedOutput       []map[string]interface{}
+	}{
+		"request": {
+			requestBody:         "partial request",
+			requestErr:          errTestRead,
+			expectedRequestBody: "partial request",
+			expectedErr:         errTestRead,
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                               "debug",
+					"@message":                             "Sending HTTP Request",
+					"@module":                              "provider." + testSubsystem,
+					tfloghttp.FieldHTTPOperationType:       tfloghttp.OperationHTTPRequest,
+					tfloghttp.FieldHTTPRequestBody:         "partial request",
+					tfloghttp.FieldHTTPRequestBodyError:    "test read error",
+					tfloghttp.FieldHTTPRequestMethod:       "POST",
+					tfloghttp.FieldHTTPRequestURI:          "SERVER",
+					tfloghttp.FieldHTTPRequestProtoVersion: "HTTP/1.1",
+					tfloghttp.FieldHTTPTransactionID:       "TRANSACTION",
+				},
+				{
+					"@level":                         "debug",
+					"@message":                       "HTTP Request Error",
+					"@module":                        "provider." + testSubsystem,
+					tfloghttp.FieldHTTPOperationType: tfloghttp.OperationHTTPResponse,
+					tfloghttp.FieldHTTPDurationMs:    "DURATION",
+					tfloghttp.FieldHTTPError:         "test read error",
+					tfloghttp.FieldHTTPTransactionID: "TRANSACTION",
+				},
+			},
+		},
+		"response": {
+			responseBody:         "partial response",
+			responseErr:          errTestRead,
+			expectedResponseBody: "partial response",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                               "debug",
+					"@message":                             "Sending HTTP Request",
+					"@module":                              "provider." + testSubsystem,
+					tfloghttp.FieldHTTPOperationType:       tfloghttp.OperationHTTPRequest,
+					tfloghttp.FieldHTTPRequestMethod:       "POST",
+					tfloghttp.FieldHTTPRequestURI:          "SERVER",
+					tfloghttp.FieldHTTPRequestProtoVersion: "HTTP/1.1",
+					tfloghttp.FieldHTTPTransactionID:       "TRANSACTION",
+				},
+				{
+					"@level":                                "debug",
+					"@message":                              "Received HTTP Response",
+					"@module":                               "provider." + testSubsystem,
+					tfloghttp.FieldHTTPOperationType:        tfloghttp.OperationHTTPResponse,
+					tfloghttp.FieldHTTPDurationMs:           "DURATION",
+					tfloghttp.FieldHTTPResponseBody:         "partial response",
+					tfloghttp.FieldHTTPResponseBodyError:    "test read error",
+					tfloghttp.FieldHTTPResponseProtoVersion: "HTTP/1.1",
+					tfloghttp.FieldHTTPResponseStatusCode:   float64(200),
+					tfloghttp.FieldHTTPResponseStatusReason: "OK",
+					tfloghttp.FieldHTTPTransactionID:        "TRANSACTION",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+			ctx = tflog.NewSubsystem(ctx, testSubsystem)
+
+			var gotRequestBody string
+
+			// The underlying transport reads the request body like
+			// http.Transport, failing the request if the body cannot be read.
+			transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				body, err := io.ReadAll(req.Body)
+
+				gotRequestBody = string(body)
+
+				if err != nil {
+					return nil, err
+				}
+
+				return &http.Response{
+					Status:     "200 OK",
+					StatusCode: http.StatusOK,
+					Proto:      "HTTP/1.1",
+					Body:       bodyWithError(testCase.responseBody, testCase.responseErr),
+				}, nil
+			})
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, testServerURL, bodyWithError(testCase.requestBody, testCase.requestErr))
+
+			if err != nil {
+				t.Fatalf("unable to create request: %s", err)
+			}
+
+			resp, err := tfloghttp.NewTransport(testSubsystem, transport).RoundTrip(req)
+
+			if !errors.Is(err, testCase.expectedErr) {
+				t.Fatalf("expected error %v, got: %v", testCase.expectedErr, err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedRequestBody, gotRequestBody); diff != "" {
+				t.Errorf("unexpected request body difference: %s", diff)
+			}
+
+			if resp != nil {
+				defer resp.Body.Close()
+
+				gotResponseBody, err := io.ReadAll(resp.Body)
+
+				if !errors.Is(err, testCase.responseErr) {
+					t.Errorf("expected response body error %v, got: %v", testCase.responseErr, err)
+				}
+
+				if diff := cmp.Diff(testCase.expectedResponseBody, string(gotResponseBody)); diff != "" {
+					t.Errorf("unexpected response body difference: %s", diff)
+				}
+			}
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			normalizeOutput(t, got, testServerURL)
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestTransport_RequestUnchanged(t *testing.T) {
+	t.Parallel()
+
+	var outputBuffer bytes.Buffer
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	defer server.Close()
+
+	ctx := loggertest.ProviderRoot(context.Background(), &outputBuffer)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, strings.NewReader("test body"))
+
+	if err != nil {
+		t.Fatalf("unable to create request: %s", err)
+	}
+
+	originalBody := req.Body
+
+	resp, err := tfloghttp.NewTransport(testSubsystem, nil).RoundTrip(req)
+
+	if err != nil {
+		t.Fatalf("unexpected request error: %s", err)
+	}
+
+	resp.Body.Close()
+
+	if req.Body != originalBody {
+		t.Error("expected request body of caller to be unchanged")
+	}
+}
+
+// testServerURL is the URL of requests which are not sent to a server.
+const testServerURL = "http://example.com"
+
+// errTestRead is the error of bodies returned by bodyWithError.
+var errTestRead = errors.New("test read error")
+
+// bodyWithError returns a body returning `body` followed by `err`, or
+// http.NoBody if both are empty.
+func bodyWithError(body string, err error) io.ReadCloser {
+	if err == nil {
+		if body == "" {
+			return http.NoBody
+		}
+
+		return io.NopCloser(strings.NewReader(body))
+	}
+
+	return io.NopCloser(io.MultiReader(strings.NewReader(body), iotest.ErrReader(err)))
+}
+
+// roundTripperFunc is a function implementing http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls the roundTripperFunc.
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// normalizeOutput replaces non-deterministic field values in the log output
+// and verifies the transaction IDs of all entries are equal.
+func normalizeOutput(t *testing.T, entries []map[string]interface{}, serverURL string) {
+	t.Helper()
+
+	var transactionID interface{}
+
+	for _, entry := range entries {
+		if uri, ok := entry[tfloghttp.FieldHTTPRequestURI].(string); ok {
+			entry[tfloghttp.FieldHTTPRequestURI] = strings.Replace(uri, serverURL, "SERVER", 1)
+		}
+
+		if _, ok := entry[tfloghttp.FieldHTTPDurationMs].(float64); ok {
+			entry[tfloghttp.FieldHTTPDurationMs] = "DURATION"
+		}
+
+		if transactionID == nil {
+			transactionID = entry[tfloghttp.FieldHTTPTransactionID]
+		}
+
+		if entry[tfloghttp.FieldHTTPTransactionID] != transactionID {
+			t.Errorf("expected transaction ID %v, got: %v", transactionID, entry[tfloghttp.FieldHTTPTransactionID])
+		}
+
+		if _, ok := entry[tfloghttp.FieldHTTPTransactionID].(string); ok {
+			entry[tfloghttp.FieldHTTPTransactionID] = "TRANSACTION"
+		}
+	}
+}
@@ -0,0 +1,418 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tflog_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklogtest"
+)
+
+// staticTraceContextExtractor is a TraceContextExtractor always returning the
+// same trace and span IDs.
+type staticTraceContextExtractor struct {
+	traceID string
+	spanID  string
+}
+
+func (e staticTraceContextExtractor) ExtractTraceContext(_ context.Context) (string, string, bool) {
+	return e.traceID, e.spanID, true
+}
+
+func TestWithTraceContextExtractor(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		extractor      tflog.TraceContextExtractor
+		span           *loggertest.Span
+		log            func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"no-extractor": {
+			span: &loggertest.Span{TraceID: "trace1", SpanID: "span1"},
+			log: func(ctx context.Context) {
+				tflog.Trace(ctx, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "provider",
+				},
+			},
+		},
+		"no-span": {
+			extractor: loggertest.TraceContextExtractor{},
+			log: func(ctx context.Context) {
+				tflog.Trace(ctx, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "provider",
+				},
+			},
+		},
+		"root": {
+			extractor: loggertest.TraceContextExtractor{},
+			span:      &loggertest.Span{TraceID: "trace1", SpanID: "span1"},
+			log: func(ctx context.Context) {
+				tflog.Trace(ctx, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "provider",
+					"span_id":  "span1",
+					"trace_id": "trace1",
+				},
+			},
+		},
+		"root-field-precedence": {
+			extractor: loggertest.TraceContextExtractor{},
+			span:      &loggertest.Span{TraceID: "trace1", SpanID: "span1"},
+			log: func(ctx context.Context) {
+				tflog.Trace(ctx, "test message", map[string]interface{}{"trace_id": "trace2"})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "provider",
+					"span_id":  "span1",
+					"trace_id": "trace2",
+				},
+			},
+		},
+		"root-logger-field-precedence": {
+			extractor: loggertest.TraceContextExtractor{},
+			span:      &loggertest.Span{TraceID: "trace1", SpanID: "span1"},
+			log: func(ctx context.Context) {
+				ctx = tflog.SetField(ctx, "span_id", "span2")
+
+				tflog.Trace(ctx, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "provider",
+					"span_id":  "span2",
+					"trace_id": "trace1",
+				},
+			},
+		},
+		"root-masked": {
+			extractor: loggertest.TraceContextExtractor{},
+			span:      &loggertest.Span{TraceID: "trace1", SpanID: "span1"},
+			log: func(ctx context.Context) {
+				ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "span_id")
+
+				tflog.Trace(ctx, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "provider",
+					"span_id":  "***",
+					"trace_id": "trace1",
+				},
+			},
+		},
+		"subsystem-inherited": {
+			extractor: loggertest.TraceContextExtractor{},
+			span:      &loggertest.Span{TraceID: "trace1", SpanID: "span1"},
+			log: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem)
+
+				tflog.SubsystemTrace(ctx, testSubsystem, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  testSubsystemModule,
+					"span_id":  "span1",
+					"trace_id": "trace1",
+				},
+			},
+		},
+		"subsystem-logger-field-precedence": {
+			extractor: loggertest.TraceContextExtractor{},
+			span:      &loggertest.Span{TraceID: "trace1", SpanID: "span1"},
+			log: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem)
+				ctx = tflog.SubsystemSetField(ctx, testSubsystem, "trace_id", "trace2")
+
+				tflog.SubsystemTrace(ctx, testSubsystem, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  testSubsystemModule,
+					"span_id":  "span1",
+					"trace_id": "trace2",
+				},
+			},
+		},
+		"subsystem-override": {
+			extractor: loggertest.TraceContextExtractor{},
+			span:      &loggertest.Span{TraceID: "trace1", SpanID: "span1"},
+			log: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem, tflog.WithTraceContextExtractor(staticTraceContextExtractor{
+					traceID: "trace2",
+					spanID:  "span2",
+				}))
+
+				tflog.SubsystemTrace(ctx, testSubsystem, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  testSubsystemModule,
+					"span_id":  "span2",
+					"trace_id": "trace2",
+				},
+			},
+		},
+		"logger-handle": {
+			extractor: loggertest.TraceContextExtractor{},
+			span:      &loggertest.Span{TraceID: "trace1", SpanID: "span1"},
+			log: func(ctx context.Context) {
+				tflog.Logger(ctx).Trace("test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "provider",
+					"span_id":  "span1",
+					"trace_id": "trace1",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := tfsdklog.NewRootProviderLogger(
+				context.Background(),
+				logging.WithoutLocation(),
+				logging.WithoutTimestamp(),
+				logging.WithOutput(&outputBuffer),
+				tfsdklog.WithTraceContextExtractor(testCase.extractor),
+			)
+
+			if testCase.span != nil {
+				ctx = loggertest.ContextWithSpan(ctx, *testCase.span)
+			}
+
+			testCase.log(ctx)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestWithSpanEventBridge(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		log            func(context.Context)
+		expectedEvents []tflog.SpanEvent
+		expectedErrors []string
+	}{
+		"no-logs": {
+			log:            func(_ context.Context) {},
+			expectedEvents: nil,
+			expectedErrors: nil,
+		},
+		"root": {
+			log: func(ctx context.Context) {
+				ctx = tflog.SetField(ctx, "k1", "v1")
+
+				tflog.Debug(ctx, "test message", map[string]interface{}{"k2": "v2"})
+			},
+			expectedEvents: []tflog.SpanEvent{
+				{
+					Level:      hclog.Debug,
+					LoggerName: "provider",
+					Message:    "test message",
+					Fields: map[string]interface{}{
+						"k1": "v1",
+						"k2": "v2",
+					},
+				},
+			},
+			expectedErrors: nil,
+		},
+		"root-error": {
+			log: func(ctx context.Context) {
+				tflog.Error(ctx, "test error message")
+			},
+			expectedEvents: []tflog.SpanEvent{
+				{
+					Level:      hclog.Error,
+					LoggerName: "provider",
+					Message:    "test error message",
+					Fields:     map[string]interface{}{},
+				},
+			},
+			expectedErrors: []string{"test error message"},
+		},
+		"root-masked": {
+			log: func(ctx context.Context) {
+				ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "k1")
+				ctx = tflog.MaskMessageStrings(ctx, "secret")
+
+				tflog.Error(ctx, "test secret message", map[string]interface{}{"k1": "secret-value"})
+			},
+			expectedEvents: []tflog.SpanEvent{
+				{
+					Level:      hclog.Error,
+					LoggerName: "provider",
+					Message:    "test *** message",
+					Fields: map[string]interface{}{
+						"k1": "***",
+					},
+				},
+			},
+			expectedErrors: []string{"test *** message"},
+		},
+		"root-omitted": {
+			log: func(ctx context.Context) {
+				ctx = tflog.OmitLogWithMessageStrings(ctx, "omitted")
+
+				tflog.Error(ctx, "test omitted message")
+			},
+			expectedEvents: nil,
+			expectedErrors: nil,
+		},
+		"subsystem-inherited": {
+			log: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem)
+
+				tflog.SubsystemWarn(ctx, testSubsystem, "test message")
+			},
+			expectedEvents: []tflog.SpanEvent{
+				{
+					Level:      hclog.Warn,
+					LoggerName: testSubsystemModule,
+					Message:    "test message",
+					Fields:     map[string]interface{}{},
+				},
+			},
+			expectedErrors: nil,
+		},
+		"subsystem-hook-masked": {
+			log: func(ctx context.Context) {
+				hook := tflog.EntryHookFunc(func(_ context.Context, entry tflog.Entry) (tflog.Entry, bool) {
+					entry.Message += " with hook-secret"
+					entry.Fields["token"] = "hook-secret"
+
+					return entry, true
+				})
+
+				ctx = tflog.NewSubsystem(ctx, testSubsystem,
+					tflog.WithEntryHooks(hook),
+					tflog.WithMaskFieldValuesWithFieldKeys("token"),
+					tflog.WithMaskMessageStrings("hook-secret"),
+				)
+
+				tflog.SubsystemError(ctx, testSubsystem, "test message")
+			},
+			expectedEvents: []tflog.SpanEvent{
+				{
+					Level:      hclog.Error,
+					LoggerName: testSubsystemModule,
+					Message:    "test message with ***",
+					Fields: map[string]interface{}{
+						"token": "***",
+					},
+				},
+			},
+			expectedErrors: []string{"test message with ***"},
+		},
+		"subsystem-level": {
+			log: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, testSubsystem, tflog.WithLevel(hclog.Warn))
+
+				tflog.SubsystemInfo(ctx, testSubsystem, "test message")
+			},
+			expectedEvents: nil,
+			expectedErrors: nil,
+		},
+		"logger-handle": {
+			log: func(ctx context.Context) {
+				tflog.Logger(ctx).With("k1", "v1").Info("test message")
+			},
+			expectedEvents: []tflog.SpanEvent{
+				{
+					Level:      hclog.Info,
+					LoggerName: "provider",
+					Message:    "test message",
+					Fields: map[string]interface{}{
+						"k1": "v1",
+					},
+				},
+			},
+			expectedErrors: nil,
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			bridge := &tfsdklogtest.SpanEventBridge{}
+
+			ctx := tfsdklog.NewRootProviderLogger(
+				context.Background(),
+				logging.WithoutLocation(),
+				logging.WithoutTimestamp(),
+				logging.WithOutput(&outputBuffer),
+				tfsdklog.WithSpanEventBridge(bridge),
+			)
+
+			testCase.log(ctx)
+
+			if diff := cmp.Diff(testCase.expectedEvents, bridge.Events()); diff != "" {
+				t.Errorf("unexpected events difference: %s", diff)
+			}
+
+			if diff := cmp.Diff(testCase.expectedErrors, bridge.Errors()); diff != "" {
+				t.Errorf("unexpected errors difference: %s", diff)
+			}
+		})
+	}
+}
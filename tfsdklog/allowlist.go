@@ -0,0 +1,14 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog
+
+import (
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+// RemovedFieldsKey is the field key listing the sorted keys of the fields
+// removed from log output by a logger configured with WithFieldAllowlist or
+// WithFieldAllowlistMasked. It is only included in log output with removed
+// fields.
+const RemovedFieldsKey = logging.RemovedFieldsKey
@@ -0,0 +1,281 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+func TestWithFieldAllowlist(t *testing.T) {
+	t.Parallel()
+
+	fields := map[string]interface{}{
+		"k1": "v1",
+		"k2": "v2",
+		"k3": map[string]interface{}{
+			"nested": "v3",
+		},
+	}
+
+	testCases := map[string]struct {
+		rootOptions    []logging.Option
+		logImpl        func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"sdk-root-drop": {
+			rootOptions: []logging.Option{
+				tfsdklog.WithFieldAllowlist("k1"),
+			},
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.SetField(ctx, "root", "test-root")
+
+				tfsdklog.Debug(ctx, "test message", fields)
+				tfsdklog.Debug(ctx, "test allowed message", map[string]interface{}{"k1": "v1"})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                  "debug",
+					"@message":                "test message",
+					"@module":                 "sdk",
+					"k1":                      "v1",
+					tfsdklog.RemovedFieldsKey: []interface{}{"k2", "k3", "root"},
+				},
+				{
+					"@level":                  "debug",
+					"@message":                "test allowed message",
+					"@module":                 "sdk",
+					"k1":                      "v1",
+					tfsdklog.RemovedFieldsKey: []interface{}{"root"},
+				},
+			},
+		},
+		"sdk-root-mask": {
+			rootOptions: []logging.Option{
+				tfsdklog.WithFieldAllowlistMasked("k1"),
+			},
+			logImpl: func(ctx context.Context) {
+				tfsdklog.Debug(ctx, "test message", fields)
+				tfsdklog.Debug(ctx, "test allowed message", map[string]interface{}{"k1": "v1"})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                  "debug",
+					"@message":                "test message",
+					"@module":                 "sdk",
+					"k1":                      "v1",
+					"k2":                      "***",
+					"k3":                      "***",
+					tfsdklog.RemovedFieldsKey: []interface{}{"k2", "k3"},
+				},
+				{
+					"@level":   "debug",
+					"@message": "test allowed message",
+					"@module":  "sdk",
+					"k1":       "v1",
+				},
+			},
+		},
+		"sdk-root-empty": {
+			rootOptions: []logging.Option{
+				tfsdklog.WithFieldAllowlist(),
+			},
+			logImpl: func(ctx context.Context) {
+				tfsdklog.Debug(ctx, "test message", fields)
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                  "debug",
+					"@message":                "test message",
+					"@module":                 "sdk",
+					tfsdklog.RemovedFieldsKey: []interface{}{"k1", "k2", "k3"},
+				},
+			},
+		},
+		"sdk-root-omit-and-mask": {
+			rootOptions: []logging.Option{
+				tfsdklog.WithFieldAllowlist("k1"),
+			},
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.OmitLogWithFieldKeys(ctx, "k4")
+				ctx = tfsdklog.MaskFieldValuesWithFieldKeys(ctx, "k1")
+
+				tfsdklog.Debug(ctx, "test omitted message", map[string]interface{}{"k4": "v4"})
+				tfsdklog.Debug(ctx, "test message", fields)
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                  "debug",
+					"@message":                "test message",
+					"@module":                 "sdk",
+					"k1":                      "***",
+					tfsdklog.RemovedFieldsKey: []interface{}{"k2", "k3"},
+				},
+			},
+		},
+		"sdk-subsystem": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem, tfsdklog.WithFieldAllowlist("k2"))
+
+				tfsdklog.SubsystemDebug(ctx, testSubsystem, "test subsystem message", fields)
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{"k1": "v1"})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                  "debug",
+					"@message":                "test subsystem message",
+					"@module":                 testSubsystemModule,
+					"k2":                      "v2",
+					tfsdklog.RemovedFieldsKey: []interface{}{"k1", "k3"},
+				},
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "sdk",
+					"k1":       "v1",
+				},
+			},
+		},
+		"sdk-subsystem-inherited-without-apply-rules": {
+			rootOptions: []logging.Option{
+				tfsdklog.WithFieldAllowlist("k1"),
+			},
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem)
+
+				tfsdklog.SubsystemDebug(ctx, testSubsystem, "test subsystem message", fields)
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                  "debug",
+					"@message":                "test subsystem message",
+					"@module":                 testSubsystemModule,
+					"k1":                      "v1",
+					tfsdklog.RemovedFieldsKey: []interface{}{"k2", "k3"},
+				},
+			},
+		},
+		"sdk-correlation-fields": {
+			rootOptions: []logging.Option{
+				tfsdklog.WithFieldAllowlist("k1"),
+				tfsdklog.WithTraceContextExtractor(loggertest.TraceContextExtractor{}),
+			},
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.SetField(ctx, tfsdklog.RequestIDFieldKey, "test-request-id")
+				ctx = loggertest.ContextWithSpan(ctx, loggertest.Span{TraceID: "test-trace-id", SpanID: "test-span-id"})
+
+				tfsdklog.Debug(ctx, "test message", fields)
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                  "debug",
+					"@message":                "test message",
+					"@module":                 "sdk",
+					"k1":                      "v1",
+					"span_id":                 "test-span-id",
+					"tf_req_id":               "test-request-id",
+					"trace_id":                "test-trace-id",
+					tfsdklog.RemovedFieldsKey: []interface{}{"k2", "k3"},
+				},
+			},
+		},
+		"sdk-subsystem-inherited": {
+			rootOptions: []logging.Option{
+				tfsdklog.WithFieldAllowlist("k1", "k2"),
+			},
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.ApplyRulesToSubsystems(ctx)
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem, tfsdklog.WithFieldAllowlistMasked("k2", "k3"))
+
+				tfsdklog.SubsystemDebug(ctx, testSubsystem, "test subsystem message", fields)
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                  "debug",
+					"@message":                "test subsystem message",
+					"@module":                 testSubsystemModule,
+					"k2":                      "v2",
+					tfsdklog.RemovedFieldsKey: []interface{}{"k1", "k3"},
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			rootOptions := append([]logging.Option{
+				logging.WithoutLocation(),
+				logging.WithoutTimestamp(),
+				logging.WithOutput(&outputBuffer),
+			}, testCase.rootOptions...)
+
+			ctx := context.Background()
+			ctx = tfsdklog.NewRootSDKLogger(ctx, rootOptions...)
+
+			testCase.logImpl(ctx)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestWithFieldAllowlist_ProviderRoot(t *testing.T) {
+	t.Parallel()
+
+	var outputBuffer bytes.Buffer
+
+	ctx := context.Background()
+	ctx = tfsdklog.NewRootProviderLogger(
+		ctx,
+		logging.WithoutLocation(),
+		logging.WithoutTimestamp(),
+		logging.WithOutput(&outputBuffer),
+		tfsdklog.WithFieldAllowlist("k1"),
+	)
+
+	tflog.Debug(ctx, "test message", map[string]interface{}{
+		"k1": "v1",
+		"k2": "v2",
+	})
+
+	got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+	if err != nil {
+		t.Fatalf("unable to read multiple line JSON: %s", err)
+	}
+
+	expectedOutput := []map[string]interface{}{
+		{
+			"@level":               "debug",
+			"@message":             "test message",
+			"@module":              "provider",
+			"k1":                   "v1",
+			tflog.RemovedFieldsKey: []interface{}{"k2"},
+		},
+	}
+
+	if diff := cmp.Diff(expectedOutput, got); diff != "" {
+		t.Errorf("unexpected output difference: %s", diff)
+	}
+}
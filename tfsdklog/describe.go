@@ -0,0 +1,49 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+// Description is a read-only summary of the configuration of a logger, as
+// returned by Describe and SubsystemDescribe. It reports the logger name,
+// effective level, fields, registered subsystems, including the ones
+// automatically created, and the omit and mask rules of the logger.
+//
+// Field values are reported after masking, and strings configured for
+// masking are only reported as counts, so that a Description can be safely
+// logged or printed.
+type Description = logging.Description
+
+// Describe returns the Description of the SDK root logger in `ctx`.
+// It is meant for debugging and for asserting logger configuration in tests.
+//
+// If no root logger is found in `ctx`, the zero value is returned.
+func Describe(ctx context.Context) Description {
+	logger := logging.GetSDKRootLogger(ctx)
+	if logger == nil {
+		return Description{}
+	}
+
+	return logging.NewDescription(logger, logging.GetSDKRootTFLoggerOpts(ctx), logging.GetSDKSubsystemRegistry(ctx))
+}
+
+// SubsystemDescribe returns the Description of the subsystem logger
+// specified in `ctx`. It is meant for debugging and for asserting logger
+// configuration in tests. The reported omit and mask rules include the ones
+// inherited from the root logger.
+//
+// If the subsystem logger was not created with NewSubsystem, the zero value
+// is returned.
+func SubsystemDescribe(ctx context.Context, subsystem string) Description {
+	logger := logging.GetSDKSubsystemLogger(ctx, subsystem)
+	if logger == nil {
+		return Description{}
+	}
+
+	return logging.NewDescription(logger, logging.GetSDKSubsystemEffectiveTFLoggerOpts(ctx, subsystem), nil)
+}
@@ -0,0 +1,194 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog_test
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		setup    func(context.Context) context.Context
+		expected tfsdklog.Description
+	}{
+		"no-root-logger": {
+			setup: func(_ context.Context) context.Context {
+				return context.Background()
+			},
+			expected: tfsdklog.Description{},
+		},
+		"no-configuration": {
+			setup: func(ctx context.Context) context.Context {
+				return ctx
+			},
+			expected: tfsdklog.Description{
+				Name:                  "sdk",
+				Level:                 hclog.Trace,
+				Subsystems:            []string{},
+				AutoCreatedSubsystems: []string{},
+			},
+		},
+		"fields-masked": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tfsdklog.SetField(ctx, "k1", "v1")
+				ctx = tfsdklog.SetField(ctx, "k2", "secret-v2")
+				ctx = tfsdklog.MaskFieldValuesWithFieldKeys(ctx, "k2")
+
+				return ctx
+			},
+			expected: tfsdklog.Description{
+				Name:  "sdk",
+				Level: hclog.Trace,
+				Fields: map[string]interface{}{
+					"k1": "v1",
+					"k2": "***",
+				},
+				Subsystems:                   []string{},
+				AutoCreatedSubsystems:        []string{},
+				MaskFieldValuesWithFieldKeys: []string{"k2"},
+			},
+		},
+		"rules": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tfsdklog.OmitLogWithFieldKeys(ctx, "k1")
+				ctx = tfsdklog.OmitLogWithMessageRegexes(ctx, regexp.MustCompile("foo"))
+				ctx = tfsdklog.OmitLogWithMessageStrings(ctx, "bar")
+				ctx = tfsdklog.MaskAllFieldValuesRegexes(ctx, regexp.MustCompile("baz"))
+				ctx = tfsdklog.MaskAllFieldValuesStrings(ctx, "secret1", "secret2")
+				ctx = tfsdklog.MaskMessageRegexes(ctx, regexp.MustCompile("qux"))
+				ctx = tfsdklog.MaskMessageStrings(ctx, "secret3")
+
+				return ctx
+			},
+			expected: tfsdklog.Description{
+				Name:                           "sdk",
+				Level:                          hclog.Trace,
+				Subsystems:                     []string{},
+				AutoCreatedSubsystems:          []string{},
+				OmitLogWithFieldKeys:           []string{"k1"},
+				OmitLogWithMessageRegexes:      []string{"foo"},
+				OmitLogWithMessageStrings:      []string{"bar"},
+				MaskAllFieldValuesRegexes:      []string{"baz"},
+				MaskAllFieldValuesStringsCount: 2,
+				MaskMessageRegexes:             []string{"qux"},
+				MaskMessageStringsCount:        1,
+			},
+		},
+		"subsystems": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tfsdklog.NewSubsystem(ctx, "sub2")
+				ctx = tfsdklog.NewSubsystem(ctx, "sub1")
+
+				return ctx
+			},
+			expected: tfsdklog.Description{
+				Name:                  "sdk",
+				Level:                 hclog.Trace,
+				Subsystems:            []string{"sub1", "sub2"},
+				AutoCreatedSubsystems: []string{},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.SDKRoot(ctx, &outputBuffer)
+			ctx = testCase.setup(ctx)
+
+			got := tfsdklog.Describe(ctx)
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSubsystemDescribe(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		setup    func(context.Context) context.Context
+		expected tfsdklog.Description
+	}{
+		"no-subsystem-logger": {
+			setup: func(ctx context.Context) context.Context {
+				return ctx
+			},
+			expected: tfsdklog.Description{},
+		},
+		"no-configuration": {
+			setup: func(ctx context.Context) context.Context {
+				return tfsdklog.NewSubsystem(ctx, testSubsystem)
+			},
+			expected: tfsdklog.Description{
+				Name:  testSubsystemModule,
+				Level: hclog.Trace,
+			},
+		},
+		"level": {
+			setup: func(ctx context.Context) context.Context {
+				return tfsdklog.NewSubsystem(ctx, testSubsystem, tfsdklog.WithLevel(hclog.Warn))
+			},
+			expected: tfsdklog.Description{
+				Name:  testSubsystemModule,
+				Level: hclog.Warn,
+			},
+		},
+		"fields-and-rules": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tfsdklog.SetField(ctx, "root-key", "root-value")
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem, tfsdklog.WithRootFields())
+				ctx = tfsdklog.SubsystemSetField(ctx, testSubsystem, "sub-key", "sub-value")
+				ctx = tfsdklog.SubsystemMaskAllFieldValuesStrings(ctx, testSubsystem, "root-value")
+
+				return ctx
+			},
+			expected: tfsdklog.Description{
+				Name:  testSubsystemModule,
+				Level: hclog.Trace,
+				Fields: map[string]interface{}{
+					"root-key": "***",
+					"sub-key":  "sub-value",
+				},
+				MaskAllFieldValuesStringsCount: 1,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.SDKRoot(ctx, &outputBuffer)
+			ctx = testCase.setup(ctx)
+
+			got := tfsdklog.SubsystemDescribe(ctx, testSubsystem)
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+func TestMaskAllFieldValues_ValueKinds(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		logImpl        func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"strings": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.MaskAllFieldValuesStrings(ctx, "secret", "4242")
+
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{
+					"bytes":    []byte("body secret"),
+					"error":    fmt.Errorf("reading instance: %w", errors.New("secret leaked")),
+					"stringer": &url.URL{Scheme: "https", Host: "example.com", RawQuery: "key=secret"},
+					"int":      4242,
+					"uint64":   uint64(14242),
+					"float64":  4242.5,
+					"bool":     true,
+					"other":    42,
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "sdk",
+					"bytes":    "body ***",
+					"error":    "reading instance: *** leaked",
+					"stringer": "https://example.com?key=***",
+					"int":      "***",
+					"uint64":   "1***",
+					"float64":  "***.5",
+					"bool":     true,
+					"other":    float64(42),
+				},
+			},
+		},
+		"regexes": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.MaskAllFieldValuesRegexes(ctx, regexp.MustCompile(`token=\w+`))
+
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{
+					"error":     errors.New("request failed: token=abc123"),
+					"unchanged": errors.New("request failed"),
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":    "debug",
+					"@message":  "test message",
+					"@module":   "sdk",
+					"error":     "request failed: ***",
+					"unchanged": "request failed",
+				},
+			},
+		},
+		"subsystem": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem)
+				ctx = tfsdklog.SubsystemMaskAllFieldValuesStrings(ctx, testSubsystem, "secret")
+
+				tfsdklog.SubsystemDebug(ctx, testSubsystem, "test message", map[string]interface{}{
+					"error": errors.New("secret leaked"),
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  testSubsystemModule,
+					"error":    "*** leaked",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			loggertest.CheckOutput(t, loggertest.SDKRoot, testCase.logImpl, testCase.expectedOutput)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog
+
+import (
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+// Entry is a log entry passed to an EntryHook, holding its level, the name of
+// the logger writing it, and its masked message and fields.
+type Entry = logging.Entry
+
+// EntryHook processes log entries after the omit and mask rules of the logger
+// have been applied, before they are written. Hooks can enrich or transform
+// entries, drop them, or send them to a side channel, such as for metrics or
+// auditing. Hooks are only called for entries that would be written by the
+// logger at its level. The mask rules of the logger are applied again to the
+// message and fields of the entries returned by hooks.
+//
+// EntryHooks are registered with the WithEntryHooks option of
+// NewRootSDKLogger, NewRootProviderLogger and NewSubsystem. Subsystem loggers
+// run their own EntryHooks first, followed by the ones of their root logger.
+type EntryHook = logging.EntryHook
+
+// EntryHookFunc is a function implementing EntryHook.
+type EntryHookFunc = logging.EntryHookFunc
@@ -0,0 +1,248 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+// recordingEntryHook is an EntryHook recording the entries it processes,
+// prefixed with its name, without changing them.
+func recordingEntryHook(name string, recorded *[]string) tfsdklog.EntryHook {
+	return tfsdklog.EntryHookFunc(func(_ context.Context, entry tfsdklog.Entry) (tfsdklog.Entry, bool) {
+		*recorded = append(*recorded, name+": "+entry.LoggerName+": "+entry.Message)
+
+		return entry, true
+	})
+}
+
+func TestWithEntryHooks(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		rootHooks        func(*[]string) []tfsdklog.EntryHook
+		log              func(context.Context, *[]string)
+		expectedRecorded []string
+		expectedOutput   []map[string]interface{}
+	}{
+		"no-hooks": {
+			log: func(ctx context.Context, _ *[]string) {
+				tfsdklog.Trace(ctx, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "sdk",
+				},
+			},
+		},
+		"enrich": {
+			rootHooks: func(_ *[]string) []tfsdklog.EntryHook {
+				return []tfsdklog.EntryHook{
+					tfsdklog.EntryHookFunc(func(_ context.Context, entry tfsdklog.Entry) (tfsdklog.Entry, bool) {
+						entry.Fields["level_string"] = entry.Level.String()
+
+						return entry, true
+					}),
+				}
+			},
+			log: func(ctx context.Context, _ *[]string) {
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{"k1": "v1"})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":       "debug",
+					"@message":     "test message",
+					"@module":      "sdk",
+					"k1":           "v1",
+					"level_string": "debug",
+				},
+			},
+		},
+		"transform": {
+			rootHooks: func(_ *[]string) []tfsdklog.EntryHook {
+				return []tfsdklog.EntryHook{
+					tfsdklog.EntryHookFunc(func(_ context.Context, entry tfsdklog.Entry) (tfsdklog.Entry, bool) {
+						entry.Message = "transformed: " + entry.Message
+						entry.Fields = map[string]interface{}{"replaced": true}
+
+						return entry, true
+					}),
+				}
+			},
+			log: func(ctx context.Context, _ *[]string) {
+				tfsdklog.Info(ctx, "test message", map[string]interface{}{"k1": "v1"})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "info",
+					"@message": "transformed: test message",
+					"@module":  "sdk",
+					"replaced": true,
+				},
+			},
+		},
+		"drop": {
+			rootHooks: func(recorded *[]string) []tfsdklog.EntryHook {
+				return []tfsdklog.EntryHook{
+					tfsdklog.EntryHookFunc(func(_ context.Context, entry tfsdklog.Entry) (tfsdklog.Entry, bool) {
+						return entry, entry.Message != "dropped message"
+					}),
+					recordingEntryHook("after-drop", recorded),
+				}
+			},
+			log: func(ctx context.Context, _ *[]string) {
+				tfsdklog.Warn(ctx, "dropped message")
+				tfsdklog.Warn(ctx, "kept message")
+			},
+			expectedRecorded: []string{
+				"after-drop: sdk: kept message",
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "warn",
+					"@message": "kept message",
+					"@module":  "sdk",
+				},
+			},
+		},
+		"masked-and-omitted": {
+			rootHooks: func(recorded *[]string) []tfsdklog.EntryHook {
+				return []tfsdklog.EntryHook{
+					recordingEntryHook("root", recorded),
+				}
+			},
+			log: func(ctx context.Context, _ *[]string) {
+				ctx = tfsdklog.MaskMessageStrings(ctx, "secret")
+				ctx = tfsdklog.OmitLogWithMessageStrings(ctx, "omitted")
+
+				tfsdklog.Error(ctx, "test secret message")
+				tfsdklog.Error(ctx, "test omitted message")
+			},
+			expectedRecorded: []string{
+				"root: sdk: test *** message",
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "error",
+					"@message": "test *** message",
+					"@module":  "sdk",
+				},
+			},
+		},
+		"hook-added-values-masked": {
+			rootHooks: func(_ *[]string) []tfsdklog.EntryHook {
+				return []tfsdklog.EntryHook{
+					tfsdklog.EntryHookFunc(func(_ context.Context, entry tfsdklog.Entry) (tfsdklog.Entry, bool) {
+						entry.Message += " with hook-secret"
+						entry.Fields["password"] = "hook-secret"
+						entry.Fields["token"] = tfsdklog.Sensitive("hook-token")
+
+						return entry, true
+					}),
+				}
+			},
+			log: func(ctx context.Context, _ *[]string) {
+				ctx = tfsdklog.MaskFieldValuesWithFieldKeys(ctx, "password")
+				ctx = tfsdklog.MaskMessageStrings(ctx, "hook-secret")
+
+				tfsdklog.Debug(ctx, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message with ***",
+					"@module":  "sdk",
+					"password": "***",
+					"token":    "***",
+				},
+			},
+		},
+		"subsystem": {
+			rootHooks: func(recorded *[]string) []tfsdklog.EntryHook {
+				return []tfsdklog.EntryHook{
+					recordingEntryHook("root", recorded),
+				}
+			},
+			log: func(ctx context.Context, recorded *[]string) {
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem, tfsdklog.WithEntryHooks(recordingEntryHook("subsystem", recorded)))
+
+				tfsdklog.SubsystemTrace(ctx, testSubsystem, "test message")
+			},
+			expectedRecorded: []string{
+				"subsystem: " + testSubsystemModule + ": test message",
+				"root: " + testSubsystemModule + ": test message",
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  testSubsystemModule,
+				},
+			},
+		},
+		"subsystem-level": {
+			rootHooks: func(recorded *[]string) []tfsdklog.EntryHook {
+				return []tfsdklog.EntryHook{
+					recordingEntryHook("root", recorded),
+				}
+			},
+			log: func(ctx context.Context, _ *[]string) {
+				ctx = tfsdklog.NewSubsystem(ctx, "test_subsystem_hooks_level", tfsdklog.WithLevel(hclog.Error))
+
+				tfsdklog.SubsystemWarn(ctx, "test_subsystem_hooks_level", "test message")
+			},
+			expectedRecorded: nil,
+			expectedOutput:   nil,
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+			var recorded []string
+			var rootHooks []tfsdklog.EntryHook
+
+			if testCase.rootHooks != nil {
+				rootHooks = testCase.rootHooks(&recorded)
+			}
+
+			ctx := tfsdklog.NewRootSDKLogger(
+				context.Background(),
+				logging.WithoutLocation(),
+				logging.WithoutTimestamp(),
+				logging.WithOutput(&outputBuffer),
+				tfsdklog.WithEntryHooks(rootHooks...),
+			)
+
+			testCase.log(ctx, &recorded)
+
+			if diff := cmp.Diff(testCase.expectedRecorded, recorded); diff != "" {
+				t.Errorf("unexpected recorded difference: %s", diff)
+			}
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
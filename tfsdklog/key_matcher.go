@@ -0,0 +1,44 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog
+
+import (
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+// FieldKeyMatcher matches field keys by a pattern, for omit and mask rules
+// which should apply to more than an exact field key. For example, a mask for
+// the "password" field key does not apply to the "Password", "db_password" or
+// "password_hash" field keys, while a mask for the
+// FieldKeyGlob("*password*").CaseInsensitive() matcher applies to all of them.
+//
+// Matching a field key does not allocate, so matchers stay fast even when
+// applied to every field of every log.
+type FieldKeyMatcher = logging.FieldKeyMatcher
+
+// FieldKeyEqualFold returns a FieldKeyMatcher matching field keys equal to
+// `key` under Unicode case-folding, e.g. "Password" for "password".
+func FieldKeyEqualFold(key string) FieldKeyMatcher {
+	return logging.NewFieldKeyEqualFold(key)
+}
+
+// FieldKeyPrefix returns a FieldKeyMatcher matching field keys starting with
+// `prefix`. Use its CaseInsensitive method to ignore case.
+func FieldKeyPrefix(prefix string) FieldKeyMatcher {
+	return logging.NewFieldKeyPrefix(prefix)
+}
+
+// FieldKeySuffix returns a FieldKeyMatcher matching field keys ending with
+// `suffix`. Use its CaseInsensitive method to ignore case.
+func FieldKeySuffix(suffix string) FieldKeyMatcher {
+	return logging.NewFieldKeySuffix(suffix)
+}
+
+// FieldKeyGlob returns a FieldKeyMatcher matching complete field keys against
+// the glob `pattern`, where `*` matches any sequence of characters and `?`
+// matches any single character, e.g. "*_token" or "password*". Use its
+// CaseInsensitive method to ignore case.
+func FieldKeyGlob(pattern string) FieldKeyMatcher {
+	return logging.NewFieldKeyGlob(pattern)
+}
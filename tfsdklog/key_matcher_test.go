@@ -0,0 +1,153 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+func TestFieldKeyMatchers(t *testing.T) {
+	t.Parallel()
+
+	fields := map[string]interface{}{
+		"Password":      "test-password",
+		"db_password":   "test-db-password",
+		"password_hash": "test-hash",
+		"debug_body":    "test-body",
+		"user":          "test-user",
+	}
+
+	testCases := map[string]struct {
+		logImpl        func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"mask": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.MaskFieldValuesWithFieldKeyMatchers(ctx, tfsdklog.FieldKeyGlob("*password*").CaseInsensitive(), tfsdklog.FieldKeyPrefix("debug_"))
+
+				tfsdklog.Debug(ctx, "test message", fields)
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":        "debug",
+					"@message":      "test message",
+					"@module":       "sdk",
+					"Password":      "***",
+					"db_password":   "***",
+					"password_hash": "***",
+					"debug_body":    "***",
+					"user":          "test-user",
+				},
+			},
+		},
+		"mask-equal-fold-suffix": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.MaskFieldValuesWithFieldKeyMatchers(ctx, tfsdklog.FieldKeyEqualFold("PASSWORD"), tfsdklog.FieldKeySuffix("_hash"))
+
+				tfsdklog.Debug(ctx, "test message", fields)
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":        "debug",
+					"@message":      "test message",
+					"@module":       "sdk",
+					"Password":      "***",
+					"db_password":   "test-db-password",
+					"password_hash": "***",
+					"debug_body":    "test-body",
+					"user":          "test-user",
+				},
+			},
+		},
+		"omit": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.OmitLogWithFieldKeyMatchers(ctx, tfsdklog.FieldKeyPrefix("debug_"))
+
+				tfsdklog.Debug(ctx, "test omitted message", fields)
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{
+					"user": "test-user",
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "sdk",
+					"user":     "test-user",
+				},
+			},
+		},
+		"subsystem": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem)
+				ctx = tfsdklog.SubsystemMaskFieldValuesWithFieldKeyMatchers(ctx, testSubsystem, tfsdklog.FieldKeyGlob("*password*").CaseInsensitive())
+				ctx = tfsdklog.SubsystemOmitLogWithFieldKeyMatchers(ctx, testSubsystem, tfsdklog.FieldKeySuffix("_body"))
+
+				tfsdklog.SubsystemDebug(ctx, testSubsystem, "test omitted message", fields)
+				tfsdklog.SubsystemDebug(ctx, testSubsystem, "test message", map[string]interface{}{
+					"DB_PASSWORD": "test-db-password",
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":      "debug",
+					"@message":    "test message",
+					"@module":     testSubsystemModule,
+					"DB_PASSWORD": "***",
+				},
+			},
+		},
+		"subsystem-options": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem,
+					tfsdklog.WithMaskFieldValuesWithFieldKeyMatchers(tfsdklog.FieldKeyGlob("*password*").CaseInsensitive()),
+					tfsdklog.WithOmitLogWithFieldKeyMatchers(tfsdklog.FieldKeySuffix("_body")),
+				)
+
+				tfsdklog.SubsystemDebug(ctx, testSubsystem, "test omitted message", fields)
+				tfsdklog.SubsystemDebug(ctx, testSubsystem, "test message", map[string]interface{}{
+					"DB_PASSWORD": "test-db-password",
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":      "debug",
+					"@message":    "test message",
+					"@module":     testSubsystemModule,
+					"DB_PASSWORD": "***",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.SDKRoot(ctx, &outputBuffer)
+
+			testCase.logImpl(ctx)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
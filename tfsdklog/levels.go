@@ -60,6 +60,18 @@ func subsystemWouldLog(subsystem string, level hclog.Level) bool {
 	return wouldLog(setLevel, level)
 }
 
+// subsystemLevelCached returns true if the level of the subsystem SDK logger
+// was cached by NewSubsystem.
+func subsystemLevelCached(subsystem string) bool {
+	subsystemLevelsMutex.RLock()
+
+	_, ok := subsystemLevels[subsystem]
+
+	subsystemLevelsMutex.RUnlock()
+
+	return ok
+}
+
 // rootWouldLog returns true if the root SDK logger would emit a log at the
 // given level. This is performed outside the context-based logger for
 // performance.
@@ -0,0 +1,186 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+// LoggerHandle is a logger resolved from a context.Context, either the
+// SDK root logger with Logger or a subsystem logger with SubsystemLogger.
+// The logger and its fields and omit and mask rules are looked up in the
+// context.Context once, rather than on every call like the package-level
+// logging functions, which is beneficial when logging multiple times with a
+// deeply nested context.Context.
+//
+// Logging with a LoggerHandle has the same semantics as the package-level
+// logging functions, including masking and omission. Changes made to the
+// context.Context after the LoggerHandle is resolved, e.g. with SetField, are
+// not reflected in the LoggerHandle.
+//
+// The zero value, as well as a LoggerHandle resolved from a context.Context
+// without a root logger, does not log anything.
+type LoggerHandle struct {
+	// ctx is the context.Context the LoggerHandle was resolved from, used to
+	// extract the IDs of the active trace and span.
+	ctx context.Context
+
+	logger hclog.Logger
+	lOpts  logging.LoggerOpts
+
+	// subsystem is the name of the subsystem logger, if any, to check the
+	// cached subsystem logger level before logging.
+	subsystem string
+}
+
+// Logger returns a LoggerHandle for the SDK root logger in `ctx`.
+func Logger(ctx context.Context) LoggerHandle {
+	logger := logging.GetSDKRootLogger(ctx)
+	if logger == nil {
+		// this essentially should never happen in production
+		// the root logger should be injected by the SDK,
+		// so really this is only likely in unit tests, at most
+		// so just making this a no-op is fine
+		return LoggerHandle{}
+	}
+
+	return LoggerHandle{
+		ctx:    ctx,
+		logger: logger,
+		lOpts:  logging.GetSDKRootTFLoggerOpts(ctx),
+	}
+}
+
+// SubsystemLogger returns a LoggerHandle for the subsystem logger specified
+// in `ctx`. As with the package-level subsystem logging functions, the
+// subsystem logger is automatically created if it was not created with
+// NewSubsystem, unless no subsystem logger with that name was ever created,
+// in which case the returned LoggerHandle does not log anything.
+func SubsystemLogger(ctx context.Context, subsystem string) LoggerHandle {
+	logger := logging.GetSDKSubsystemLogger(ctx, subsystem)
+	if logger == nil {
+		if logging.GetSDKRootLogger(ctx) == nil {
+			// logging isn't set up, nothing we can do, just silently fail
+			// this should basically never happen in production
+			return LoggerHandle{}
+		}
+
+		// the package-level subsystem logging functions do not log with
+		// subsystems without a cached level, so neither does the handle
+		if !subsystemLevelCached(subsystem) {
+			return LoggerHandle{}
+		}
+
+		// create a new logger if one doesn't exist
+		logger = autoCreateSubsystemLogger(ctx, subsystem)
+	}
+
+	return LoggerHandle{
+		ctx:       ctx,
+		logger:    logger,
+		lOpts:     logging.GetSDKSubsystemEffectiveTFLoggerOpts(ctx, subsystem),
+		subsystem: subsystem,
+	}
+}
+
+// With returns a copy of the LoggerHandle which will include key and value
+// as fields in all its log output.
+//
+// In case of the same key is used multiple times (i.e. key collision),
+// the last one set is the one that gets persisted and then outputted with the logs.
+func (h LoggerHandle) With(key string, value interface{}) LoggerHandle {
+	// Copy to prevent slice/map aliasing issues.
+	// Reference: https://github.com/hashicorp/terraform-plugin-log/issues/131
+	h.lOpts = logging.WithField(key, value)(h.lOpts.Copy())
+
+	return h
+}
+
+// Trace logs `msg` at the trace level with the LoggerHandle, with optional
+// `additionalFields` structured key-value fields in the log output. Fields are
+// shallow merged with any defined on the LoggerHandle, e.g. by the `With()`
+// method, and across multiple maps.
+func (h LoggerHandle) Trace(msg string, additionalFields ...map[string]interface{}) {
+	if h.logger == nil || (h.subsystem != "" && !subsystemWouldLog(h.subsystem, hclog.Trace)) {
+		return
+	}
+
+	additionalArgs, shouldOmit := logging.OmitOrMask(h.ctx, h.logger, hclog.Trace, h.lOpts, &msg, additionalFields)
+	if shouldOmit {
+		return
+	}
+
+	h.logger.Trace(msg, additionalArgs...)
+}
+
+// Debug logs `msg` at the debug level with the LoggerHandle, with optional
+// `additionalFields` structured key-value fields in the log output. Fields are
+// shallow merged with any defined on the LoggerHandle, e.g. by the `With()`
+// method, and across multiple maps.
+func (h LoggerHandle) Debug(msg string, additionalFields ...map[string]interface{}) {
+	if h.logger == nil || (h.subsystem != "" && !subsystemWouldLog(h.subsystem, hclog.Debug)) {
+		return
+	}
+
+	additionalArgs, shouldOmit := logging.OmitOrMask(h.ctx, h.logger, hclog.Debug, h.lOpts, &msg, additionalFields)
+	if shouldOmit {
+		return
+	}
+
+	h.logger.Debug(msg, additionalArgs...)
+}
+
+// Info logs `msg` at the info level with the LoggerHandle, with optional
+// `additionalFields` structured key-value fields in the log output. Fields are
+// shallow merged with any defined on the LoggerHandle, e.g. by the `With()`
+// method, and across multiple maps.
+func (h LoggerHandle) Info(msg string, additionalFields ...map[string]interface{}) {
+	if h.logger == nil || (h.subsystem != "" && !subsystemWouldLog(h.subsystem, hclog.Info)) {
+		return
+	}
+
+	additionalArgs, shouldOmit := logging.OmitOrMask(h.ctx, h.logger, hclog.Info, h.lOpts, &msg, additionalFields)
+	if shouldOmit {
+		return
+	}
+
+	h.logger.Info(msg, additionalArgs...)
+}
+
+// Warn logs `msg` at the warn level with the LoggerHandle, with optional
+// `additionalFields` structured key-value fields in the log output. Fields are
+// shallow merged with any defined on the LoggerHandle, e.g. by the `With()`
+// method, and across multiple maps.
+func (h LoggerHandle) Warn(msg string, additionalFields ...map[string]interface{}) {
+	if h.logger == nil || (h.subsystem != "" && !subsystemWouldLog(h.subsystem, hclog.Warn)) {
+		return
+	}
+
+	additionalArgs, shouldOmit := logging.OmitOrMask(h.ctx, h.logger, hclog.Warn, h.lOpts, &msg, additionalFields)
+	if shouldOmit {
+		return
+	}
+
+	h.logger.Warn(msg, additionalArgs...)
+}
+
+// Error logs `msg` at the error level with the LoggerHandle, with optional
+// `additionalFields` structured key-value fields in the log output. Fields are
+// shallow merged with any defined on the LoggerHandle, e.g. by the `With()`
+// method, and across multiple maps.
+func (h LoggerHandle) Error(msg string, additionalFields ...map[string]interface{}) {
+	if h.logger == nil || (h.subsystem != "" && !subsystemWouldLog(h.subsystem, hclog.Error)) {
+		return
+	}
+
+	additionalArgs, shouldOmit := logging.OmitOrMask(h.ctx, h.logger, hclog.Error, h.lOpts, &msg, additionalFields)
+	if shouldOmit {
+		return
+	}
+
+	h.logger.Error(msg, additionalArgs...)
+}
@@ -0,0 +1,309 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+func BenchmarkTraceDeepContext(b *testing.B) {
+	var outputBuffer bytes.Buffer
+
+	ctx := benchmarkDeepContext(loggertest.SDKRoot(context.Background(), &outputBuffer))
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		tfsdklog.Trace(ctx, "test message")
+	}
+}
+
+func BenchmarkLoggerTraceDeepContext(b *testing.B) {
+	var outputBuffer bytes.Buffer
+
+	ctx := benchmarkDeepContext(loggertest.SDKRoot(context.Background(), &outputBuffer))
+	logger := tfsdklog.Logger(ctx)
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		logger.Trace("test message")
+	}
+}
+
+func BenchmarkSubsystemTraceDeepContext(b *testing.B) {
+	var outputBuffer bytes.Buffer
+
+	ctx := loggertest.SDKRoot(context.Background(), &outputBuffer)
+	ctx = benchmarkDeepContext(tfsdklog.NewSubsystem(ctx, b.Name()))
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		tfsdklog.SubsystemTrace(ctx, b.Name(), "test message")
+	}
+}
+
+func BenchmarkSubsystemLoggerTraceDeepContext(b *testing.B) {
+	var outputBuffer bytes.Buffer
+
+	ctx := loggertest.SDKRoot(context.Background(), &outputBuffer)
+	ctx = benchmarkDeepContext(tfsdklog.NewSubsystem(ctx, b.Name()))
+	logger := tfsdklog.SubsystemLogger(ctx, b.Name())
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		logger.Trace("test message")
+	}
+}
+
+// benchmarkDeepContext wraps ctx with unrelated values, similar to the
+// context.Context of a request passing through many layers of an SDK.
+func benchmarkDeepContext(ctx context.Context) context.Context {
+	type benchmarkKey int
+
+	for i := 0; i < 50; i++ {
+		ctx = context.WithValue(ctx, benchmarkKey(i), i)
+	}
+
+	return ctx
+}
+
+func TestLogger(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		setup          func(context.Context) context.Context
+		log            func(tfsdklog.LoggerHandle)
+		expectedOutput []map[string]interface{}
+	}{
+		"no-root-logger": {
+			setup: func(_ context.Context) context.Context {
+				return context.Background()
+			},
+			log: func(logger tfsdklog.LoggerHandle) {
+				logger.Trace("test message")
+			},
+			expectedOutput: nil,
+		},
+		"levels": {
+			setup: func(ctx context.Context) context.Context {
+				return ctx
+			},
+			log: func(logger tfsdklog.LoggerHandle) {
+				logger.Trace("test trace")
+				logger.Debug("test debug")
+				logger.Info("test info")
+				logger.Warn("test warn")
+				logger.Error("test error")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test trace",
+					"@module":  "sdk",
+				},
+				{
+					"@level":   "debug",
+					"@message": "test debug",
+					"@module":  "sdk",
+				},
+				{
+					"@level":   "info",
+					"@message": "test info",
+					"@module":  "sdk",
+				},
+				{
+					"@level":   "warn",
+					"@message": "test warn",
+					"@module":  "sdk",
+				},
+				{
+					"@level":   "error",
+					"@message": "test error",
+					"@module":  "sdk",
+				},
+			},
+		},
+		"fields": {
+			setup: func(ctx context.Context) context.Context {
+				return tfsdklog.SetField(ctx, "k1", "v1")
+			},
+			log: func(logger tfsdklog.LoggerHandle) {
+				logger.With("k2", "v2").Trace("test message", map[string]interface{}{"k3": "v3"})
+				logger.Trace("test message without with")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "sdk",
+					"k1":       "v1",
+					"k2":       "v2",
+					"k3":       "v3",
+				},
+				{
+					"@level":   "trace",
+					"@message": "test message without with",
+					"@module":  "sdk",
+					"k1":       "v1",
+				},
+			},
+		},
+		"omit-and-mask": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tfsdklog.OmitLogWithMessageStrings(ctx, "omitted")
+				ctx = tfsdklog.MaskFieldValuesWithFieldKeys(ctx, "k1")
+				ctx = tfsdklog.MaskMessageStrings(ctx, "secret")
+
+				return ctx
+			},
+			log: func(logger tfsdklog.LoggerHandle) {
+				logger.Debug("test omitted message")
+				logger.With("k1", "v1").Debug("test secret message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test *** message",
+					"@module":  "sdk",
+					"k1":       "***",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.SDKRoot(ctx, &outputBuffer)
+			ctx = testCase.setup(ctx)
+
+			testCase.log(tfsdklog.Logger(ctx))
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSubsystemLogger(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		setup          func(context.Context, string) context.Context
+		log            func(tfsdklog.LoggerHandle)
+		expectedOutput func(string) []map[string]interface{}
+	}{
+		"level": {
+			setup: func(ctx context.Context, subsystem string) context.Context {
+				return tfsdklog.NewSubsystem(ctx, subsystem, tfsdklog.WithLevel(hclog.Warn))
+			},
+			log: func(logger tfsdklog.LoggerHandle) {
+				logger.Info("test info")
+				logger.Warn("test warn")
+			},
+			expectedOutput: func(subsystem string) []map[string]interface{} {
+				return []map[string]interface{}{
+					{
+						"@level":   "warn",
+						"@message": "test warn",
+						"@module":  "sdk." + subsystem,
+					},
+				}
+			},
+		},
+		"fields-and-mask": {
+			setup: func(ctx context.Context, subsystem string) context.Context {
+				ctx = tfsdklog.NewSubsystem(ctx, subsystem)
+				ctx = tfsdklog.SubsystemSetField(ctx, subsystem, "k1", "v1")
+				ctx = tfsdklog.SubsystemMaskAllFieldValuesStrings(ctx, subsystem, "v2")
+
+				return ctx
+			},
+			log: func(logger tfsdklog.LoggerHandle) {
+				logger.With("k2", "v2").Trace("test message")
+			},
+			expectedOutput: func(subsystem string) []map[string]interface{} {
+				return []map[string]interface{}{
+					{
+						"@level":   "trace",
+						"@message": "test message",
+						"@module":  "sdk." + subsystem,
+						"k1":       "v1",
+						"k2":       "***",
+					},
+				}
+			},
+		},
+		"inherited-rules": {
+			setup: func(ctx context.Context, subsystem string) context.Context {
+				ctx = tfsdklog.ApplyRulesToSubsystems(ctx)
+				ctx = tfsdklog.MaskMessageStrings(ctx, "secret")
+
+				return tfsdklog.NewSubsystem(ctx, subsystem)
+			},
+			log: func(logger tfsdklog.LoggerHandle) {
+				logger.Error("test secret message")
+			},
+			expectedOutput: func(subsystem string) []map[string]interface{} {
+				return []map[string]interface{}{
+					{
+						"@level":   "error",
+						"@message": "test *** message",
+						"@module":  "sdk." + subsystem,
+					},
+				}
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			subsystem := fmt.Sprintf("test_subsystem_logger_%s", name)
+
+			ctx := context.Background()
+			ctx = loggertest.SDKRoot(ctx, &outputBuffer)
+			ctx = testCase.setup(ctx, subsystem)
+
+			testCase.log(tfsdklog.SubsystemLogger(ctx, subsystem))
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput(subsystem), got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
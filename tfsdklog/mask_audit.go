@@ -0,0 +1,17 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog
+
+import (
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+// MaskedFieldKey is the field key of the masking audit metadata included in
+// log output by loggers configured with WithMaskAudit or WithMaskAuditRuleIDs.
+// Its value is an object with the sorted keys of the masked fields as
+// "fields", the number of replacements in the message as
+// "message_replacements" and, with WithMaskAuditRuleIDs, the sorted
+// identifiers of the rules which masked any value as "rules". It is only
+// included in log output with masked values.
+const MaskedFieldKey = logging.MaskedFieldKey
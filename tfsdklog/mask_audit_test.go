@@ -0,0 +1,169 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+func TestWithMaskAudit(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		rootOptions    []logging.Option
+		logImpl        func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"root": {
+			rootOptions: []logging.Option{
+				tfsdklog.WithMaskAudit(),
+			},
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.MaskFieldValuesWithFieldKeys(ctx, "password")
+				ctx = tfsdklog.MaskMessageStrings(ctx, "hunter2")
+
+				tfsdklog.Debug(ctx, "test message hunter2 hunter2", map[string]interface{}{
+					"password": "hunter2",
+					"user":     "test-user",
+				})
+				tfsdklog.Debug(ctx, "test unmasked message", map[string]interface{}{
+					"user": "test-user",
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message *** ***",
+					"@module":  "sdk",
+					"password": "***",
+					"user":     "test-user",
+					tfsdklog.MaskedFieldKey: map[string]interface{}{
+						"fields":               []interface{}{"password"},
+						"message_replacements": float64(2),
+					},
+				},
+				{
+					"@level":   "debug",
+					"@message": "test unmasked message",
+					"@module":  "sdk",
+					"user":     "test-user",
+				},
+			},
+		},
+		"root-rule-ids": {
+			rootOptions: []logging.Option{
+				tfsdklog.WithMaskAuditRuleIDs(),
+			},
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.MaskFieldValuesWithFieldKeys(ctx, "password")
+				ctx = tfsdklog.MaskAllFieldValuesStrings(ctx, "hunter2")
+
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{
+					"password": "hunter2",
+					"note":     "was hunter2",
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "sdk",
+					"note":     "was ***",
+					"password": "***",
+					tfsdklog.MaskedFieldKey: map[string]interface{}{
+						"fields": []interface{}{"note", "password"},
+						"rules": []interface{}{
+							"mask_all_field_values_strings[0]",
+							"mask_field_values_with_field_keys:password",
+						},
+					},
+				},
+			},
+		},
+		"subsystem": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem, tfsdklog.WithMaskAudit(), tfsdklog.WithMaskFieldValuesWithFieldKeys("password"))
+
+				tfsdklog.SubsystemDebug(ctx, testSubsystem, "test message", map[string]interface{}{
+					"password": "hunter2",
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  testSubsystemModule,
+					"password": "***",
+					tfsdklog.MaskedFieldKey: map[string]interface{}{
+						"fields": []interface{}{"password"},
+					},
+				},
+			},
+		},
+		"subsystem-inherited": {
+			rootOptions: []logging.Option{
+				tfsdklog.WithMaskAuditRuleIDs(),
+			},
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem, tfsdklog.WithMaskFieldValuesWithFieldKeys("password"))
+
+				tfsdklog.SubsystemDebug(ctx, testSubsystem, "test message", map[string]interface{}{
+					"password": tfsdklog.Sensitive("hunter2"),
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  testSubsystemModule,
+					"password": "***",
+					tfsdklog.MaskedFieldKey: map[string]interface{}{
+						"fields": []interface{}{"password"},
+						"rules": []interface{}{
+							"mask_field_values_with_field_keys:password",
+							"sensitive_value",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			rootOptions := append([]logging.Option{
+				logging.WithoutLocation(),
+				logging.WithoutTimestamp(),
+				logging.WithOutput(&outputBuffer),
+			}, testCase.rootOptions...)
+
+			ctx := context.Background()
+			ctx = tfsdklog.NewRootSDKLogger(ctx, rootOptions...)
+
+			testCase.logImpl(ctx)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
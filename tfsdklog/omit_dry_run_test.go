@@ -0,0 +1,130 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+func TestOmitDryRun(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		logImpl        func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"root": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.OmitLogWithMessageRegexes(ctx, regexp.MustCompile("^Polling"))
+				ctx = tfsdklog.OmitDryRun(ctx)
+
+				tfsdklog.Debug(ctx, "Polling status")
+				tfsdklog.Debug(ctx, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                   "debug",
+					"@message":                 "Polling status",
+					"@module":                  "sdk",
+					tfsdklog.WouldOmitFieldKey: "omit_log_with_message_regexes:^Polling",
+				},
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "sdk",
+				},
+			},
+		},
+		"root-rules-and-masking": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.OmitDryRun(ctx)
+				ctx = tfsdklog.OmitLogWithRules(ctx, tfsdklog.OmitRuleFieldValues("http_status", 200).BelowLevel(hclog.Info))
+				ctx = tfsdklog.MaskFieldValuesWithFieldKeys(ctx, "token")
+
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{
+					"http_status": 200,
+					"token":       "test-token",
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                   "debug",
+					"@message":                 "test message",
+					"@module":                  "sdk",
+					"http_status":              float64(200),
+					"token":                    "***",
+					tfsdklog.WouldOmitFieldKey: "omit_log_with_rules:field_values:http_status (below INFO)",
+				},
+			},
+		},
+		"subsystem": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem)
+				ctx = tfsdklog.SubsystemOmitLogWithFieldKeys(ctx, testSubsystem, "debug_body")
+				ctx = tfsdklog.SubsystemOmitDryRun(ctx, testSubsystem)
+				ctx = tfsdklog.OmitLogWithFieldKeys(ctx, "debug_body")
+
+				tfsdklog.SubsystemDebug(ctx, testSubsystem, "test subsystem message", map[string]interface{}{"debug_body": "test-body"})
+				tfsdklog.Debug(ctx, "test omitted message", map[string]interface{}{"debug_body": "test-body"})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                   "debug",
+					"@message":                 "test subsystem message",
+					"@module":                  testSubsystemModule,
+					"debug_body":               "test-body",
+					tfsdklog.WouldOmitFieldKey: "omit_log_with_field_keys:debug_body",
+				},
+			},
+		},
+		"subsystem-option": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem, tfsdklog.WithOmitDryRun(), tfsdklog.WithOmitLogWithMessageStrings("status"))
+
+				tfsdklog.SubsystemDebug(ctx, testSubsystem, "Polling status")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                   "debug",
+					"@message":                 "Polling status",
+					"@module":                  testSubsystemModule,
+					tfsdklog.WouldOmitFieldKey: "omit_log_with_message_strings:status",
+				},
+			},
+		},
+		"subsystem-inherited": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.OmitLogWithMessageStrings(ctx, "status")
+				ctx = tfsdklog.OmitDryRun(ctx)
+				ctx = tfsdklog.ApplyRulesToSubsystems(ctx)
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem)
+
+				tfsdklog.SubsystemDebug(ctx, testSubsystem, "Polling status")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                   "debug",
+					"@message":                 "Polling status",
+					"@module":                  testSubsystemModule,
+					tfsdklog.WouldOmitFieldKey: "omit_log_with_message_strings:status",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			loggertest.CheckOutput(t, loggertest.SDKRoot, testCase.logImpl, testCase.expectedOutput)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog_test
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+func TestOmitLogWithRules(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		logImpl        func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"field-values-below-level": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.OmitLogWithRules(ctx, tfsdklog.OmitRuleFieldValues("http_status", 200).BelowLevel(hclog.Info))
+
+				tfsdklog.Debug(ctx, "test omitted message", map[string]interface{}{"http_status": 200})
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{"http_status": 404})
+				tfsdklog.Info(ctx, "test info message", map[string]interface{}{"http_status": 200})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":      "debug",
+					"@message":    "test message",
+					"@module":     "sdk",
+					"http_status": float64(404),
+				},
+				{
+					"@level":      "info",
+					"@message":    "test info message",
+					"@module":     "sdk",
+					"http_status": float64(200),
+				},
+			},
+		},
+		"field-value-regexes": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.SetField(ctx, "path", "/health")
+				ctx = tfsdklog.OmitLogWithRules(ctx, tfsdklog.OmitRuleFieldValueRegexes("path", regexp.MustCompile("^/health")))
+
+				tfsdklog.Debug(ctx, "test omitted message")
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{"path": "/v1"})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "sdk",
+					"path":     "/v1",
+				},
+			},
+		},
+		"message-regexes-below-level": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.OmitLogWithRules(ctx, tfsdklog.OmitRuleMessageRegexes(regexp.MustCompile("^Polling")).BelowLevel(hclog.Warn))
+
+				tfsdklog.Info(ctx, "Polling status")
+				tfsdklog.Warn(ctx, "Polling timed out")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "warn",
+					"@message": "Polling timed out",
+					"@module":  "sdk",
+				},
+			},
+		},
+		"func": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.OmitLogWithRules(ctx, tfsdklog.OmitRuleFunc(func(level hclog.Level, msg string, fields map[string]interface{}) bool {
+					return level == hclog.Trace && fields["attempt"] != nil
+				}))
+
+				tfsdklog.Trace(ctx, "test omitted message", map[string]interface{}{"attempt": 1})
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{"attempt": 1})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "sdk",
+					"attempt":  float64(1),
+				},
+			},
+		},
+		"subsystem": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem)
+				ctx = tfsdklog.SubsystemOmitLogWithRules(ctx, testSubsystem, tfsdklog.OmitRuleFieldValues("http_status", 200))
+
+				tfsdklog.SubsystemDebug(ctx, testSubsystem, "test omitted message", map[string]interface{}{"http_status": 200})
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{"http_status": 200})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":      "debug",
+					"@message":    "test message",
+					"@module":     "sdk",
+					"http_status": float64(200),
+				},
+			},
+		},
+		"subsystem-option": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem, tfsdklog.WithOmitLogWithRules(tfsdklog.OmitRuleFieldValues("http_status", 200)))
+
+				tfsdklog.SubsystemDebug(ctx, testSubsystem, "test omitted message", map[string]interface{}{"http_status": 200})
+				tfsdklog.SubsystemDebug(ctx, testSubsystem, "test message", map[string]interface{}{"http_status": 500})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":      "debug",
+					"@message":    "test message",
+					"@module":     testSubsystemModule,
+					"http_status": float64(500),
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.SDKRoot(ctx, &outputBuffer)
+
+			testCase.logImpl(ctx)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
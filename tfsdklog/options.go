@@ -5,6 +5,7 @@ package tfsdklog
 
 import (
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/go-hclog"
@@ -14,6 +15,11 @@ import (
 // Options is a collection of logging options, useful for collecting arguments
 // to NewSubsystem, NewRootSDKLogger, and NewRootProviderLogger before calling
 // them.
+//
+// NewRootSDKLogger and NewRootProviderLogger add the fields and the omit and
+// mask rules of the options to the ones already set in the context.Context.
+// WithRootFields and WithoutInheritedRules only have an effect when used with
+// NewSubsystem.
 type Options []logging.Option
 
 // WithAdditionalLocationOffset returns an option that allowing implementations
@@ -25,7 +31,8 @@ func WithAdditionalLocationOffset(additionalLocationOffset int) logging.Option {
 }
 
 // WithLogName returns an option that will set the logger name explicitly to
-// `name`. This has no effect when used with NewSubsystem.
+// `name`, rather than deriving it from the subsystem name when used with
+// NewSubsystem.
 func WithLogName(name string) logging.Option {
 	return func(l logging.LoggerOpts) logging.LoggerOpts {
 		l.Name = name
@@ -56,15 +63,197 @@ func WithLevel(level hclog.Level) logging.Option {
 	}
 }
 
+// WithField returns an option that will include the key and value as a field
+// in all log output of the logger.
+func WithField(key string, value interface{}) logging.Option {
+	return logging.WithField(key, value)
+}
+
+// WithFields returns an option that will include all the key/value pairs of
+// `fields` as fields in all log output of the logger.
+func WithFields(fields map[string]interface{}) logging.Option {
+	return logging.WithFields(fields)
+}
+
+// WithOmitLogWithFieldKeys returns an option that will omit log output which
+// contains any of the given field keys.
+func WithOmitLogWithFieldKeys(keys ...string) logging.Option {
+	return logging.WithOmitLogWithFieldKeys(keys...)
+}
+
+// WithOmitLogWithFieldKeyMatchers returns an option that will omit log output
+// which contains any field key matching any of the given FieldKeyMatcher.
+func WithOmitLogWithFieldKeyMatchers(matchers ...FieldKeyMatcher) logging.Option {
+	return logging.WithOmitLogWithFieldKeyMatchers(matchers...)
+}
+
+// WithOmitLogWithMessageRegexes returns an option that will omit log output
+// whose message matches any of the given *regexp.Regexp.
+func WithOmitLogWithMessageRegexes(expressions ...*regexp.Regexp) logging.Option {
+	return logging.WithOmitLogWithMessageRegexes(expressions...)
+}
+
+// WithOmitLogWithMessageStrings returns an option that will omit log output
+// whose message contains any of the given strings.
+func WithOmitLogWithMessageStrings(matchingStrings ...string) logging.Option {
+	return logging.WithOmitLogWithMessageStrings(matchingStrings...)
+}
+
+// WithOmitLogWithRules returns an option that will omit log output which any
+// of the given OmitRule omits.
+func WithOmitLogWithRules(rules ...OmitRule) logging.Option {
+	return logging.WithOmitLogWithRules(rules...)
+}
+
+// WithOmitDryRun returns an option that will write the logs the omit rules of
+// the logger would omit, tagged with the WouldOmitFieldKey field, rather than
+// omitting them.
+func WithOmitDryRun() logging.Option {
+	return logging.WithOmitDryRun()
+}
+
+// WithMaskFieldValuesWithFieldKeys returns an option that will mask the values
+// of fields with any of the given keys.
+func WithMaskFieldValuesWithFieldKeys(keys ...string) logging.Option {
+	return logging.WithMaskFieldValuesWithFieldKeys(keys...)
+}
+
+// WithMaskFieldValuesWithFieldKeyMatchers returns an option that will mask the
+// values of fields with keys matching any of the given FieldKeyMatcher.
+func WithMaskFieldValuesWithFieldKeyMatchers(matchers ...FieldKeyMatcher) logging.Option {
+	return logging.WithMaskFieldValuesWithFieldKeyMatchers(matchers...)
+}
+
+// WithMaskFieldValuesWithFunc returns an option that will replace the value of
+// any field with one of the given keys, or of all fields when no keys are
+// given, with the value returned by `fn`.
+func WithMaskFieldValuesWithFunc(keys []string, fn func(interface{}) interface{}) logging.Option {
+	return logging.WithMaskFieldValuesWithFunc(keys, fn)
+}
+
+// WithMaskAllFieldValuesRegexes returns an option that will mask the portions
+// of all field values matching any of the given *regexp.Regexp.
+func WithMaskAllFieldValuesRegexes(expressions ...*regexp.Regexp) logging.Option {
+	return logging.WithMaskAllFieldValuesRegexes(expressions...)
+}
+
+// WithMaskAllFieldValuesStrings returns an option that will mask the
+// occurrences of any of the given strings in all field values.
+func WithMaskAllFieldValuesStrings(matchingStrings ...string) logging.Option {
+	return logging.WithMaskAllFieldValuesStrings(matchingStrings...)
+}
+
+// WithMaskMessageRegexes returns an option that will mask the portions of the
+// log message matching any of the given *regexp.Regexp.
+func WithMaskMessageRegexes(expressions ...*regexp.Regexp) logging.Option {
+	return logging.WithMaskMessageRegexes(expressions...)
+}
+
+// WithMaskMessageStrings returns an option that will mask the occurrences of
+// any of the given strings in the log message.
+func WithMaskMessageStrings(matchingStrings ...string) logging.Option {
+	return logging.WithMaskMessageStrings(matchingStrings...)
+}
+
+// WithMaskMessageWithFunc returns an option that will replace the log message
+// with the one returned by `fn`.
+func WithMaskMessageWithFunc(fn func(string) string) logging.Option {
+	return logging.WithMaskMessageWithFunc(fn)
+}
+
 // WithRootFields enables the copying of root logger fields to a new subsystem
 // logger during creation.
 func WithRootFields() logging.Option {
 	return logging.WithRootFields()
 }
 
+// WithFieldAllowlist returns an option that will only write the fields with
+// the given keys in the log output of the logger. All other fields, including
+// fields which are unknown when the logger is configured, are dropped, and
+// their keys are listed in the RemovedFieldsKey field. Omit rules still apply
+// to all fields.
+//
+// The tf_req_id, trace_id and span_id fields, which correlate log output with
+// requests and traces, are always written. Subsystem loggers only write the
+// fields allowed by both their own field allowlist, if any, and the one of
+// their root logger, even when the root logger does not apply its rules to
+// subsystems.
+func WithFieldAllowlist(keys ...string) logging.Option {
+	return logging.WithFieldAllowlist(logging.FieldAllowlistModeDrop, keys...)
+}
+
+// WithFieldAllowlistMasked returns an option that will mask the values of all
+// fields without the given keys in the log output of the logger, rather than
+// dropping them as with WithFieldAllowlist. Their keys are also listed in the
+// RemovedFieldsKey field. The same fields are always written and the same
+// subsystem rules apply as with WithFieldAllowlist.
+func WithFieldAllowlistMasked(keys ...string) logging.Option {
+	return logging.WithFieldAllowlist(logging.FieldAllowlistModeMask, keys...)
+}
+
+// WithEntryHooks returns an option that will process all log output of the
+// logger, after omission and masking, with the given EntryHook, in order.
+// Subsystem loggers run their own EntryHooks first, followed by the ones of
+// their root logger.
+func WithEntryHooks(hooks ...EntryHook) logging.Option {
+	return logging.WithEntryHooks(hooks...)
+}
+
+// WithMaskAudit returns an option that will include the MaskedFieldKey field
+// in all log output of the logger with masked message or field values,
+// listing the keys of the masked fields and the number of replacements in the
+// message, but never the original values. Subsystem loggers also include it when
+// their root logger does.
+func WithMaskAudit() logging.Option {
+	return logging.WithMaskAudit(false)
+}
+
+// WithMaskAuditRuleIDs returns an option that will include the MaskedFieldKey
+// field as with WithMaskAudit, also listing the identifiers of the rules which
+// masked any value, such as "mask_field_values_with_field_keys:password" or
+// "common_secrets:jwt". Rules configured with strings to mask are identified
+// by their index instead, such as "mask_message_strings[0]", as those strings
+// are commonly the sensitive values themselves.
+func WithMaskAuditRuleIDs() logging.Option {
+	return logging.WithMaskAudit(true)
+}
+
+// WithSpanEventBridge returns an option that will record all log output of
+// the logger, after omission and masking, as events on the active trace span
+// with the given SpanEventBridge. Subsystem loggers use the SpanEventBridge of
+// their root logger, unless configured with their own.
+func WithSpanEventBridge(bridge SpanEventBridge) logging.Option {
+	return logging.WithSpanEventBridge(bridge)
+}
+
+// WithStats returns an option that will count the log entries emitted,
+// omitted and masked by the logger with the given StatsCollector. Subsystem
+// loggers use the StatsCollector of their root logger, unless configured with
+// their own.
+func WithStats(collector *StatsCollector) logging.Option {
+	return logging.WithStats(collector)
+}
+
+// WithTraceContextExtractor returns an option that will include the IDs of
+// the active trace and span, as returned by the given TraceContextExtractor,
+// as the trace_id and span_id fields in all log output of the logger. Fields
+// with the same keys, set on the logger or passed to the logging functions,
+// take precedence. Subsystem loggers use the TraceContextExtractor of their
+// root logger, unless configured with their own.
+func WithTraceContextExtractor(extractor TraceContextExtractor) logging.Option {
+	return logging.WithTraceContextExtractor(extractor)
+}
+
+// WithoutInheritedRules returns an option that disables the application of
+// the omit and mask rules of the root logger by a subsystem logger, when the
+// root logger applies them to subsystems with ApplyRulesToSubsystems. This
+// only has an effect when used with NewSubsystem.
+func WithoutInheritedRules() logging.Option {
+	return logging.WithoutInheritedRules()
+}
+
 // WithoutLocation returns an option that disables including the location of
-// the log line in the log output, which is on by default. This has no effect
-// when used with NewSubsystem.
+// the log line in the log output, which is on by default.
 func WithoutLocation() logging.Option {
 	return func(l logging.LoggerOpts) logging.LoggerOpts {
 		l.IncludeLocation = false
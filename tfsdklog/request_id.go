@@ -0,0 +1,48 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+// RequestIDFieldKey is the field key holding the correlation ID of the request
+// set by ContextWithRequestID.
+const RequestIDFieldKey = logging.RequestIDFieldKey
+
+// ContextWithRequestID returns a new context.Context with a correlation ID for
+// the request, which is generated if `ctx` does not contain one yet. The
+// correlation ID is included as the tf_req_id root field of both the SDK and
+// provider loggers, as if set with both SetField and tflog.SetField, and can
+// be read back with RequestID or tflog.RequestID, e.g. to pass it on to
+// outgoing API calls.
+//
+// Subsystem loggers created afterwards with the WithRootFields option also
+// include the correlation ID. It is not added to subsystem loggers which
+// already exist in `ctx`.
+func ContextWithRequestID(ctx context.Context) context.Context {
+	requestID := logging.GetRequestID(ctx)
+
+	if requestID == "" {
+		requestID = logging.NewRequestID()
+		ctx = logging.SetRequestID(ctx, requestID)
+	}
+
+	ctx = SetField(ctx, RequestIDFieldKey, requestID)
+
+	// Copy to prevent slice/map aliasing issues.
+	// Reference: https://github.com/hashicorp/terraform-plugin-log/issues/131
+	providerLOpts := logging.WithField(RequestIDFieldKey, requestID)(logging.GetProviderRootTFLoggerOpts(ctx).Copy())
+
+	return logging.SetProviderRootTFLoggerOpts(ctx, providerLOpts)
+}
+
+// RequestID returns the correlation ID of the request set by
+// ContextWithRequestID. If `ctx` does not contain a correlation ID, it returns
+// an empty string.
+func RequestID(ctx context.Context) string {
+	return logging.GetRequestID(ctx)
+}
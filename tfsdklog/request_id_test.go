@@ -0,0 +1,158 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog_test
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+func TestContextWithRequestID(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		logImpl        func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"roots": {
+			logImpl: func(ctx context.Context) {
+				tfsdklog.Trace(ctx, "test sdk message")
+				tflog.Trace(ctx, "test provider message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                   "trace",
+					"@message":                 "test sdk message",
+					"@module":                  "sdk",
+					tfsdklog.RequestIDFieldKey: "REQUEST_ID",
+				},
+				{
+					"@level":                   "trace",
+					"@message":                 "test provider message",
+					"@module":                  "provider",
+					tfsdklog.RequestIDFieldKey: "REQUEST_ID",
+				},
+			},
+		},
+		"subsystems-with-root-fields": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem, tfsdklog.WithRootFields())
+				ctx = tflog.NewSubsystem(ctx, "test_provider_subsystem", tflog.WithRootFields())
+
+				tfsdklog.SubsystemTrace(ctx, testSubsystem, "test sdk subsystem message")
+				tflog.SubsystemTrace(ctx, "test_provider_subsystem", "test provider subsystem message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                   "trace",
+					"@message":                 "test sdk subsystem message",
+					"@module":                  testSubsystemModule,
+					tfsdklog.RequestIDFieldKey: "REQUEST_ID",
+				},
+				{
+					"@level":                   "trace",
+					"@message":                 "test provider subsystem message",
+					"@module":                  "provider.test_provider_subsystem",
+					tfsdklog.RequestIDFieldKey: "REQUEST_ID",
+				},
+			},
+		},
+		"subsystem-without-root-fields": {
+			logImpl: func(ctx context.Context) {
+				ctx = tflog.NewSubsystem(ctx, "test_provider_subsystem")
+
+				tflog.SubsystemTrace(ctx, "test_provider_subsystem", "test provider subsystem message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test provider subsystem message",
+					"@module":  "provider.test_provider_subsystem",
+				},
+			},
+		},
+		"existing-request-id": {
+			logImpl: func(ctx context.Context) {
+				requestID := tfsdklog.RequestID(ctx)
+
+				ctx = tfsdklog.ContextWithRequestID(ctx)
+
+				if got := tfsdklog.RequestID(ctx); got != requestID {
+					t.Errorf("expected request ID %q to be unchanged, got: %q", requestID, got)
+				}
+
+				tflog.Trace(ctx, "test provider message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                   "trace",
+					"@message":                 "test provider message",
+					"@module":                  "provider",
+					tfsdklog.RequestIDFieldKey: "REQUEST_ID",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.SDKRoot(ctx, &outputBuffer)
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+			ctx = tfsdklog.ContextWithRequestID(ctx)
+
+			requestID := tfsdklog.RequestID(ctx)
+
+			if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(requestID) {
+				t.Fatalf("expected UUID request ID, got: %q", requestID)
+			}
+
+			if got := tflog.RequestID(ctx); got != requestID {
+				t.Errorf("expected tflog.RequestID %q, got: %q", requestID, got)
+			}
+
+			testCase.logImpl(ctx)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			for _, entry := range got {
+				if entry[tfsdklog.RequestIDFieldKey] == requestID {
+					entry[tfsdklog.RequestIDFieldKey] = "REQUEST_ID"
+				}
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRequestID_None(t *testing.T) {
+	t.Parallel()
+
+	if got := tfsdklog.RequestID(context.Background()); got != "" {
+		t.Errorf("expected no request ID, got: %q", got)
+	}
+
+	if got := tflog.RequestID(context.Background()); got != "" {
+		t.Errorf("expected no request ID, got: %q", got)
+	}
+}
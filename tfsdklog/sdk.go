@@ -32,6 +32,9 @@ func NewRootSDKLogger(ctx context.Context, options ...logging.Option) context.Co
 
 		ctx = logging.SetSDKRootLogger(ctx, logger)
 		ctx = logging.SetSDKRootLoggerOptions(ctx, sdkLoggerOptions)
+		ctx = logging.SetSDKSubsystemRegistry(ctx, logging.NewSubsystemRegistry())
+		rootOpts := opts.WithRootExtensions(logging.GetSDKRootTFLoggerOpts(ctx))
+		ctx = logging.SetSDKRootTFLoggerOpts(ctx, rootOpts.WithStatsLogger(logger.Name(), rootOpts))
 
 		return ctx
 	}
@@ -57,8 +60,13 @@ func NewRootSDKLogger(ctx context.Context, options ...logging.Option) context.Co
 		AdditionalLocationOffset: opts.AdditionalLocationOffset,
 	}
 
-	ctx = logging.SetSDKRootLogger(ctx, hclog.New(loggerOptions))
+	logger := hclog.New(loggerOptions)
+	rootOpts := opts.WithRootExtensions(logging.GetSDKRootTFLoggerOpts(ctx))
+
+	ctx = logging.SetSDKRootLogger(ctx, logger)
 	ctx = logging.SetSDKRootLoggerOptions(ctx, loggerOptions)
+	ctx = logging.SetSDKSubsystemRegistry(ctx, logging.NewSubsystemRegistry())
+	ctx = logging.SetSDKRootTFLoggerOpts(ctx, rootOpts.WithStatsLogger(logger.Name(), rootOpts))
 
 	return ctx
 }
@@ -83,6 +91,9 @@ func NewRootProviderLogger(ctx context.Context, options ...logging.Option) conte
 
 		ctx = logging.SetProviderRootLogger(ctx, logger)
 		ctx = logging.SetProviderRootLoggerOptions(ctx, providerLoggerOptions)
+		ctx = logging.SetProviderSubsystemRegistry(ctx, logging.NewSubsystemRegistry())
+		rootOpts := opts.WithRootExtensions(logging.GetProviderRootTFLoggerOpts(ctx))
+		ctx = logging.SetProviderRootTFLoggerOpts(ctx, rootOpts.WithStatsLogger(logger.Name(), rootOpts))
 
 		return ctx
 	}
@@ -100,8 +111,13 @@ func NewRootProviderLogger(ctx context.Context, options ...logging.Option) conte
 		AdditionalLocationOffset: opts.AdditionalLocationOffset,
 	}
 
-	ctx = logging.SetProviderRootLogger(ctx, hclog.New(loggerOptions))
+	logger := hclog.New(loggerOptions)
+	rootOpts := opts.WithRootExtensions(logging.GetProviderRootTFLoggerOpts(ctx))
+
+	ctx = logging.SetProviderRootLogger(ctx, logger)
 	ctx = logging.SetProviderRootLoggerOptions(ctx, loggerOptions)
+	ctx = logging.SetProviderSubsystemRegistry(ctx, logging.NewSubsystemRegistry())
+	ctx = logging.SetProviderRootTFLoggerOpts(ctx, rootOpts.WithStatsLogger(logger.Name(), rootOpts))
 
 	return ctx
 }
@@ -135,7 +151,7 @@ func Trace(ctx context.Context, msg string, additionalFields ...map[string]inter
 		return
 	}
 
-	additionalArgs, shouldOmit := logging.OmitOrMask(logging.GetSDKRootTFLoggerOpts(ctx), &msg, additionalFields)
+	additionalArgs, shouldOmit := logging.OmitOrMask(ctx, logger, hclog.Trace, logging.GetSDKRootTFLoggerOpts(ctx), &msg, additionalFields)
 	if shouldOmit {
 		return
 	}
@@ -157,7 +173,7 @@ func Debug(ctx context.Context, msg string, additionalFields ...map[string]inter
 		return
 	}
 
-	additionalArgs, shouldOmit := logging.OmitOrMask(logging.GetSDKRootTFLoggerOpts(ctx), &msg, additionalFields)
+	additionalArgs, shouldOmit := logging.OmitOrMask(ctx, logger, hclog.Debug, logging.GetSDKRootTFLoggerOpts(ctx), &msg, additionalFields)
 	if shouldOmit {
 		return
 	}
@@ -179,7 +195,7 @@ func Info(ctx context.Context, msg string, additionalFields ...map[string]interf
 		return
 	}
 
-	additionalArgs, shouldOmit := logging.OmitOrMask(logging.GetSDKRootTFLoggerOpts(ctx), &msg, additionalFields)
+	additionalArgs, shouldOmit := logging.OmitOrMask(ctx, logger, hclog.Info, logging.GetSDKRootTFLoggerOpts(ctx), &msg, additionalFields)
 	if shouldOmit {
 		return
 	}
@@ -201,7 +217,7 @@ func Warn(ctx context.Context, msg string, additionalFields ...map[string]interf
 		return
 	}
 
-	additionalArgs, shouldOmit := logging.OmitOrMask(logging.GetSDKRootTFLoggerOpts(ctx), &msg, additionalFields)
+	additionalArgs, shouldOmit := logging.OmitOrMask(ctx, logger, hclog.Warn, logging.GetSDKRootTFLoggerOpts(ctx), &msg, additionalFields)
 	if shouldOmit {
 		return
 	}
@@ -223,7 +239,7 @@ func Error(ctx context.Context, msg string, additionalFields ...map[string]inter
 		return
 	}
 
-	additionalArgs, shouldOmit := logging.OmitOrMask(logging.GetSDKRootTFLoggerOpts(ctx), &msg, additionalFields)
+	additionalArgs, shouldOmit := logging.OmitOrMask(ctx, logger, hclog.Error, logging.GetSDKRootTFLoggerOpts(ctx), &msg, additionalFields)
 	if shouldOmit {
 		return
 	}
@@ -255,6 +271,29 @@ func OmitLogWithFieldKeys(ctx context.Context, keys ...string) context.Context {
 	return logging.SetSDKRootTFLoggerOpts(ctx, lOpts)
 }
 
+// OmitLogWithFieldKeyMatchers returns a new context.Context that has a
+// modified logger that will omit to write any log when any key within its
+// fields matches any of the given FieldKeyMatcher.
+//
+// Each call to this function is additive:
+// the matchers to omit by are added to the existing configuration.
+//
+// Example:
+//
+//	configuration = `[FieldKeyPrefix("debug_")]`
+//
+//	log1 = `{ msg = "...", fields = { 'debug_body': '...', 'bar': '...' }`  -> omitted
+//	log2 = `{ msg = "...", fields = { 'bar': '...' }`                       -> printed
+func OmitLogWithFieldKeyMatchers(ctx context.Context, matchers ...FieldKeyMatcher) context.Context {
+	lOpts := logging.GetSDKRootTFLoggerOpts(ctx)
+
+	// Copy to prevent slice/map aliasing issues.
+	// Reference: https://github.com/hashicorp/terraform-plugin-log/issues/131
+	lOpts = logging.WithOmitLogWithFieldKeyMatchers(matchers...)(lOpts.Copy())
+
+	return logging.SetSDKRootTFLoggerOpts(ctx, lOpts)
+}
+
 // OmitLogWithMessageRegexes returns a new context.Context that has a modified logger
 // that will omit to write any log that has a message matching any of the
 // given *regexp.Regexp.
@@ -302,6 +341,49 @@ func OmitLogWithMessageStrings(ctx context.Context, matchingStrings ...string) c
 	return logging.SetSDKRootTFLoggerOpts(ctx, lOpts)
 }
 
+// OmitLogWithRules returns a new context.Context that has a modified logger
+// that will omit to write any log that any of the given OmitRule omits, such
+// as a log with a field equal to a value, optionally only below a level.
+//
+// Each call to this function is additive:
+// the rules to omit by are added to the existing configuration.
+//
+// Example:
+//
+//	configuration = `[OmitRuleFieldValues("http_status", 200).BelowLevel(hclog.Info)]`
+//
+//	log1 = `{ level = "debug", msg = "...", fields = { 'http_status': 200 }`  -> omitted
+//	log2 = `{ level = "debug", msg = "...", fields = { 'http_status': 404 }`  -> printed
+//	log3 = `{ level = "info", msg = "...", fields = { 'http_status': 200 }`   -> printed
+func OmitLogWithRules(ctx context.Context, rules ...OmitRule) context.Context {
+	lOpts := logging.GetSDKRootTFLoggerOpts(ctx)
+
+	// Copy to prevent slice/map aliasing issues.
+	// Reference: https://github.com/hashicorp/terraform-plugin-log/issues/131
+	lOpts = logging.WithOmitLogWithRules(rules...)(lOpts.Copy())
+
+	return logging.SetSDKRootTFLoggerOpts(ctx, lOpts)
+}
+
+// OmitDryRun returns a new context.Context that has a modified logger, which
+// writes the logs its omit rules would omit rather than omitting them. Each
+// of those logs is tagged with the WouldOmitFieldKey field, identifying the
+// rule which would omit it, such as "omit_log_with_message_regexes:^Polling".
+// This allows checking the effect of new omit rules against real traffic
+// before enabling them.
+//
+// When the logger applies its rules to subsystems with
+// ApplyRulesToSubsystems, its subsystem loggers are also in dry-run mode.
+func OmitDryRun(ctx context.Context) context.Context {
+	lOpts := logging.GetSDKRootTFLoggerOpts(ctx)
+
+	// Copy to prevent slice/map aliasing issues.
+	// Reference: https://github.com/hashicorp/terraform-plugin-log/issues/131
+	lOpts = logging.WithOmitDryRun()(lOpts.Copy())
+
+	return logging.SetSDKRootTFLoggerOpts(ctx, lOpts)
+}
+
 // MaskFieldValuesWithFieldKeys returns a new context.Context that has a modified logger
 // that masks (replaces) with asterisks (`***`) any field value where the
 // key matches one of the given keys.
@@ -326,11 +408,70 @@ func MaskFieldValuesWithFieldKeys(ctx context.Context, keys ...string) context.C
 	return logging.SetSDKRootTFLoggerOpts(ctx, lOpts)
 }
 
+// MaskFieldValuesWithFieldKeyMatchers returns a new context.Context that has
+// a modified logger that masks (replaces) with asterisks (`***`) any field
+// value where the key matches any of the given FieldKeyMatcher.
+//
+// Each call to this function is additive:
+// the matchers to mask by are added to the existing configuration.
+//
+// Example:
+//
+//	configuration = `[FieldKeyGlob("*password*").CaseInsensitive()]`
+//
+//	log1 = `{ msg = "...", fields = { 'Password': '***', 'bar': '...' }`  -> masked value
+//	log2 = `{ msg = "...", fields = { 'bar': '...' }`                     -> as-is value
+//	log3 = `{ msg = "...", fields = { 'db_password': '***' }`             -> masked value
+func MaskFieldValuesWithFieldKeyMatchers(ctx context.Context, matchers ...FieldKeyMatcher) context.Context {
+	lOpts := logging.GetSDKRootTFLoggerOpts(ctx)
+
+	// Copy to prevent slice/map aliasing issues.
+	// Reference: https://github.com/hashicorp/terraform-plugin-log/issues/131
+	lOpts = logging.WithMaskFieldValuesWithFieldKeyMatchers(matchers...)(lOpts.Copy())
+
+	return logging.SetSDKRootTFLoggerOpts(ctx, lOpts)
+}
+
+// MaskFieldValuesWithFunc returns a new context.Context that has a modified
+// logger that replaces the value of any field with one of the given keys, or
+// of all fields when no keys are given, with the value returned by `fn`. This
+// allows domain-specific masking, such as keeping the host of a URL while
+// masking its credentials and query parameters. Field values of any type,
+// not only strings, are given to `fn`, which must not modify them, but return
+// a new value instead. Returning the given value leaves it as-is.
+//
+// Functions run after the masking by field key, and are never given values
+// which are already fully masked, such as by key. They run before the masking
+// by regexp and strings, which are still applied to the values they return.
+//
+// Each call to this function is additive:
+// the function is added to the existing configuration, running after the
+// functions added before.
+//
+// Example:
+//
+//	configuration = `[{ keys: ['url'], fn: redactURL }]`
+//
+//	log1 = `{ msg = "...", fields = { 'url': 'https://host/***' }`  -> masked value
+//	log2 = `{ msg = "...", fields = { 'bar': '...' }`               -> as-is value
+func MaskFieldValuesWithFunc(ctx context.Context, keys []string, fn func(interface{}) interface{}) context.Context {
+	lOpts := logging.GetSDKRootTFLoggerOpts(ctx)
+
+	// Copy to prevent slice/map aliasing issues.
+	// Reference: https://github.com/hashicorp/terraform-plugin-log/issues/131
+	lOpts = logging.WithMaskFieldValuesWithFunc(keys, fn)(lOpts.Copy())
+
+	return logging.SetSDKRootTFLoggerOpts(ctx, lOpts)
+}
+
 // MaskAllFieldValuesRegexes returns a new context.Context that has a modified logger
 // that masks (replaces) with asterisks (`***`) all field value substrings,
 // matching one of the given *regexp.Regexp.
 //
-// Note that the replacement will happen, only for field values that are of type string.
+// Note that the replacement will happen, only for field values that are strings,
+// or that are rendered as text: []byte, error, fmt.Stringer and numbers. Those
+// are replaced with their masked text, while values without any replacement
+// keep their type.
 //
 // Each call to this function is additive:
 // the regexp to mask by are added to the existing configuration.
@@ -356,11 +497,17 @@ func MaskAllFieldValuesRegexes(ctx context.Context, expressions ...*regexp.Regex
 // that masks (replaces) with asterisks (`***`) all field value substrings,
 // equal to one of the given strings.
 //
-// Note that the replacement will happen, only for field values that are of type string.
+// Note that the replacement will happen, only for field values that are strings,
+// or that are rendered as text: []byte, error, fmt.Stringer and numbers. Those
+// are replaced with their masked text, while values without any replacement
+// keep their type.
 //
 // Each call to this function is additive:
 // the regexp to mask by are added to the existing configuration.
 //
+// Where the strings overlap, the longest one is masked first, such as
+// `secret-token` rather than only `secret` within it.
+//
 // Example:
 //
 //	configuration = `[regexp.MustCompile("(foo|bar)")]`
@@ -409,6 +556,9 @@ func MaskMessageRegexes(ctx context.Context, expressions ...*regexp.Regexp) cont
 // Each call to this function is additive:
 // the string to mask by are added to the existing configuration.
 //
+// Where the strings overlap, the longest one is masked first, such as
+// `secret-token` rather than only `secret` within it.
+//
 // Example:
 //
 //	configuration = `['foo', 'bar']`
@@ -426,6 +576,32 @@ func MaskMessageStrings(ctx context.Context, matchingStrings ...string) context.
 	return logging.SetSDKRootTFLoggerOpts(ctx, lOpts)
 }
 
+// MaskMessageWithFunc returns a new context.Context that has a modified
+// logger that replaces the log message with the one returned by `fn`.
+//
+// Functions run before the masking by regexp and strings, which are still
+// applied to the messages they return.
+//
+// Each call to this function is additive:
+// the function is added to the existing configuration, running after the
+// functions added before.
+//
+// Example:
+//
+//	configuration = `[redactURLs]`
+//
+//	log1 = `{ msg = "request to https://host/***", fields = {...}`  -> masked message
+//	log2 = `{ msg = "pineapple mango", fields = {...}`             -> as-is
+func MaskMessageWithFunc(ctx context.Context, fn func(string) string) context.Context {
+	lOpts := logging.GetSDKRootTFLoggerOpts(ctx)
+
+	// Copy to prevent slice/map aliasing issues.
+	// Reference: https://github.com/hashicorp/terraform-plugin-log/issues/131
+	lOpts = logging.WithMaskMessageWithFunc(fn)(lOpts.Copy())
+
+	return logging.SetSDKRootTFLoggerOpts(ctx, lOpts)
+}
+
 // MaskLogRegexes is a shortcut to invoke MaskMessageRegexes and MaskAllFieldValuesRegexes using the same input.
 // Refer to those functions for details.
 func MaskLogRegexes(ctx context.Context, expressions ...*regexp.Regexp) context.Context {
@@ -437,3 +613,22 @@ func MaskLogRegexes(ctx context.Context, expressions ...*regexp.Regexp) context.
 func MaskLogStrings(ctx context.Context, matchingStrings ...string) context.Context {
 	return MaskMessageStrings(MaskAllFieldValuesStrings(ctx, matchingStrings...), matchingStrings...)
 }
+
+// ApplyRulesToSubsystems returns a new context.Context that has a modified
+// logger, whose omit and mask rules are also applied by all of its subsystem
+// loggers. This includes subsystem loggers created afterwards, as well as
+// rules added to this logger afterwards, such as a mask for a sensitive field
+// key added at the start of a request.
+//
+// Inherited rules are applied before the rules of the subsystem logger itself.
+// Subsystem loggers created with the WithoutInheritedRules option do not
+// apply the rules of this logger.
+func ApplyRulesToSubsystems(ctx context.Context) context.Context {
+	lOpts := logging.GetSDKRootTFLoggerOpts(ctx)
+
+	// Copy to prevent slice/map aliasing issues.
+	// Reference: https://github.com/hashicorp/terraform-plugin-log/issues/131
+	lOpts = logging.WithApplyRulesToSubsystems()(lOpts.Copy())
+
+	return logging.SetSDKRootTFLoggerOpts(ctx, lOpts)
+}
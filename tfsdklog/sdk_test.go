@@ -11,6 +11,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
 	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
 )
 
@@ -1858,3 +1859,241 @@ func TestMaskLogStrings(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyRulesToSubsystems(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		applyRulesToSubsystems bool
+		subsystemOptions       []logging.Option
+		expectedOutput         []map[string]interface{}
+	}{
+		"disabled": {
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "root message with ***",
+					"@module":  "sdk",
+					"k1":       "***",
+				},
+				{
+					"@level":   "trace",
+					"@message": "subsystem message with secret-value",
+					"@module":  testSubsystemModule,
+					"k1":       "v1",
+				},
+				{
+					"@level":   "trace",
+					"@message": "omitted subsystem message",
+					"@module":  testSubsystemModule,
+				},
+			},
+		},
+		"enabled": {
+			applyRulesToSubsystems: true,
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "root message with ***",
+					"@module":  "sdk",
+					"k1":       "***",
+				},
+				{
+					"@level":   "trace",
+					"@message": "subsystem message with ***",
+					"@module":  testSubsystemModule,
+					"k1":       "***",
+				},
+			},
+		},
+		"enabled-subsystem-excluded": {
+			applyRulesToSubsystems: true,
+			subsystemOptions: []logging.Option{
+				tfsdklog.WithoutInheritedRules(),
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "root message with ***",
+					"@module":  "sdk",
+					"k1":       "***",
+				},
+				{
+					"@level":   "trace",
+					"@message": "subsystem message with secret-value",
+					"@module":  testSubsystemModule,
+					"k1":       "v1",
+				},
+				{
+					"@level":   "trace",
+					"@message": "omitted subsystem message",
+					"@module":  testSubsystemModule,
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.SDKRoot(ctx, &outputBuffer)
+			ctx = tfsdklog.MaskFieldValuesWithFieldKeys(ctx, "k1")
+
+			if testCase.applyRulesToSubsystems {
+				ctx = tfsdklog.ApplyRulesToSubsystems(ctx)
+			}
+
+			// Subsystem created before the root logger rules are added
+			ctx = tfsdklog.NewSubsystem(ctx, testSubsystem, testCase.subsystemOptions...)
+
+			ctx = tfsdklog.MaskMessageStrings(ctx, "secret-value")
+			ctx = tfsdklog.OmitLogWithMessageStrings(ctx, "omitted")
+
+			tfsdklog.Trace(ctx, "root message with secret-value", map[string]interface{}{"k1": "v1"})
+			tfsdklog.SubsystemTrace(ctx, testSubsystem, "subsystem message with secret-value", map[string]interface{}{"k1": "v1"})
+			tfsdklog.SubsystemTrace(ctx, testSubsystem, "omitted subsystem message")
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestNewRootSDKLogger_Options(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		existingImpl   func(context.Context) context.Context
+		rootOptions    []logging.Option
+		logImpl        func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"fields": {
+			rootOptions: []logging.Option{
+				tfsdklog.WithField("k1", "v1"),
+			},
+			logImpl: func(ctx context.Context) {
+				tfsdklog.Debug(ctx, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "sdk",
+					"k1":       "v1",
+				},
+			},
+		},
+		"omit-and-mask": {
+			rootOptions: []logging.Option{
+				tfsdklog.WithOmitLogWithMessageStrings("omitted"),
+				tfsdklog.WithMaskFieldValuesWithFieldKeys("password"),
+				tfsdklog.WithMaskMessageStrings("secret"),
+			},
+			logImpl: func(ctx context.Context) {
+				tfsdklog.Debug(ctx, "test omitted message")
+				tfsdklog.Debug(ctx, "test secret message", map[string]interface{}{
+					"password": "hunter2",
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test *** message",
+					"@module":  "sdk",
+					"password": "***",
+				},
+			},
+		},
+		"existing-rules": {
+			existingImpl: func(ctx context.Context) context.Context {
+				ctx = tfsdklog.NewRootSDKLogger(ctx)
+				ctx = tfsdklog.SetField(ctx, "k1", "existing")
+				ctx = tfsdklog.SetField(ctx, "k2", "existing")
+
+				return tfsdklog.MaskMessageStrings(ctx, "existing-secret")
+			},
+			rootOptions: []logging.Option{
+				tfsdklog.WithField("k1", "v1"),
+				tfsdklog.WithMaskMessageStrings("secret"),
+			},
+			logImpl: func(ctx context.Context) {
+				tfsdklog.Debug(ctx, "test existing-secret and secret message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test *** and *** message",
+					"@module":  "sdk",
+					"k1":       "v1",
+					"k2":       "existing",
+				},
+			},
+		},
+		"apply-rules-to-subsystems": {
+			rootOptions: []logging.Option{
+				logging.WithApplyRulesToSubsystems(),
+				tfsdklog.WithMaskMessageStrings("secret"),
+			},
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.NewSubsystem(ctx, "test_subsystem")
+
+				tfsdklog.SubsystemDebug(ctx, "test_subsystem", "test secret message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test *** message",
+					"@module":  "sdk.test_subsystem",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			rootOptions := append([]logging.Option{
+				logging.WithoutLocation(),
+				logging.WithoutTimestamp(),
+				logging.WithOutput(&outputBuffer),
+			}, testCase.rootOptions...)
+
+			ctx := context.Background()
+
+			if testCase.existingImpl != nil {
+				ctx = testCase.existingImpl(ctx)
+			}
+
+			ctx = tfsdklog.NewRootSDKLogger(ctx, rootOptions...)
+
+			testCase.logImpl(ctx)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+func TestSensitive(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		logImpl        func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"field": {
+			logImpl: func(ctx context.Context) {
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{
+					"any_key": tfsdklog.Sensitive("secret-value"),
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "sdk",
+					"any_key":  "***",
+				},
+			},
+		},
+		"nested-field": {
+			logImpl: func(ctx context.Context) {
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{
+					"config": map[string]interface{}{
+						"credentials": []interface{}{
+							map[string]interface{}{
+								"name":  "test-name",
+								"value": tfsdklog.Sensitive("secret-value"),
+							},
+						},
+					},
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "sdk",
+					"config": map[string]interface{}{
+						"credentials": []interface{}{
+							map[string]interface{}{
+								"name":  "test-name",
+								"value": "***",
+							},
+						},
+					},
+				},
+			},
+		},
+		"struct-field": {
+			logImpl: func(ctx context.Context) {
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{
+					"config": struct {
+						Password tfsdklog.SensitiveValue
+					}{
+						Password: tfsdklog.Sensitive("secret-value"),
+					},
+				})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "sdk",
+					"config":   map[string]interface{}{"Password": "***"},
+				},
+			},
+		},
+		"set-field": {
+			logImpl: func(ctx context.Context) {
+				ctx = tfsdklog.SetField(ctx, "password", tfsdklog.Sensitive("secret-value"))
+
+				tfsdklog.Debug(ctx, "test message")
+				tfsdklog.Debug(ctx, "another test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message",
+					"@module":  "sdk",
+					"password": "***",
+				},
+				{
+					"@level":   "debug",
+					"@message": "another test message",
+					"@module":  "sdk",
+					"password": "***",
+				},
+			},
+		},
+		"message": {
+			logImpl: func(ctx context.Context) {
+				tfsdklog.Debug(ctx, fmt.Sprintf("test message with %s and %v", tfsdklog.Sensitive("secret-value"), tfsdklog.Sensitive(123)))
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "debug",
+					"@message": "test message with *** and ***",
+					"@module":  "sdk",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.SDKRoot(ctx, &outputBuffer)
+
+			testCase.logImpl(ctx)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
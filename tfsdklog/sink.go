@@ -98,6 +98,7 @@ func ContextWithStandardLogging(ctx context.Context, testName string) context.Co
 
 	ctx = logging.SetSink(ctx, logger)
 	ctx = logging.SetSinkOptions(ctx, loggerOptions)
+	ctx = logging.SetSinkWritesToStandardLogger(ctx, true)
 
 	return ctx
 }
@@ -0,0 +1,215 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog_test
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		log      func(context.Context, *tfsdklog.StatsCollector)
+		expected map[tfsdklog.StatsKey]tfsdklog.StatsCounts
+	}{
+		"no-logs": {
+			log:      func(_ context.Context, _ *tfsdklog.StatsCollector) {},
+			expected: map[tfsdklog.StatsKey]tfsdklog.StatsCounts{},
+		},
+		"emitted": {
+			log: func(ctx context.Context, _ *tfsdklog.StatsCollector) {
+				tfsdklog.Trace(ctx, "test message")
+				tfsdklog.Trace(ctx, "test message")
+				tfsdklog.Error(ctx, "test message")
+			},
+			expected: map[tfsdklog.StatsKey]tfsdklog.StatsCounts{
+				{LoggerName: "test_stats", Level: hclog.Trace}: {Emitted: 2},
+				{LoggerName: "test_stats", Level: hclog.Error}: {Emitted: 1},
+			},
+		},
+		"omitted-and-masked": {
+			log: func(ctx context.Context, _ *tfsdklog.StatsCollector) {
+				ctx = tfsdklog.OmitLogWithMessageStrings(ctx, "omitted")
+				ctx = tfsdklog.MaskMessageStrings(ctx, "secret")
+
+				tfsdklog.Debug(ctx, "test omitted message")
+				tfsdklog.Debug(ctx, "test secret message")
+				tfsdklog.Debug(ctx, "test message")
+			},
+			expected: map[tfsdklog.StatsKey]tfsdklog.StatsCounts{
+				{LoggerName: "test_stats", Level: hclog.Debug}: {
+					Emitted:       2,
+					Omitted:       1,
+					OmittedByRule: map[string]uint64{"omit_log_with_message_strings:omitted": 1},
+					Masked:        1,
+				},
+			},
+		},
+		"omitted-by-rule": {
+			log: func(ctx context.Context, _ *tfsdklog.StatsCollector) {
+				ctx = tfsdklog.OmitLogWithFieldKeys(ctx, "debug_body")
+				ctx = tfsdklog.OmitLogWithRules(ctx, tfsdklog.OmitRuleFieldValues("http_status", 200))
+
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{"debug_body": "test-body"})
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{"http_status": 200})
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{"http_status": 200})
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{"http_status": 404})
+			},
+			expected: map[tfsdklog.StatsKey]tfsdklog.StatsCounts{
+				{LoggerName: "test_stats", Level: hclog.Debug}: {
+					Emitted: 1,
+					Omitted: 3,
+					OmittedByRule: map[string]uint64{
+						"omit_log_with_field_keys:debug_body":          1,
+						"omit_log_with_rules:field_values:http_status": 2,
+					},
+				},
+			},
+		},
+		"dropped-by-hook": {
+			log: func(ctx context.Context, _ *tfsdklog.StatsCollector) {
+				ctx = tfsdklog.NewSubsystem(ctx, "test_subsystem", tfsdklog.WithEntryHooks(
+					tfsdklog.EntryHookFunc(func(_ context.Context, entry tfsdklog.Entry) (tfsdklog.Entry, bool) {
+						return entry, false
+					}),
+				))
+
+				tfsdklog.SubsystemInfo(ctx, "test_subsystem", "test message")
+			},
+			expected: map[tfsdklog.StatsKey]tfsdklog.StatsCounts{
+				{LoggerName: "test_stats.test_subsystem", Subsystem: "test_subsystem", Level: hclog.Info}: {
+					Omitted:       1,
+					OmittedByRule: map[string]uint64{"entry_hook": 1},
+				},
+			},
+		},
+		"subsystem": {
+			log: func(ctx context.Context, _ *tfsdklog.StatsCollector) {
+				ctx = tfsdklog.NewSubsystem(ctx, "test_subsystem", tfsdklog.WithLevel(hclog.Debug))
+
+				tfsdklog.SubsystemTrace(ctx, "test_subsystem", "test message below level")
+				tfsdklog.SubsystemDebug(ctx, "test_subsystem", "test message")
+				tfsdklog.SubsystemWarn(ctx, "test_subsystem", "test message")
+			},
+			expected: map[tfsdklog.StatsKey]tfsdklog.StatsCounts{
+				{LoggerName: "test_stats.test_subsystem", Subsystem: "test_subsystem", Level: hclog.Debug}: {Emitted: 1},
+				{LoggerName: "test_stats.test_subsystem", Subsystem: "test_subsystem", Level: hclog.Warn}:  {Emitted: 1},
+			},
+		},
+		"reset": {
+			log: func(ctx context.Context, collector *tfsdklog.StatsCollector) {
+				tfsdklog.Trace(ctx, "test message")
+
+				collector.Reset()
+
+				tfsdklog.Error(ctx, "test message")
+			},
+			expected: map[tfsdklog.StatsKey]tfsdklog.StatsCounts{
+				{LoggerName: "test_stats", Level: hclog.Error}: {Emitted: 1},
+			},
+		},
+		"subsystem-own-collector": {
+			log: func(ctx context.Context, _ *tfsdklog.StatsCollector) {
+				ctx = tfsdklog.NewSubsystem(ctx, "test_subsystem", tfsdklog.WithStats(tfsdklog.NewStatsCollector()))
+
+				tfsdklog.SubsystemInfo(ctx, "test_subsystem", "test message")
+			},
+			expected: map[tfsdklog.StatsKey]tfsdklog.StatsCounts{},
+		},
+		"provider": {
+			log: func(_ context.Context, collector *tfsdklog.StatsCollector) {
+				var outputBuffer bytes.Buffer
+
+				ctx := tfsdklog.NewRootProviderLogger(
+					context.Background(),
+					logging.WithOutput(&outputBuffer),
+					tfsdklog.WithLogName("test_stats.provider"),
+					tfsdklog.WithStats(collector),
+					tfsdklog.WithLevel(hclog.Info),
+				)
+
+				tflog.Debug(ctx, "test message below level")
+				tflog.Info(ctx, "test message")
+			},
+			expected: map[tfsdklog.StatsKey]tfsdklog.StatsCounts{
+				{LoggerName: "test_stats.provider", Level: hclog.Info}: {Emitted: 1},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			collector := tfsdklog.NewStatsCollector()
+
+			ctx := tfsdklog.NewRootSDKLogger(
+				context.Background(),
+				logging.WithOutput(&outputBuffer),
+				tfsdklog.WithLogName("test_stats"),
+				tfsdklog.WithStats(collector),
+			)
+
+			testCase.log(ctx, collector)
+
+			if diff := cmp.Diff(testCase.expected, collector.Snapshot()); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestStats_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	var outputBuffer bytes.Buffer
+	var wg sync.WaitGroup
+
+	collector := tfsdklog.NewStatsCollector()
+
+	ctx := tfsdklog.NewRootSDKLogger(
+		context.Background(),
+		logging.WithOutput(&outputBuffer),
+		tfsdklog.WithLogName("test_stats"),
+		tfsdklog.WithStats(collector),
+	)
+	ctx = tfsdklog.MaskMessageStrings(ctx, "secret")
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				tfsdklog.Trace(ctx, "test message")
+				tfsdklog.Warn(ctx, "test secret message")
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	expected := map[tfsdklog.StatsKey]tfsdklog.StatsCounts{
+		{LoggerName: "test_stats", Level: hclog.Trace}: {Emitted: 1000},
+		{LoggerName: "test_stats", Level: hclog.Warn}:  {Emitted: 1000, Masked: 1000},
+	}
+
+	if diff := cmp.Diff(expected, collector.Snapshot()); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+// RedirectStandardLogging redirects the output of the standard library log
+// package, e.g. log.Printf, to the provider subsystem logger specified in
+// `ctx`, so log output of legacy code and vendored libraries becomes
+// structured log output with the fields, masking, and omission of the
+// subsystem logger. If the subsystem logger was not created with
+// tflog.NewSubsystem, the provider root logger is used.
+//
+// Each log line is logged at the level of its conventional Terraform prefix,
+// such as `[DEBUG]` or `[ERROR]`, which is stripped from the log message. Log
+// lines without a prefix are logged at the info level. The flags and prefix of
+// the standard logger are cleared while redirected, so timestamps and
+// prefixes do not interfere with the level prefix.
+//
+// RedirectStandardLogging returns a function which restores the previous
+// output, flags, and prefix of the standard logger, e.g. for use with
+// t.Cleanup in tests. As the standard logger is global, it should not be
+// redirected by concurrently running tests. If `ctx` has no provider root
+// logger, the standard logger is left unchanged.
+//
+// The standard logger is also left unchanged, with a warning logged, if the
+// logger writes to the standard logger itself, such as when created with the
+// sink of ContextWithStandardLogging, or when its output is the standard
+// logger output after a previous redirection. Its log output would otherwise
+// be written back to itself, which blocks forever. Loggers with an output
+// which calls the standard logger in any other way must never be used.
+func RedirectStandardLogging(ctx context.Context, subsystem string) func() {
+	logger := logging.GetProviderSubsystemLogger(ctx, subsystem)
+	loggerOptions := logging.GetProviderSubsystemLoggerOptions(ctx, subsystem)
+	lOpts := logging.GetProviderSubsystemEffectiveTFLoggerOpts(ctx, subsystem)
+
+	if logger == nil {
+		logger = logging.GetProviderRootLogger(ctx)
+		loggerOptions = logging.GetProviderRootLoggerOptions(ctx)
+		lOpts = logging.GetProviderRootTFLoggerOpts(ctx)
+	}
+
+	if logger == nil {
+		// this essentially should never happen in production
+		// the root logger should be injected by the SDK,
+		// so really this is only likely in unit tests, at most
+		// so just making this a no-op is fine
+		return func() {}
+	}
+
+	stdLogger := log.Default()
+
+	if writesToStandardLogger(ctx, loggerOptions) {
+		logger.Warn("Standard logging not redirected, as the logger writes to the standard logger, which would write the log output back to itself")
+
+		return func() {}
+	}
+
+	previousOutput := stdLogger.Writer()
+	previousFlags := stdLogger.Flags()
+	previousPrefix := stdLogger.Prefix()
+
+	stdLogger.SetFlags(0)
+	stdLogger.SetPrefix("")
+	stdLogger.SetOutput(logging.NewStdlogWriter(ctx, logger, loggerOptions, lOpts, hclog.StandardLoggerOptions{
+		InferLevels: true,
+	}))
+
+	return func() {
+		stdLogger.SetOutput(previousOutput)
+		stdLogger.SetFlags(previousFlags)
+		stdLogger.SetPrefix(previousPrefix)
+	}
+}
+
+// writesToStandardLogger returns true if the logger created with
+// `loggerOptions` in `ctx` writes to the standard logger, either through the
+// sink or through the output of a previous redirection of the standard
+// logger.
+func writesToStandardLogger(ctx context.Context, loggerOptions *hclog.LoggerOptions) bool {
+	if logging.GetSinkWritesToStandardLogger(ctx) {
+		return true
+	}
+
+	if loggerOptions == nil {
+		return false
+	}
+
+	_, ok := loggerOptions.Output.(*logging.StdlogWriter)
+
+	return ok
+}
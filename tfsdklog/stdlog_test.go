@@ -0,0 +1,307 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+//nolint:paralleltest // Modifies the global standard logger
+func TestRedirectStandardLogging(t *testing.T) {
+	testCases := map[string]struct {
+		setup          func(context.Context) context.Context
+		logImpl        func()
+		expectedOutput []map[string]interface{}
+	}{
+		"levels": {
+			setup: func(ctx context.Context) context.Context {
+				return tflog.NewSubsystem(ctx, "legacy")
+			},
+			logImpl: func() {
+				log.Printf("[TRACE] test trace message")
+				log.Printf("[DEBUG] test debug message")
+				log.Printf("[INFO] test info message")
+				log.Printf("[WARN] test warn message")
+				log.Printf("[ERROR] test error message")
+				log.Printf("[ERR] test err message")
+				log.Printf("test message without prefix")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test trace message",
+					"@module":  "provider.legacy",
+				},
+				{
+					"@level":   "debug",
+					"@message": "test debug message",
+					"@module":  "provider.legacy",
+				},
+				{
+					"@level":   "info",
+					"@message": "test info message",
+					"@module":  "provider.legacy",
+				},
+				{
+					"@level":   "warn",
+					"@message": "test warn message",
+					"@module":  "provider.legacy",
+				},
+				{
+					"@level":   "error",
+					"@message": "test error message",
+					"@module":  "provider.legacy",
+				},
+				{
+					"@level":   "error",
+					"@message": "test err message",
+					"@module":  "provider.legacy",
+				},
+				{
+					"@level":   "info",
+					"@message": "test message without prefix",
+					"@module":  "provider.legacy",
+				},
+			},
+		},
+		"subsystem-level": {
+			setup: func(ctx context.Context) context.Context {
+				return tflog.NewSubsystem(ctx, "legacy", tflog.WithLevel(hclog.Info))
+			},
+			logImpl: func() {
+				log.Printf("[DEBUG] test debug message")
+				log.Printf("[INFO] test info message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "info",
+					"@message": "test info message",
+					"@module":  "provider.legacy",
+				},
+			},
+		},
+		"fields-masking-omission": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tflog.NewSubsystem(ctx, "legacy")
+				ctx = tflog.SubsystemSetField(ctx, "legacy", "subsystem-key", "subsystem-value")
+				ctx = tflog.SubsystemMaskMessageStrings(ctx, "legacy", "hunter2")
+				ctx = tflog.SubsystemOmitLogWithMessageStrings(ctx, "legacy", "omitted")
+
+				return ctx
+			},
+			logImpl: func() {
+				log.Printf("[DEBUG] test omitted message")
+				log.Printf("[DEBUG] password is %s", "hunter2")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":        "debug",
+					"@message":      "password is ***",
+					"@module":       "provider.legacy",
+					"subsystem-key": "subsystem-value",
+				},
+			},
+		},
+		"root-fallback": {
+			setup: func(ctx context.Context) context.Context {
+				return tflog.SetField(ctx, "root-key", "root-value")
+			},
+			logImpl: func() {
+				log.Println("[WARN] test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "warn",
+					"@message": "test message",
+					"@module":  "provider",
+					"root-key": "root-value",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.ProviderRoot(ctx, &outputBuffer)
+			ctx = testCase.setup(ctx)
+
+			restore := tfsdklog.RedirectStandardLogging(ctx, "legacy")
+			testCase.logImpl()
+			restore()
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Modifies the global standard logger
+func TestRedirectStandardLogging_Location(t *testing.T) {
+	var outputBuffer bytes.Buffer
+
+	ctx := context.Background()
+	ctx = loggertest.ProviderRootWithLocation(ctx, &outputBuffer)
+	ctx = tflog.NewSubsystem(ctx, "legacy")
+
+	restore := tfsdklog.RedirectStandardLogging(ctx, "legacy")
+	_, _, line, _ := runtime.Caller(0)
+	log.Printf("[DEBUG] test message")
+	restore()
+
+	got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+	if err != nil {
+		t.Fatalf("unable to read multiple line JSON: %s", err)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 log entry, got: %v", got)
+	}
+
+	caller, ok := got[0]["@caller"].(string)
+
+	if !ok {
+		t.Fatalf("expected @caller string field, got: %v", got[0])
+	}
+
+	expectedSuffix := fmt.Sprintf("/tfsdklog/stdlog_test.go:%d", line+1)
+
+	if !strings.HasSuffix(caller, expectedSuffix) {
+		t.Errorf("expected @caller with suffix %q, got: %q", expectedSuffix, caller)
+	}
+}
+
+//nolint:paralleltest // Modifies the global standard logger
+func TestRedirectStandardLogging_Restore(t *testing.T) {
+	var outputBuffer, stdOutputBuffer bytes.Buffer
+
+	previousOutput := log.Writer()
+	previousFlags := log.Flags()
+	previousPrefix := log.Prefix()
+
+	log.SetOutput(&stdOutputBuffer)
+	log.SetFlags(log.Lshortfile)
+	log.SetPrefix("test-prefix: ")
+
+	t.Cleanup(func() {
+		log.SetOutput(previousOutput)
+		log.SetFlags(previousFlags)
+		log.SetPrefix(previousPrefix)
+	})
+
+	ctx := loggertest.ProviderRoot(context.Background(), &outputBuffer)
+
+	restore := tfsdklog.RedirectStandardLogging(ctx, "legacy")
+	log.Print("[INFO] test redirected message")
+	restore()
+
+	log.Print("test restored message")
+
+	if log.Flags() != log.Lshortfile {
+		t.Errorf("expected restored flags %d, got: %d", log.Lshortfile, log.Flags())
+	}
+
+	if !strings.Contains(outputBuffer.String(), "test redirected message") {
+		t.Errorf("expected redirected message in logger output, got: %q", outputBuffer.String())
+	}
+
+	if strings.Contains(outputBuffer.String(), "test restored message") {
+		t.Errorf("unexpected restored message in logger output, got: %q", outputBuffer.String())
+	}
+
+	if !strings.HasPrefix(stdOutputBuffer.String(), "test-prefix: ") || !strings.Contains(stdOutputBuffer.String(), "test restored message") {
+		t.Errorf("expected restored message in standard logger output, got: %q", stdOutputBuffer.String())
+	}
+}
+
+//nolint:paralleltest // Modifies the global standard logger
+func TestRedirectStandardLogging_NoRootLogger(t *testing.T) {
+	previousOutput := log.Writer()
+
+	restore := tfsdklog.RedirectStandardLogging(context.Background(), "legacy")
+	defer restore()
+
+	if log.Writer() != previousOutput {
+		t.Errorf("expected unchanged standard logger output")
+	}
+}
+
+//nolint:paralleltest // Modifies the global standard logger
+func TestRedirectStandardLogging_WritesToStandardLogger(t *testing.T) {
+	testCases := map[string]struct {
+		newContext func(*testing.T, context.Context) context.Context
+	}{
+		"standard-logging-sink": {
+			newContext: func(t *testing.T, ctx context.Context) context.Context {
+				ctx = tfsdklog.ContextWithStandardLogging(ctx, t.Name())
+
+				return tfsdklog.NewRootProviderLogger(ctx)
+			},
+		},
+		"redirected-output": {
+			newContext: func(t *testing.T, ctx context.Context) context.Context {
+				var outputBuffer bytes.Buffer
+
+				restore := tfsdklog.RedirectStandardLogging(loggertest.ProviderRoot(ctx, &outputBuffer), "legacy")
+				t.Cleanup(restore)
+
+				return tfsdklog.NewRootProviderLogger(ctx, logging.WithOutput(log.Writer()))
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var stdOutputBuffer bytes.Buffer
+
+			previousOutput := log.Writer()
+			previousFlags := log.Flags()
+
+			log.SetOutput(&stdOutputBuffer)
+			log.SetFlags(0)
+
+			t.Cleanup(func() {
+				log.SetOutput(previousOutput)
+				log.SetFlags(previousFlags)
+			})
+
+			ctx := testCase.newContext(t, context.Background())
+			expectedOutput := log.Writer()
+
+			restore := tfsdklog.RedirectStandardLogging(ctx, "legacy")
+			defer restore()
+
+			if log.Writer() != expectedOutput {
+				t.Fatalf("expected unchanged standard logger output")
+			}
+
+			// Would block forever if redirected
+			tflog.Info(ctx, "test message")
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
 	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
 )
 
@@ -1908,3 +1909,418 @@ func TestSubsystemMaskLogStrings(t *testing.T) {
 		})
 	}
 }
+
+func TestSubsystems(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		setup    func(context.Context) context.Context
+		expected []string
+	}{
+		"no-root-logger": {
+			setup: func(_ context.Context) context.Context {
+				return context.Background()
+			},
+			expected: nil,
+		},
+		"no-subsystems": {
+			setup: func(ctx context.Context) context.Context {
+				return ctx
+			},
+			expected: []string{},
+		},
+		"subsystems": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tfsdklog.NewSubsystem(ctx, "sub2")
+				ctx = tfsdklog.NewSubsystem(ctx, "sub1")
+				ctx = tfsdklog.NewSubsystem(ctx, "sub1")
+
+				return ctx
+			},
+			expected: []string{"sub1", "sub2"},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.SDKRoot(ctx, &outputBuffer)
+			ctx = testCase.setup(ctx)
+
+			got := tfsdklog.Subsystems(ctx)
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestAutoCreatedSubsystems(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		setup    func(context.Context) context.Context
+		expected []string
+	}{
+		"no-root-logger": {
+			setup: func(_ context.Context) context.Context {
+				return context.Background()
+			},
+			expected: nil,
+		},
+		"no-subsystems": {
+			setup: func(ctx context.Context) context.Context {
+				return ctx
+			},
+			expected: []string{},
+		},
+		"subsystems-created": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tfsdklog.NewSubsystem(ctx, "auto_created_sub1")
+				tfsdklog.SubsystemTrace(ctx, "auto_created_sub1", "test message")
+
+				return ctx
+			},
+			expected: []string{},
+		},
+		"subsystems-auto-created": {
+			setup: func(ctx context.Context) context.Context {
+				// SDK subsystem loggers are only automatically created
+				// when the subsystem level is known, which happens when
+				// the subsystem is created with any root logger.
+				tfsdklog.NewSubsystem(loggertest.SDKRoot(context.Background(), &bytes.Buffer{}), "auto_created_sub2")
+
+				ctx = tfsdklog.NewSubsystem(ctx, "auto_created_sub3")
+				tfsdklog.SubsystemTrace(ctx, "auto_created_sub2", "test message")
+
+				return ctx
+			},
+			expected: []string{"auto_created_sub2"},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.SDKRoot(ctx, &outputBuffer)
+			ctx = testCase.setup(ctx)
+
+			got := tfsdklog.AutoCreatedSubsystems(ctx)
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestHasSubsystem(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		setup    func(context.Context) context.Context
+		expected bool
+	}{
+		"no-root-logger": {
+			setup: func(_ context.Context) context.Context {
+				return tfsdklog.NewSubsystem(context.Background(), testSubsystem)
+			},
+			expected: false,
+		},
+		"no-subsystem": {
+			setup: func(ctx context.Context) context.Context {
+				return ctx
+			},
+			expected: false,
+		},
+		"other-subsystem": {
+			setup: func(ctx context.Context) context.Context {
+				return tfsdklog.NewSubsystem(ctx, "other")
+			},
+			expected: false,
+		},
+		"subsystem": {
+			setup: func(ctx context.Context) context.Context {
+				return tfsdklog.NewSubsystem(ctx, testSubsystem)
+			},
+			expected: true,
+		},
+		"subsystem-auto-created": {
+			setup: func(ctx context.Context) context.Context {
+				tfsdklog.SubsystemTrace(ctx, testSubsystem, "test message")
+
+				return ctx
+			},
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.SDKRoot(ctx, &outputBuffer)
+			ctx = testCase.setup(ctx)
+
+			got := tfsdklog.HasSubsystem(ctx, testSubsystem)
+
+			if got != testCase.expected {
+				t.Errorf("expected %t, got: %t", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewSubsystem_Options(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		subsystem      string
+		options        []logging.Option
+		logMessage     string
+		fields         map[string]interface{}
+		expectedOutput []map[string]interface{}
+	}{
+		"no-options": {
+			subsystem:  "tfsdklog_options_none",
+			logMessage: "test message",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "sdk.tfsdklog_options_none",
+				},
+			},
+		},
+		"log-name": {
+			subsystem:  "tfsdklog_options_log_name",
+			options:    []logging.Option{tfsdklog.WithLogName("custom")},
+			logMessage: "test message",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "custom",
+				},
+			},
+		},
+		"level": {
+			subsystem:      "tfsdklog_options_level",
+			options:        []logging.Option{tfsdklog.WithLevel(hclog.Warn)},
+			logMessage:     "test message",
+			expectedOutput: nil,
+		},
+		"fields": {
+			subsystem: "tfsdklog_options_fields",
+			options: []logging.Option{
+				tfsdklog.WithField("k1", "v1"),
+				tfsdklog.WithFields(map[string]interface{}{"k2": "v2"}),
+			},
+			logMessage: "test message",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "sdk.tfsdklog_options_fields",
+					"k1":       "v1",
+					"k2":       "v2",
+				},
+			},
+		},
+		"omit-rules": {
+			subsystem: "tfsdklog_options_omit",
+			options: []logging.Option{
+				tfsdklog.WithOmitLogWithFieldKeys("k1"),
+				tfsdklog.WithOmitLogWithMessageRegexes(regexp.MustCompile("foo")),
+				tfsdklog.WithOmitLogWithMessageStrings("bar"),
+			},
+			logMessage:     "test message with foo",
+			expectedOutput: nil,
+		},
+		"mask-rules": {
+			subsystem: "tfsdklog_options_mask",
+			options: []logging.Option{
+				tfsdklog.WithMaskFieldValuesWithFieldKeys("k1"),
+				tfsdklog.WithMaskAllFieldValuesRegexes(regexp.MustCompile("v[0-9]")),
+				tfsdklog.WithMaskAllFieldValuesStrings("secret"),
+				tfsdklog.WithMaskMessageRegexes(regexp.MustCompile("foo")),
+				tfsdklog.WithMaskMessageStrings("bar"),
+			},
+			logMessage: "test message with foo and bar",
+			fields: map[string]interface{}{
+				"k1": "value",
+				"k2": "v2",
+				"k3": "a secret value",
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message with *** and ***",
+					"@module":  "sdk.tfsdklog_options_mask",
+					"k1":       "***",
+					"k2":       "***",
+					"k3":       "a *** value",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.SDKRoot(ctx, &outputBuffer)
+			ctx = tfsdklog.NewSubsystem(ctx, testCase.subsystem, testCase.options...)
+
+			tfsdklog.SubsystemTrace(ctx, testCase.subsystem, testCase.logMessage, testCase.fields)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestNewSubsystem_Nested(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		setup          func(context.Context) context.Context
+		subsystem      string
+		logMessage     string
+		expectedOutput []map[string]interface{}
+	}{
+		"no-parent": {
+			setup: func(ctx context.Context) context.Context {
+				return tfsdklog.NewSubsystem(ctx, "tfsdklog_nested_none.child")
+			},
+			subsystem:  "tfsdklog_nested_none.child",
+			logMessage: "test message",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "sdk.tfsdklog_nested_none.child",
+				},
+			},
+		},
+		"parent": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tfsdklog.NewSubsystem(ctx, "tfsdklog_nested_parent",
+					tfsdklog.WithField("parent-key", "parent-value"),
+					tfsdklog.WithMaskMessageStrings("secret"),
+				)
+
+				return tfsdklog.NewSubsystem(ctx, "tfsdklog_nested_parent.child",
+					tfsdklog.WithField("child-key", "child-value"),
+				)
+			},
+			subsystem:  "tfsdklog_nested_parent.child",
+			logMessage: "test message with secret",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":     "trace",
+					"@message":   "test message with ***",
+					"@module":    "sdk.tfsdklog_nested_parent.child",
+					"child-key":  "child-value",
+					"parent-key": "parent-value",
+				},
+			},
+		},
+		"parent-level": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tfsdklog.NewSubsystem(ctx, "tfsdklog_nested_level", tfsdklog.WithLevel(hclog.Warn))
+
+				return tfsdklog.NewSubsystem(ctx, "tfsdklog_nested_level.child")
+			},
+			subsystem:      "tfsdklog_nested_level.child",
+			logMessage:     "test message",
+			expectedOutput: nil,
+		},
+		"grandparent": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tfsdklog.NewSubsystem(ctx, "tfsdklog_nested_grandparent", tfsdklog.WithField("grandparent-key", "grandparent-value"))
+
+				return tfsdklog.NewSubsystem(ctx, "tfsdklog_nested_grandparent.parent.child")
+			},
+			subsystem:  "tfsdklog_nested_grandparent.parent.child",
+			logMessage: "test message",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":          "trace",
+					"@message":        "test message",
+					"@module":         "sdk.tfsdklog_nested_grandparent.parent.child",
+					"grandparent-key": "grandparent-value",
+				},
+			},
+		},
+		"parent-fields-not-modified": {
+			setup: func(ctx context.Context) context.Context {
+				ctx = tfsdklog.NewSubsystem(ctx, "tfsdklog_nested_unmodified", tfsdklog.WithField("parent-key", "parent-value"))
+				ctx = tfsdklog.NewSubsystem(ctx, "tfsdklog_nested_unmodified.child", tfsdklog.WithField("child-key", "child-value"))
+
+				return ctx
+			},
+			subsystem:  "tfsdklog_nested_unmodified",
+			logMessage: "test message",
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":     "trace",
+					"@message":   "test message",
+					"@module":    "sdk.tfsdklog_nested_unmodified",
+					"parent-key": "parent-value",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := context.Background()
+			ctx = loggertest.SDKRoot(ctx, &outputBuffer)
+			ctx = testCase.setup(ctx)
+
+			tfsdklog.SubsystemTrace(ctx, testCase.subsystem, testCase.logMessage)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tfsdkloggrpc provides gRPC server interceptors which set up
+// request-scoped SDK logging fields and write the start, finish, and errors of
+// each RPC as log output of an SDK subsystem logger.
+//
+// This package is intended for SDKs and other libraries serving the Terraform
+// plugin protocol, providers should never need to use it.
+package tfsdkloggrpc
@@ -0,0 +1,146 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdkloggrpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// EnvTfLogSDK is the prefix of the environment variables setting the
+	// level of the subsystem logger created by the interceptors. The
+	// environment variable is EnvTfLogSDK, followed by the subsystem name in
+	// all caps with periods replaced by underscores, e.g. TF_LOG_SDK_GRPC.
+	EnvTfLogSDK = "TF_LOG_SDK"
+
+	// FieldRPC is the field key for the full gRPC method name of the RPC,
+	// e.g. /tfplugin6.Provider/GetProviderSchema.
+	FieldRPC = "tf_rpc"
+
+	// FieldRequestID is the field key for the correlation ID of the RPC, as
+	// set by tfsdklog.ContextWithRequestID.
+	FieldRequestID = tfsdklog.RequestIDFieldKey
+
+	// FieldRequestDurationMs is the field key for the number of milliseconds
+	// it took to serve the RPC.
+	FieldRequestDurationMs = "tf_req_duration_ms"
+
+	// FieldGRPCCode is the field key for the gRPC status code of an RPC
+	// which returned an error, e.g. NotFound.
+	FieldGRPCCode = "tf_grpc_code"
+
+	// FieldError is the field key for the error returned by an RPC.
+	FieldError = "error"
+)
+
+// UnaryServerInterceptor returns a grpc.UnaryServerInterceptor which calls
+// the handler with a context.Context containing the FieldRPC field in the root
+// SDK logger and the subsystem logger, and a correlation ID created with
+// tfsdklog.ContextWithRequestID as the FieldRequestID field in the root SDK and
+// provider loggers and the subsystem logger. It writes the start, finish, and
+// error of the RPC as log output of the subsystem logger.
+//
+// If the context.Context does not contain the subsystem logger, e.g. created
+// with WithContextInit, it is created with the level of the EnvTfLogSDK
+// environment variable for the subsystem, or the level of the root SDK
+// logger. Otherwise the level of the existing subsystem logger is honored.
+func UnaryServerInterceptor(options ...Option) grpc.UnaryServerInterceptor {
+	opts := newInterceptorOpts(options...)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = opts.requestContext(ctx, info.FullMethod)
+		start := time.Now()
+
+		tfsdklog.SubsystemTrace(ctx, opts.subsystem, "Received request")
+
+		resp, err := handler(ctx, req)
+
+		opts.logResult(ctx, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a grpc.StreamServerInterceptor with the
+// same behavior as UnaryServerInterceptor, where the Context method of the
+// grpc.ServerStream passed to the handler returns the context.Context with
+// the logging fields.
+func StreamServerInterceptor(options ...Option) grpc.StreamServerInterceptor {
+	opts := newInterceptorOpts(options...)
+
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := opts.requestContext(stream.Context(), info.FullMethod)
+		start := time.Now()
+
+		tfsdklog.SubsystemTrace(ctx, opts.subsystem, "Received request")
+
+		err := handler(srv, &serverStream{
+			ServerStream: stream,
+			ctx:          ctx,
+		})
+
+		opts.logResult(ctx, start, err)
+
+		return err
+	}
+}
+
+// requestContext returns the context.Context of an RPC with the logging
+// fields of the RPC and the subsystem logger.
+func (o interceptorOpts) requestContext(ctx context.Context, method string) context.Context {
+	if o.contextInit != nil {
+		ctx = o.contextInit(ctx)
+	}
+
+	ctx = tfsdklog.ContextWithRequestID(ctx)
+	ctx = tfsdklog.SetField(ctx, FieldRPC, method)
+	requestID := tfsdklog.RequestID(ctx)
+
+	if !tfsdklog.HasSubsystem(ctx, o.subsystem) {
+		ctx = tfsdklog.NewSubsystem(ctx, o.subsystem, tfsdklog.WithLevelFromEnv(EnvTfLogSDK, strings.Split(o.subsystem, ".")...))
+	}
+
+	ctx = tfsdklog.SubsystemSetField(ctx, o.subsystem, FieldRPC, method)
+	ctx = tfsdklog.SubsystemSetField(ctx, o.subsystem, FieldRequestID, requestID)
+
+	return ctx
+}
+
+// logResult writes the finish or error of an RPC started at `start` as log
+// output of the subsystem logger.
+func (o interceptorOpts) logResult(ctx context.Context, start time.Time, err error) {
+	duration := time.Since(start)
+
+	if err != nil {
+		tfsdklog.SubsystemError(ctx, o.subsystem, "Error serving request", map[string]interface{}{
+			FieldRequestDurationMs: duration.Milliseconds(),
+			FieldGRPCCode:          status.Code(err).String(),
+			FieldError:             err.Error(),
+		})
+
+		return
+	}
+
+	tfsdklog.SubsystemTrace(ctx, o.subsystem, "Served request", map[string]interface{}{
+		FieldRequestDurationMs: duration.Milliseconds(),
+	})
+}
+
+// serverStream is a grpc.ServerStream with a different context.Context.
+type serverStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+// Context returns the context.Context with the logging fields of the RPC.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
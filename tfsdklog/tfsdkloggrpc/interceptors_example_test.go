@@ -0,0 +1,30 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdkloggrpc_test
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog/tfsdkloggrpc"
+	"google.golang.org/grpc"
+)
+
+func ExampleUnaryServerInterceptor() {
+	options := []tfsdkloggrpc.Option{
+		// Create the root SDK logger for each RPC
+		tfsdkloggrpc.WithContextInit(func(ctx context.Context) context.Context {
+			return tfsdklog.NewRootSDKLogger(ctx, tfsdklog.WithLevelFromEnv("TF_LOG_SDK"))
+		}),
+		tfsdkloggrpc.WithSubsystem("proto"),
+	}
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(tfsdkloggrpc.UnaryServerInterceptor(options...)),
+		grpc.StreamInterceptor(tfsdkloggrpc.StreamServerInterceptor(options...)),
+	)
+
+	// Register services and serve
+	_ = server
+}
@@ -0,0 +1,358 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdkloggrpc_test
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog/tfsdkloggrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use, as the log output is
+// written by the gRPC server goroutines.
+type syncBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) Bytes() []byte {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return bytes.Clone(b.buffer.Bytes())
+}
+
+// waitForLines waits until at least `lines` lines were written.
+func (b *syncBuffer) waitForLines(t *testing.T, lines int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for bytes.Count(b.Bytes(), []byte("\n")) < lines {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d log lines, got: %s", lines, b.Bytes())
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// newTestClient starts an in-process gRPC health server with the interceptors,
+// followed by the given interceptors, and returns a client connected to it.
+func newTestClient(t *testing.T, options []tfsdkloggrpc.Option, unary grpc.UnaryServerInterceptor, stream grpc.StreamServerInterceptor) healthpb.HealthClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(tfsdkloggrpc.UnaryServerInterceptor(options...), unary),
+		grpc.ChainStreamInterceptor(tfsdkloggrpc.StreamServerInterceptor(options...), stream),
+	)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("test-service", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+
+	return healthpb.NewHealthClient(conn)
+}
+
+func TestInterceptors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		subsystem      string
+		subsystemLevel hclog.Level
+		call           func(context.Context, healthpb.HealthClient) error
+		expectedOutput []map[string]interface{}
+	}{
+		"unary": {
+			subsystem: "test_grpc_unary",
+			call: func(ctx context.Context, client healthpb.HealthClient) error {
+				_, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "test-service"})
+
+				return err
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                    "trace",
+					"@message":                  "Received request",
+					"@module":                   "sdk.test_grpc_unary",
+					tfsdkloggrpc.FieldRPC:       "/grpc.health.v1.Health/Check",
+					tfsdkloggrpc.FieldRequestID: "REQUEST_ID",
+				},
+				{
+					"@level":                    "debug",
+					"@message":                  "handler message",
+					"@module":                   "sdk",
+					tfsdkloggrpc.FieldRPC:       "/grpc.health.v1.Health/Check",
+					tfsdkloggrpc.FieldRequestID: "REQUEST_ID",
+				},
+				{
+					"@level":                            "trace",
+					"@message":                          "Served request",
+					"@module":                           "sdk.test_grpc_unary",
+					tfsdkloggrpc.FieldRPC:               "/grpc.health.v1.Health/Check",
+					tfsdkloggrpc.FieldRequestID:         "REQUEST_ID",
+					tfsdkloggrpc.FieldRequestDurationMs: "DURATION",
+				},
+			},
+		},
+		"unary-error": {
+			subsystem: "test_grpc_unary_error",
+			call: func(ctx context.Context, client healthpb.HealthClient) error {
+				_, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown-service"})
+
+				if err == nil {
+					t.Error("expected error")
+				}
+
+				return nil
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                    "trace",
+					"@message":                  "Received request",
+					"@module":                   "sdk.test_grpc_unary_error",
+					tfsdkloggrpc.FieldRPC:       "/grpc.health.v1.Health/Check",
+					tfsdkloggrpc.FieldRequestID: "REQUEST_ID",
+				},
+				{
+					"@level":                    "debug",
+					"@message":                  "handler message",
+					"@module":                   "sdk",
+					tfsdkloggrpc.FieldRPC:       "/grpc.health.v1.Health/Check",
+					tfsdkloggrpc.FieldRequestID: "REQUEST_ID",
+				},
+				{
+					"@level":                            "error",
+					"@message":                          "Error serving request",
+					"@module":                           "sdk.test_grpc_unary_error",
+					tfsdkloggrpc.FieldRPC:               "/grpc.health.v1.Health/Check",
+					tfsdkloggrpc.FieldRequestID:         "REQUEST_ID",
+					tfsdkloggrpc.FieldRequestDurationMs: "DURATION",
+					tfsdkloggrpc.FieldGRPCCode:          "NotFound",
+					tfsdkloggrpc.FieldError:             "rpc error: code = NotFound desc = unknown service",
+				},
+			},
+		},
+		"unary-subsystem-level": {
+			subsystem:      "test_grpc_unary_level",
+			subsystemLevel: hclog.Error,
+			call: func(ctx context.Context, client healthpb.HealthClient) error {
+				_, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "test-service"})
+
+				if err != nil {
+					return err
+				}
+
+				_, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown-service"})
+
+				if err == nil {
+					t.Error("expected error")
+				}
+
+				return nil
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                    "debug",
+					"@message":                  "handler message",
+					"@module":                   "sdk",
+					tfsdkloggrpc.FieldRPC:       "/grpc.health.v1.Health/Check",
+					tfsdkloggrpc.FieldRequestID: "REQUEST_ID",
+				},
+				{
+					"@level":                    "debug",
+					"@message":                  "handler message",
+					"@module":                   "sdk",
+					tfsdkloggrpc.FieldRPC:       "/grpc.health.v1.Health/Check",
+					tfsdkloggrpc.FieldRequestID: "REQUEST_ID",
+				},
+				{
+					"@level":                            "error",
+					"@message":                          "Error serving request",
+					"@module":                           "sdk.test_grpc_unary_level",
+					tfsdkloggrpc.FieldRPC:               "/grpc.health.v1.Health/Check",
+					tfsdkloggrpc.FieldRequestID:         "REQUEST_ID",
+					tfsdkloggrpc.FieldRequestDurationMs: "DURATION",
+					tfsdkloggrpc.FieldGRPCCode:          "NotFound",
+					tfsdkloggrpc.FieldError:             "rpc error: code = NotFound desc = unknown service",
+				},
+			},
+		},
+		"stream": {
+			subsystem: "test_grpc_stream",
+			call: func(ctx context.Context, client healthpb.HealthClient) error {
+				ctx, cancel := context.WithCancel(ctx)
+				defer cancel()
+
+				stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "test-service"})
+
+				if err != nil {
+					return err
+				}
+
+				if _, err := stream.Recv(); err != nil {
+					return err
+				}
+
+				cancel()
+
+				// Wait for the server to finish the stream
+				for {
+					if _, err := stream.Recv(); err != nil {
+						return nil
+					}
+				}
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":                    "trace",
+					"@message":                  "Received request",
+					"@module":                   "sdk.test_grpc_stream",
+					tfsdkloggrpc.FieldRPC:       "/grpc.health.v1.Health/Watch",
+					tfsdkloggrpc.FieldRequestID: "REQUEST_ID",
+				},
+				{
+					"@level":                    "debug",
+					"@message":                  "handler message",
+					"@module":                   "sdk",
+					tfsdkloggrpc.FieldRPC:       "/grpc.health.v1.Health/Watch",
+					tfsdkloggrpc.FieldRequestID: "REQUEST_ID",
+				},
+				{
+					"@level":                            "error",
+					"@message":                          "Error serving request",
+					"@module":                           "sdk.test_grpc_stream",
+					tfsdkloggrpc.FieldRPC:               "/grpc.health.v1.Health/Watch",
+					tfsdkloggrpc.FieldRequestID:         "REQUEST_ID",
+					tfsdkloggrpc.FieldRequestDurationMs: "DURATION",
+					tfsdkloggrpc.FieldGRPCCode:          "Canceled",
+					tfsdkloggrpc.FieldError:             "CANCELED",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer syncBuffer
+
+			contextInit := func(ctx context.Context) context.Context {
+				ctx = loggertest.SDKRoot(ctx, &outputBuffer)
+
+				if testCase.subsystemLevel != hclog.NoLevel {
+					ctx = tfsdklog.NewSubsystem(ctx, testCase.subsystem, tfsdklog.WithLevel(testCase.subsystemLevel))
+				}
+
+				return ctx
+			}
+
+			// Log through the root SDK logger in the context of the RPC,
+			// verifying the request-scoped fields.
+			handlerLogger := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				tfsdklog.Debug(ctx, "handler message")
+
+				return handler(ctx, req)
+			}
+
+			streamHandlerLogger := func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				tfsdklog.Debug(stream.Context(), "handler message")
+
+				return handler(srv, stream)
+			}
+
+			options := []tfsdkloggrpc.Option{
+				tfsdkloggrpc.WithContextInit(contextInit),
+				tfsdkloggrpc.WithSubsystem(testCase.subsystem),
+			}
+
+			client := newTestClient(t, options, handlerLogger, streamHandlerLogger)
+
+			if err := testCase.call(context.Background(), client); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			// Streams can finish on the server after the client returns
+			outputBuffer.waitForLines(t, len(testCase.expectedOutput))
+
+			got, err := loggertest.MultilineJSONDecode(bytes.NewReader(outputBuffer.Bytes()))
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			for _, entry := range got {
+				requestID, ok := entry[tfsdkloggrpc.FieldRequestID].(string)
+
+				if !ok || !uuidRegexp.MatchString(requestID) {
+					t.Errorf("expected UUID %s field, got: %v", tfsdkloggrpc.FieldRequestID, entry)
+				}
+
+				entry[tfsdkloggrpc.FieldRequestID] = "REQUEST_ID"
+
+				// The description of canceled streams depends on timing
+				if entry[tfsdkloggrpc.FieldGRPCCode] == "Canceled" {
+					entry[tfsdkloggrpc.FieldError] = "CANCELED"
+				}
+
+				if _, ok := entry[tfsdkloggrpc.FieldRequestDurationMs].(float64); ok {
+					entry[tfsdkloggrpc.FieldRequestDurationMs] = "DURATION"
+				}
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
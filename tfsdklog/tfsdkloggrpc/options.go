@@ -0,0 +1,52 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdkloggrpc
+
+import (
+	"context"
+)
+
+// DefaultSubsystem is the SDK subsystem the interceptors write log output to,
+// unless configured with WithSubsystem.
+const DefaultSubsystem = "grpc"
+
+// Option configures the interceptors.
+type Option func(*interceptorOpts)
+
+// interceptorOpts is the configuration of the interceptors.
+type interceptorOpts struct {
+	contextInit func(context.Context) context.Context
+	subsystem   string
+}
+
+// newInterceptorOpts applies `options` to the default configuration.
+func newInterceptorOpts(options ...Option) interceptorOpts {
+	opts := interceptorOpts{
+		subsystem: DefaultSubsystem,
+	}
+
+	for _, option := range options {
+		option(&opts)
+	}
+
+	return opts
+}
+
+// WithContextInit returns an option that will call `contextInit` with the
+// context.Context of each RPC before anything else, e.g. to create the root
+// SDK logger with tfsdklog.NewRootSDKLogger, as the context.Context of an RPC
+// does not contain any loggers by default.
+func WithContextInit(contextInit func(context.Context) context.Context) Option {
+	return func(opts *interceptorOpts) {
+		opts.contextInit = contextInit
+	}
+}
+
+// WithSubsystem returns an option that will write log output to the SDK
+// subsystem `subsystem`, rather than DefaultSubsystem.
+func WithSubsystem(subsystem string) Option {
+	return func(opts *interceptorOpts) {
+		opts.subsystem = subsystem
+	}
+}
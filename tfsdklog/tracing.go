@@ -0,0 +1,50 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog
+
+import (
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+)
+
+// TraceContextExtractor extracts the IDs of the active trace and span from
+// the context.Context passed to the logging functions, which are then included
+// as the trace_id and span_id fields in the log output. This allows linking
+// log output with traces, without this module depending on a specific tracing
+// library. For example, an OpenTelemetry based implementation could be:
+//
+//	type otelExtractor struct{}
+//
+//	func (otelExtractor) ExtractTraceContext(ctx context.Context) (string, string, bool) {
+//		spanContext := trace.SpanContextFromContext(ctx)
+//		if !spanContext.IsValid() {
+//			return "", "", false
+//		}
+//
+//		return spanContext.TraceID().String(), spanContext.SpanID().String(), true
+//	}
+//
+// A TraceContextExtractor is configured with the WithTraceContextExtractor
+// option of NewRootSDKLogger and NewRootProviderLogger, and can be overridden
+// for a subsystem logger with the same option of NewSubsystem.
+type TraceContextExtractor = logging.TraceContextExtractor
+
+// SpanEvent is a log entry recorded as an event on the active trace span by a
+// SpanEventBridge. The message and fields are masked.
+type SpanEvent = logging.SpanEvent
+
+// SpanEventBridge records log entries as events on the active trace span in
+// the context.Context passed to the logging functions, with log entries at the
+// error level also setting the status of the span. This allows viewing log
+// output alongside traces, without this module depending on a specific
+// tracing library.
+//
+// Log entries are only recorded when they are not omitted and would be
+// written by the logger at its level. Masking is always applied before,
+// including to the entries returned by EntryHooks, so masked values never
+// reach the tracing backend.
+//
+// A SpanEventBridge is configured with the WithSpanEventBridge option of
+// NewRootSDKLogger and NewRootProviderLogger, and can be overridden for a
+// subsystem logger with the same option of NewSubsystem.
+type SpanEventBridge = logging.SpanEventBridge
@@ -0,0 +1,417 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklog_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/internal/loggertest"
+	"github.com/hashicorp/terraform-plugin-log/internal/logging"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklogtest"
+)
+
+// staticTraceContextExtractor is a TraceContextExtractor always returning the
+// same trace and span IDs.
+type staticTraceContextExtractor struct {
+	traceID string
+	spanID  string
+}
+
+func (e staticTraceContextExtractor) ExtractTraceContext(_ context.Context) (string, string, bool) {
+	return e.traceID, e.spanID, true
+}
+
+func TestWithTraceContextExtractor(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		extractor      tfsdklog.TraceContextExtractor
+		span           *loggertest.Span
+		log            func(context.Context)
+		expectedOutput []map[string]interface{}
+	}{
+		"no-extractor": {
+			span: &loggertest.Span{TraceID: "trace1", SpanID: "span1"},
+			log: func(ctx context.Context) {
+				tfsdklog.Trace(ctx, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "sdk",
+				},
+			},
+		},
+		"no-span": {
+			extractor: loggertest.TraceContextExtractor{},
+			log: func(ctx context.Context) {
+				tfsdklog.Trace(ctx, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "sdk",
+				},
+			},
+		},
+		"root": {
+			extractor: loggertest.TraceContextExtractor{},
+			span:      &loggertest.Span{TraceID: "trace1", SpanID: "span1"},
+			log: func(ctx context.Context) {
+				tfsdklog.Trace(ctx, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "sdk",
+					"span_id":  "span1",
+					"trace_id": "trace1",
+				},
+			},
+		},
+		"root-field-precedence": {
+			extractor: loggertest.TraceContextExtractor{},
+			span:      &loggertest.Span{TraceID: "trace1", SpanID: "span1"},
+			log: func(ctx context.Context) {
+				tfsdklog.Trace(ctx, "test message", map[string]interface{}{"trace_id": "trace2"})
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "sdk",
+					"span_id":  "span1",
+					"trace_id": "trace2",
+				},
+			},
+		},
+		"root-logger-field-precedence": {
+			extractor: loggertest.TraceContextExtractor{},
+			span:      &loggertest.Span{TraceID: "trace1", SpanID: "span1"},
+			log: func(ctx context.Context) {
+				ctx = tfsdklog.SetField(ctx, "span_id", "span2")
+
+				tfsdklog.Trace(ctx, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "sdk",
+					"span_id":  "span2",
+					"trace_id": "trace1",
+				},
+			},
+		},
+		"root-masked": {
+			extractor: loggertest.TraceContextExtractor{},
+			span:      &loggertest.Span{TraceID: "trace1", SpanID: "span1"},
+			log: func(ctx context.Context) {
+				ctx = tfsdklog.MaskFieldValuesWithFieldKeys(ctx, "span_id")
+
+				tfsdklog.Trace(ctx, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "sdk",
+					"span_id":  "***",
+					"trace_id": "trace1",
+				},
+			},
+		},
+		"subsystem-inherited": {
+			extractor: loggertest.TraceContextExtractor{},
+			span:      &loggertest.Span{TraceID: "trace1", SpanID: "span1"},
+			log: func(ctx context.Context) {
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem)
+
+				tfsdklog.SubsystemTrace(ctx, testSubsystem, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  testSubsystemModule,
+					"span_id":  "span1",
+					"trace_id": "trace1",
+				},
+			},
+		},
+		"subsystem-logger-field-precedence": {
+			extractor: loggertest.TraceContextExtractor{},
+			span:      &loggertest.Span{TraceID: "trace1", SpanID: "span1"},
+			log: func(ctx context.Context) {
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem)
+				ctx = tfsdklog.SubsystemSetField(ctx, testSubsystem, "trace_id", "trace2")
+
+				tfsdklog.SubsystemTrace(ctx, testSubsystem, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  testSubsystemModule,
+					"span_id":  "span1",
+					"trace_id": "trace2",
+				},
+			},
+		},
+		"subsystem-override": {
+			extractor: loggertest.TraceContextExtractor{},
+			span:      &loggertest.Span{TraceID: "trace1", SpanID: "span1"},
+			log: func(ctx context.Context) {
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem, tfsdklog.WithTraceContextExtractor(staticTraceContextExtractor{
+					traceID: "trace2",
+					spanID:  "span2",
+				}))
+
+				tfsdklog.SubsystemTrace(ctx, testSubsystem, "test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  testSubsystemModule,
+					"span_id":  "span2",
+					"trace_id": "trace2",
+				},
+			},
+		},
+		"logger-handle": {
+			extractor: loggertest.TraceContextExtractor{},
+			span:      &loggertest.Span{TraceID: "trace1", SpanID: "span1"},
+			log: func(ctx context.Context) {
+				tfsdklog.Logger(ctx).Trace("test message")
+			},
+			expectedOutput: []map[string]interface{}{
+				{
+					"@level":   "trace",
+					"@message": "test message",
+					"@module":  "sdk",
+					"span_id":  "span1",
+					"trace_id": "trace1",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			ctx := tfsdklog.NewRootSDKLogger(
+				context.Background(),
+				logging.WithoutLocation(),
+				logging.WithoutTimestamp(),
+				logging.WithOutput(&outputBuffer),
+				tfsdklog.WithTraceContextExtractor(testCase.extractor),
+			)
+
+			if testCase.span != nil {
+				ctx = loggertest.ContextWithSpan(ctx, *testCase.span)
+			}
+
+			testCase.log(ctx)
+
+			got, err := loggertest.MultilineJSONDecode(&outputBuffer)
+
+			if err != nil {
+				t.Fatalf("unable to read multiple line JSON: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedOutput, got); diff != "" {
+				t.Errorf("unexpected output difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestWithSpanEventBridge(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		log            func(context.Context)
+		expectedEvents []tfsdklog.SpanEvent
+		expectedErrors []string
+	}{
+		"no-logs": {
+			log:            func(_ context.Context) {},
+			expectedEvents: nil,
+			expectedErrors: nil,
+		},
+		"root": {
+			log: func(ctx context.Context) {
+				ctx = tfsdklog.SetField(ctx, "k1", "v1")
+
+				tfsdklog.Debug(ctx, "test message", map[string]interface{}{"k2": "v2"})
+			},
+			expectedEvents: []tfsdklog.SpanEvent{
+				{
+					Level:      hclog.Debug,
+					LoggerName: "sdk",
+					Message:    "test message",
+					Fields: map[string]interface{}{
+						"k1": "v1",
+						"k2": "v2",
+					},
+				},
+			},
+			expectedErrors: nil,
+		},
+		"root-error": {
+			log: func(ctx context.Context) {
+				tfsdklog.Error(ctx, "test error message")
+			},
+			expectedEvents: []tfsdklog.SpanEvent{
+				{
+					Level:      hclog.Error,
+					LoggerName: "sdk",
+					Message:    "test error message",
+					Fields:     map[string]interface{}{},
+				},
+			},
+			expectedErrors: []string{"test error message"},
+		},
+		"root-masked": {
+			log: func(ctx context.Context) {
+				ctx = tfsdklog.MaskFieldValuesWithFieldKeys(ctx, "k1")
+				ctx = tfsdklog.MaskMessageStrings(ctx, "secret")
+
+				tfsdklog.Error(ctx, "test secret message", map[string]interface{}{"k1": "secret-value"})
+			},
+			expectedEvents: []tfsdklog.SpanEvent{
+				{
+					Level:      hclog.Error,
+					LoggerName: "sdk",
+					Message:    "test *** message",
+					Fields: map[string]interface{}{
+						"k1": "***",
+					},
+				},
+			},
+			expectedErrors: []string{"test *** message"},
+		},
+		"root-omitted": {
+			log: func(ctx context.Context) {
+				ctx = tfsdklog.OmitLogWithMessageStrings(ctx, "omitted")
+
+				tfsdklog.Error(ctx, "test omitted message")
+			},
+			expectedEvents: nil,
+			expectedErrors: nil,
+		},
+		"subsystem-inherited": {
+			log: func(ctx context.Context) {
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem)
+
+				tfsdklog.SubsystemWarn(ctx, testSubsystem, "test message")
+			},
+			expectedEvents: []tfsdklog.SpanEvent{
+				{
+					Level:      hclog.Warn,
+					LoggerName: testSubsystemModule,
+					Message:    "test message",
+					Fields:     map[string]interface{}{},
+				},
+			},
+			expectedErrors: nil,
+		},
+		"subsystem-hook-masked": {
+			log: func(ctx context.Context) {
+				hook := tfsdklog.EntryHookFunc(func(_ context.Context, entry tfsdklog.Entry) (tfsdklog.Entry, bool) {
+					entry.Message += " with hook-secret"
+					entry.Fields["token"] = "hook-secret"
+
+					return entry, true
+				})
+
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem,
+					tfsdklog.WithEntryHooks(hook),
+					tfsdklog.WithMaskFieldValuesWithFieldKeys("token"),
+					tfsdklog.WithMaskMessageStrings("hook-secret"),
+				)
+
+				tfsdklog.SubsystemError(ctx, testSubsystem, "test message")
+			},
+			expectedEvents: []tfsdklog.SpanEvent{
+				{
+					Level:      hclog.Error,
+					LoggerName: testSubsystemModule,
+					Message:    "test message with ***",
+					Fields: map[string]interface{}{
+						"token": "***",
+					},
+				},
+			},
+			expectedErrors: []string{"test message with ***"},
+		},
+		"subsystem-level": {
+			log: func(ctx context.Context) {
+				ctx = tfsdklog.NewSubsystem(ctx, testSubsystem, tfsdklog.WithLevel(hclog.Warn))
+
+				tfsdklog.SubsystemInfo(ctx, testSubsystem, "test message")
+			},
+			expectedEvents: nil,
+			expectedErrors: nil,
+		},
+		"logger-handle": {
+			log: func(ctx context.Context) {
+				tfsdklog.Logger(ctx).With("k1", "v1").Info("test message")
+			},
+			expectedEvents: []tfsdklog.SpanEvent{
+				{
+					Level:      hclog.Info,
+					LoggerName: "sdk",
+					Message:    "test message",
+					Fields: map[string]interface{}{
+						"k1": "v1",
+					},
+				},
+			},
+			expectedErrors: nil,
+		},
+	}
+
+	for name, testCase := range testCases {
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var outputBuffer bytes.Buffer
+
+			bridge := &tfsdklogtest.SpanEventBridge{}
+
+			ctx := tfsdklog.NewRootSDKLogger(
+				context.Background(),
+				logging.WithoutLocation(),
+				logging.WithoutTimestamp(),
+				logging.WithOutput(&outputBuffer),
+				tfsdklog.WithSpanEventBridge(bridge),
+			)
+
+			testCase.log(ctx)
+
+			if diff := cmp.Diff(testCase.expectedEvents, bridge.Events()); diff != "" {
+				t.Errorf("unexpected events difference: %s", diff)
+			}
+
+			if diff := cmp.Diff(testCase.expectedErrors, bridge.Errors()); diff != "" {
+				t.Errorf("unexpected errors difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright IBM Corp. 2021, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfsdklogtest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+// SpanEventBridge is an in-memory tfsdklog.SpanEventBridge suitable for unit
+// testing, which records all span events and errors regardless of the active
+// span. It is safe for concurrent use.
+type SpanEventBridge struct {
+	// mutex protects events and errors from concurrent read and write
+	// panics.
+	mutex sync.Mutex
+
+	events []tfsdklog.SpanEvent
+	errors []string
+}
+
+// AddSpanEvent records the span event.
+func (b *SpanEventBridge) AddSpanEvent(_ context.Context, event tfsdklog.SpanEvent) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.events = append(b.events, event)
+}
+
+// SetSpanError records the span error description.
+func (b *SpanEventBridge) SetSpanError(_ context.Context, description string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.errors = append(b.errors, description)
+}
+
+// Events returns a copy of all recorded span events, in order.
+func (b *SpanEventBridge) Events() []tfsdklog.SpanEvent {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.events == nil {
+		return nil
+	}
+
+	result := make([]tfsdklog.SpanEvent, len(b.events))
+
+	copy(result, b.events)
+
+	return result
+}
+
+// Errors returns a copy of all recorded span error descriptions, in order.
+func (b *SpanEventBridge) Errors() []string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.errors == nil {
+		return nil
+	}
+
+	result := make([]string, len(b.errors))
+
+	copy(result, b.errors)
+
+	return result
+}